@@ -7,6 +7,7 @@ import (
 	"github.com/tommzn/go-config"
 	"github.com/tommzn/go-log"
 	"github.com/tommzn/go-secrets"
+	"github.com/tommzn/utte-universe/core"
 )
 
 func bootstrap() (config.Config, secrets.SecretsManager, log.Logger, context.Context) {
@@ -18,28 +19,39 @@ func bootstrap() (config.Config, secrets.SecretsManager, log.Logger, context.Con
 	return conf, secretsManager, logger, ctx
 }
 
+// loadConfig loads config from S3, a local file or defaults, see core.LoadServiceConfig.
 func loadConfig() config.Config {
 
-	configSource, err := config.NewS3ConfigSourceFromEnv()
-	if err != nil {
-		panic(err)
-	}
-
-	conf, err := configSource.Load()
+	conf, err := core.LoadServiceConfig()
 	if err != nil {
 		panic(err)
 	}
 	return conf
 }
 
+// newSecretsManager reads secrets mounted by Docker or K8s at SECRETS_PATH, /run/secrets/token by default.
+// If there are no mounted secrets, e.g. running locally, secrets are read from environment variables.
 func newSecretsManager() secrets.SecretsManager {
-	secretsManager := secrets.NewDockerecretsManager("/run/secrets/token")
+	secretsPath := os.Getenv("SECRETS_PATH")
+	if secretsPath == "" {
+		secretsPath = "/run/secrets/token"
+	}
+	if _, err := os.Stat(secretsPath); err != nil {
+		return secrets.NewSecretsManager()
+	}
+	secretsManager := secrets.NewDockerecretsManager(secretsPath)
 	secrets.ExportToEnvironment([]string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"}, secretsManager)
 	return secretsManager
 }
 
+// newLogger ships logs as configured. Without a Logz.io token, logs are written to stdout instead.
 func newLogger(conf config.Config, secretsMenager secrets.SecretsManager, ctx context.Context) log.Logger {
-	logger := log.NewLoggerFromConfig(conf, secretsMenager)
+	var logger log.Logger
+	if _, err := secretsMenager.Obtain(log.LOGZIO_TOKEN_KEY); err == nil {
+		logger = log.NewLoggerFromConfig(conf, secretsMenager)
+	} else {
+		logger = log.NewLogger(log.LogLevelFromConfig(conf), nil, nil)
+	}
 	logContextValues := make(map[string]string)
 	logContextValues[log.LogCtxNamespace] = "utte-universe"
 	if node, ok := os.LookupEnv("K8S_NODE_NAME"); ok {
@@ -2,34 +2,93 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
 	"syscall"
 	"time"
 
-	_ "github.com/tommzn/go-config"
+	"github.com/tommzn/go-config"
 	"github.com/tommzn/go-log"
+	"github.com/tommzn/go-secrets"
 	"github.com/tommzn/utte-universe/core"
 )
 
 func main() {
 
-	conf, _, logger, ctx := bootstrap()
+	conf, secretsManager, logger, ctx := bootstrap()
 	httpPort := os.Getenv("HTTP_PORT")
 	grpcPort := os.Getenv("GRPC_PORT")
 	defer logger.Flush()
 
-	gameConfig := &core.Config{}
-	if err := gameConfig.LoadFrom(conf); err != nil {
-		logger.Error("Failed to load game configuration: %v", err)
+	gameConfig, err := loadGameConfig(conf)
+	if err != nil {
+		logger.Errorf("Failed to load game configuration: %v", err)
 		os.Exit(1)
 	}
 
 	gameLogger := AsGameLogger(logger)
-	rand := core.NewBuiltInRand()
 
-	planet, npcs := core.SeedUniverse(gameConfig.SeedConfig, rand)
-	game := core.NewGameService(*gameConfig, rand, gameLogger, planet, npcs)
+	tracingConfig, err := core.LoadTracingConfig(conf, "tracing")
+	if err != nil {
+		logger.Errorf("Failed to load tracing configuration: %v", err)
+		os.Exit(1)
+	}
+	shutdownTracing, err := core.SetupTracing(ctx, tracingConfig, "utte-backend")
+	if err != nil {
+		logger.Errorf("Failed to set up tracing: %v", err)
+		os.Exit(1)
+	}
+	seed, err := universeSeed(conf)
+	if err != nil {
+		logger.Errorf("Failed to load seed: %v", err)
+		os.Exit(1)
+	}
+	rand := core.NewSeededRand(seed)
+
+	var planet []*core.Planet
+	var npcs []*core.NPC
+	var scheduledEvents []*core.ScheduledEvent
+	if gameConfig.ScenarioFile != "" {
+		scenario, err := core.LoadScenarioFile(gameConfig.ScenarioFile, gameConfig.SeedConfig, rand, time.Now())
+		if err != nil {
+			logger.Errorf("Failed to load scenario: %v", err)
+			os.Exit(1)
+		}
+		logger.Infof("Loaded scenario %s from %s", scenario.Name, gameConfig.ScenarioFile)
+		planet, npcs, scheduledEvents = scenario.Planets, scenario.NPCs, scenario.Events
+	} else {
+		planet, npcs = core.SeedUniverse(gameConfig.SeedConfig, rand)
+	}
+	game := core.NewGameService(gameConfig, rand, gameLogger, planet, npcs)
+	game.SetSeed(seed)
+	logger.Infof("Universe created with seed %d, server version %s", seed, core.Version)
+
+	historyConfig, err := core.LoadHistoryConfig(conf, "history")
+	if err != nil {
+		logger.Errorf("Failed to load history configuration: %v", err)
+		os.Exit(1)
+	}
+	game.EnableHistory(historyConfig)
+
+	journalConfig, err := core.LoadJournalConfig(conf, "journal")
+	if err != nil {
+		logger.Errorf("Failed to load journal configuration: %v", err)
+		os.Exit(1)
+	}
+	if journalConfig.Dir != "" {
+		// each start creates a new universe, its journal is kept next to journals of previous runs
+		journalConfig.Dir = filepath.Join(journalConfig.Dir, time.Now().UTC().Format("20060102T150405Z"))
+		if err := game.StartJournal(journalConfig); err != nil {
+			logger.Errorf("Failed to start journal: %v", err)
+			os.Exit(1)
+		}
+	}
+	game.ScheduleEvents(scheduledEvents)
 
 	gameCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -38,20 +97,52 @@ func main() {
 		game.GameLoop(gameCtx)
 	}()
 
-	healthServer := NewHealthServer(":"+httpPort, logger)
+	if reloadInterval, err := time.ParseDuration(*conf.Get("config_reload_interval", config.AsStringPtr("0s"))); err == nil && reloadInterval > 0 {
+		go game.WatchConfig(gameCtx, reloadInterval, reloadGameConfig)
+	}
+
+	registry := newMetricsRegistry()
+	metrics := core.NewMetrics(game)
+	registry.MustRegister(metrics)
+	var grpcServing atomic.Bool
+	readiness := func() error {
+		if !grpcServing.Load() {
+			return errors.New("gRPC server isn't serving")
+		}
+		return game.CheckReady()
+	}
+	healthServer := NewHealthServer(":"+httpPort, game.CheckAlive, readiness, registry, logger)
 	go healthServer.Start()
 
 	// Graceful gRPC server setup
 	grpcDone := make(chan struct{})
-	grpcServer, grpcListener, err := core.NewGRPCServer(game, ":"+grpcPort, gameLogger)
+	tlsOptions, err := core.TLSServerOptions(core.LoadTLSConfig(conf, "tls"), gameLogger)
+	if err != nil {
+		logger.Error("Failed to load TLS certificates: %v", err)
+		os.Exit(1)
+	}
+	serverOptions := append(tlsOptions, core.MetricsServerOptions(metrics)...)
+	serverOptions = append(serverOptions, core.TracingServerOptions()...)
+	verifier, err := newTokenVerifier(conf, secretsManager)
+	if err != nil {
+		logger.Errorf("Failed to set up authentication: %v", err)
+		os.Exit(1)
+	}
+	if verifier == nil {
+		logger.Error("AUTH_DISABLED is set, requests aren't authenticated")
+	}
+	grpcServer, grpcListener, err := core.NewGRPCServer(game, ":"+grpcPort, verifier, gameLogger, serverOptions...)
 	if err != nil {
 		logger.Error("Failed to start gRPC server: %v", err)
 		os.Exit(1)
 	}
+	grpcHealth := core.RegisterHealthService(gameCtx, grpcServer, game.CheckReady, 5*time.Second, gameLogger)
 	go func() {
+		grpcServing.Store(true)
 		if err := grpcServer.Serve(grpcListener); err != nil {
 			logger.Error("gRPC server error: %v", err)
 		}
+		grpcServing.Store(false)
 		close(grpcDone)
 	}()
 
@@ -63,6 +154,7 @@ func main() {
 
 	// Cancel context → stops GameLoop
 	cancel()
+	grpcHealth.Shutdown()
 
 	healthServer.Shutdown(5 * time.Second)
 
@@ -70,9 +162,71 @@ func main() {
 	grpcServer.GracefulStop()
 	<-grpcDone
 
+	if err := game.StopJournal(); err != nil {
+		logger.Errorf("Failed to close journal: %v", err)
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Errorf("Failed to flush spans: %v", err)
+	}
+
 	logger.Info("Exited cleanly")
 }
 
+// loadGameConfig applies passed config to default game config and validates the result.
+func loadGameConfig(conf config.Config) (core.Config, error) {
+	gameConfig := core.DefaultConfig()
+	if err := gameConfig.LoadFrom(conf); err != nil {
+		return gameConfig, err
+	}
+	return gameConfig, gameConfig.Validate()
+}
+
+// universeSeed returns the configured seed of universe and random generator, a random one if there's none.
+func universeSeed(conf config.Config) (int64, error) {
+	seed := *conf.Get("seed", config.AsStringPtr(""))
+	if seed == "" {
+		return time.Now().UnixNano(), nil
+	}
+	return strconv.ParseInt(seed, 10, 64)
+}
+
+// reloadGameConfig loads game config again from the same source as on startup.
+func reloadGameConfig() (core.Config, error) {
+	conf, err := core.LoadServiceConfig()
+	if err != nil {
+		return core.Config{}, err
+	}
+	return loadGameConfig(conf)
+}
+
+// newTokenVerifier returns a verifier for tokens signed with the key AUTH_TOKEN_KEY and issued by auth.issuer.
+// Authentication is only disabled, and nil returned, if AUTH_DISABLED is true and no key is configured.
+// A missing, unreadable or empty key is an error, the server mustn't run without authentication by accident.
+func newTokenVerifier(conf config.Config, secretsManager secrets.SecretsManager) (*core.TokenVerifier, error) {
+	disabled, err := core.AuthDisabled()
+	if err != nil {
+		return nil, err
+	}
+	key, err := secretsManager.Obtain(core.AuthTokenKey)
+	var notFound *secrets.SecretNotFoundError
+	switch {
+	case errors.As(err, &notFound) && disabled:
+		return nil, nil
+	case errors.As(err, &notFound):
+		return nil, fmt.Errorf("%s isn't configured, set %s=true to run without authentication", core.AuthTokenKey, core.AuthDisabledKey)
+	case err != nil:
+		return nil, fmt.Errorf("unable to obtain %s: %w", core.AuthTokenKey, err)
+	case disabled:
+		return nil, fmt.Errorf("%s is configured, but %s is true", core.AuthTokenKey, core.AuthDisabledKey)
+	case *key == "":
+		return nil, fmt.Errorf("%s is empty", core.AuthTokenKey)
+	}
+	return core.NewTokenVerifier([]byte(*key), *conf.Get("auth.issuer", config.AsStringPtr(""))), nil
+}
+
 func AsGameLogger(logger log.Logger) core.Log {
 	return core.NewCustomLogger(logger)
 }
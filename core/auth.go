@@ -0,0 +1,198 @@
+package core
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AuthTokenKey is the name of the secret tokens are signed with.
+const AuthTokenKey = "AUTH_TOKEN_KEY"
+
+// AuthDisabledKey is the name of the environment variable which turns authentication off if it's true.
+const AuthDisabledKey = "AUTH_DISABLED"
+
+// AuthDisabled returns true if authentication has been turned off deliberately with AUTH_DISABLED.
+func AuthDisabled() (bool, error) {
+	value := os.Getenv(AuthDisabledKey)
+	if value == "" {
+		return false, nil
+	}
+	disabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %q", AuthDisabledKey, value)
+	}
+	return disabled, nil
+}
+
+var (
+	ErrMissingToken = errors.New("missing token")
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// Role defines what a caller is allowed to do. Each role includes all permissions of the roles before it.
+type Role int
+
+const (
+	RoleViewer Role = iota // reads the universe and streams its state
+	RolePlayer             // joins the universe and acts as a player
+	RoleAdmin              // controls the game loop
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RolePlayer:
+		return "player"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// RoleFromString converts a string to a Role.
+func RoleFromString(s string) Role {
+	switch strings.ToLower(s) {
+	case "viewer":
+		return RoleViewer
+	case "player":
+		return RolePlayer
+	case "admin":
+		return RoleAdmin
+	default:
+		return Role(-1) // Unknown
+	}
+}
+
+// Allows returns true if a caller with this role may do what passed role is required for.
+func (r Role) Allows(required Role) bool {
+	return r >= required
+}
+
+// Claims is the payload of a token.
+type Claims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	Issuer    string `json:"iss,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Identity is the verified caller of a request.
+type Identity struct {
+	Subject string // account of the caller, players are bound to it when joining
+	Role    Role
+}
+
+type identityKey struct{}
+
+// ContextWithIdentity returns a context carrying passed identity.
+func ContextWithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the verified identity of a request, false if the request isn't authenticated.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// SignToken returns a JWT with passed claims, signed with HMAC-SHA256.
+func SignToken(claims Claims, key []byte) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode claims: %w", err)
+	}
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sign(unsigned, key)), nil
+}
+
+// TokenVerifier verifies JWTs signed with HMAC-SHA256 and a shared key, locally, without
+// asking an identity provider.
+type TokenVerifier struct {
+	key    []byte
+	issuer string // required issuer, any issuer is accepted if empty
+	clock  Clock
+}
+
+func NewTokenVerifier(key []byte, issuer string) *TokenVerifier {
+	return &TokenVerifier{key: key, issuer: issuer, clock: NewWallClock()}
+}
+
+// Verify checks signature, issuer and validity period of a token and returns the identity it carries.
+// Tokens without expiry, subject or a known role are rejected.
+func (v *TokenVerifier) Verify(token string) (Identity, error) {
+	if token == "" {
+		return Identity{}, ErrMissingToken
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Identity{}, fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return Identity{}, fmt.Errorf("%w: unsupported algorithm", ErrInvalidToken)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(parts[0]+"."+parts[1], v.key)) {
+		return Identity{}, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Identity{}, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	now := v.clock.Now().Unix()
+	if claims.ExpiresAt == 0 || now >= claims.ExpiresAt {
+		return Identity{}, ErrTokenExpired
+	}
+	if claims.NotBefore > 0 && now < claims.NotBefore {
+		return Identity{}, fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return Identity{}, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	role := RoleFromString(claims.Role)
+	if claims.Subject == "" || role < 0 {
+		return Identity{}, fmt.Errorf("%w: missing subject or unknown role %q", ErrInvalidToken, claims.Role)
+	}
+	return Identity{Subject: claims.Subject, Role: role}, nil
+}
+
+// BearerToken returns the token of an authorization header value, "Bearer <token>".
+func BearerToken(header string) string {
+	if len(header) > 7 && strings.EqualFold(header[:7], "bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+func sign(unsigned string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
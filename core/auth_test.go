@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	pb "github.com/tommzn/utte-universe/core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type AuthSuite struct {
+	suite.Suite
+	key      []byte
+	now      time.Time
+	verifier *TokenVerifier
+	log      *mockLog
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
+
+func (s *AuthSuite) SetupTest() {
+	s.key = []byte("secret")
+	s.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.verifier = NewTokenVerifier(s.key, "utte")
+	s.verifier.clock = NewSimulationClock(s.now, 0)
+	s.log = &mockLog{}
+}
+
+func (s *AuthSuite) token(subject, role string) string {
+	token, err := SignToken(Claims{Subject: subject, Role: role, Issuer: "utte", ExpiresAt: s.now.Add(time.Hour).Unix()}, s.key)
+	s.NoError(err)
+	return token
+}
+
+func (s *AuthSuite) TestRoles() {
+	s.Equal(RoleAdmin, RoleFromString("Admin"))
+	s.Equal(Role(-1), RoleFromString("guest"))
+	s.Equal("player", RolePlayer.String())
+	s.True(RoleAdmin.Allows(RolePlayer))
+	s.True(RolePlayer.Allows(RolePlayer))
+	s.False(RoleViewer.Allows(RolePlayer))
+}
+
+func (s *AuthSuite) TestVerify() {
+	identity, err := s.verifier.Verify(s.token("alice", "player"))
+	s.NoError(err)
+	s.Equal(Identity{Subject: "alice", Role: RolePlayer}, identity)
+
+	_, err = s.verifier.Verify("")
+	s.ErrorIs(err, ErrMissingToken)
+	_, err = s.verifier.Verify("not.a-token")
+	s.ErrorIs(err, ErrInvalidToken)
+	_, err = s.verifier.Verify(s.token("alice", "emperor"))
+	s.ErrorIs(err, ErrInvalidToken)
+	_, err = s.verifier.Verify(s.token("", "viewer"))
+	s.ErrorIs(err, ErrInvalidToken)
+
+	forged, _ := SignToken(Claims{Subject: "alice", Role: "admin", Issuer: "utte", ExpiresAt: s.now.Add(time.Hour).Unix()}, []byte("guessed"))
+	_, err = s.verifier.Verify(forged)
+	s.ErrorIs(err, ErrInvalidToken)
+
+	// unsigned tokens are rejected
+	parts := strings.Split(s.token("alice", "admin"), ".")
+	_, err = s.verifier.Verify("eyJhbGciOiJub25lIn0." + parts[1] + ".")
+	s.ErrorIs(err, ErrInvalidToken)
+
+	expired, _ := SignToken(Claims{Subject: "alice", Role: "viewer", Issuer: "utte", ExpiresAt: s.now.Unix()}, s.key)
+	_, err = s.verifier.Verify(expired)
+	s.ErrorIs(err, ErrTokenExpired)
+	foreign, _ := SignToken(Claims{Subject: "alice", Role: "viewer", Issuer: "other", ExpiresAt: s.now.Add(time.Hour).Unix()}, s.key)
+	_, err = s.verifier.Verify(foreign)
+	s.ErrorIs(err, ErrInvalidToken)
+}
+
+func (s *AuthSuite) TestAuthDisabled() {
+	disabled, err := AuthDisabled()
+	s.NoError(err)
+	s.False(disabled)
+
+	s.T().Setenv(AuthDisabledKey, "true")
+	disabled, err = AuthDisabled()
+	s.NoError(err)
+	s.True(disabled)
+
+	s.T().Setenv(AuthDisabledKey, "maybe")
+	_, err = AuthDisabled()
+	s.Error(err)
+}
+
+func (s *AuthSuite) TestBearerToken() {
+	s.Equal("abc", BearerToken("Bearer abc"))
+	s.Equal("abc", BearerToken("bearer abc"))
+	s.Equal("", BearerToken("Basic abc"))
+	s.Equal("", BearerToken(""))
+}
+
+func (s *AuthSuite) TestUnaryAuthInterceptor() {
+	interceptor := UnaryAuthInterceptor(s.verifier, s.log)
+	var caller Identity
+	handler := func(ctx context.Context, req any) (any, error) {
+		caller, _ = IdentityFromContext(ctx)
+		return "ok", nil
+	}
+	call := func(token, method string) error {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(AuthorizationHeader, "Bearer "+token))
+		}
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	s.Equal(codes.Unauthenticated, status.Code(call("", pb.UniverseService_GetPlanets_FullMethodName)))
+	s.Equal(codes.Unauthenticated, status.Code(call("garbage", pb.UniverseService_GetPlanets_FullMethodName)))
+
+	viewer := s.token("carol", "viewer")
+	s.NoError(call(viewer, pb.UniverseService_GetPlanets_FullMethodName))
+	s.Equal(Identity{Subject: "carol", Role: RoleViewer}, caller)
+	s.Equal(codes.PermissionDenied, status.Code(call(viewer, pb.UniverseService_BuyResources_FullMethodName)))
+
+	player := s.token("alice", "player")
+	s.NoError(call(player, pb.UniverseService_BuyResources_FullMethodName))
+	s.Equal(codes.PermissionDenied, status.Code(call(player, pb.UniverseService_ControlGame_FullMethodName)))
+	s.Equal(codes.PermissionDenied, status.Code(call(player, "/proto.UniverseService/Unknown")))
+
+	s.NoError(call(s.token("ops", "admin"), pb.UniverseService_ControlGame_FullMethodName))
+}
+
+func (s *AuthSuite) TestStreamAuthInterceptor() {
+	interceptor := StreamAuthInterceptor(s.verifier, s.log)
+	var caller Identity
+	handler := func(srv any, stream grpc.ServerStream) error {
+		caller, _ = IdentityFromContext(stream.Context())
+		return nil
+	}
+	info := &grpc.StreamServerInfo{FullMethod: pb.UniverseService_StreamUniverseState_FullMethodName}
+
+	err := interceptor(nil, &mockStream{}, info, handler)
+	s.Equal(codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationHeader, "Bearer "+s.token("carol", "viewer")))
+	s.NoError(interceptor(nil, &mockStream{ctx: ctx}, info, handler))
+	s.Equal("carol", caller.Subject)
+}
+
+func (s *AuthSuite) TestPlayersAreBoundToAccounts() {
+	planet := &Planet{Name: "Mars", Type: Desert, Resources: map[ResourceType]int{Iron: 1000}, Modifiers: map[ResourceType]float64{}}
+	game := NewGameService(DefaultConfig(), &mockRand{}, s.log, []*Planet{planet}, []*NPC{})
+	server := &UniverseServer{Game: game, Log: s.log}
+	asAlice := ContextWithIdentity(context.Background(), Identity{Subject: "alice", Role: RolePlayer})
+
+	_, err := server.GetPlayer(asAlice, &pb.Empty{})
+	s.Equal(codes.Unauthenticated, status.Code(err))
+	joined, err := server.JoinUniverse(asAlice, &pb.JoinRequest{Name: "Alice"})
+	s.NoError(err)
+	_, err = server.JoinUniverse(asAlice, &pb.JoinRequest{Name: "Alias"})
+	s.Equal(codes.AlreadyExists, status.Code(err))
+
+	// the player is found by account, a player ID passed as metadata is ignored
+	resp, err := server.BuyResources(asAlice, &pb.TradeRequest{Planet: "Mars", Resource: "Iron", Amount: 10})
+	s.NoError(err)
+	s.Equal(joined.Id, resp.Id)
+	asMallory := metadata.NewIncomingContext(ContextWithIdentity(context.Background(), Identity{Subject: "mallory", Role: RolePlayer}), metadata.Pairs(PlayerIDHeader, joined.Id))
+	_, err = server.BuyResources(asMallory, &pb.TradeRequest{Planet: "Mars", Resource: "Iron", Amount: 10})
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
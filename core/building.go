@@ -5,14 +5,11 @@ package core
 // deducts the required resources, increases the building's level, and logs the process.
 // Returns true if the upgrade was successful, false otherwise.
 func (b *Building) Upgrade(p *Planet, log Log) bool {
-	upgradeCost := make(map[ResourceType]int)
-	for res, cost := range b.BuildCost {
-		upgradeCost[res] = cost * (b.Level + 1)
-	}
+	upgradeCost := b.UpgradeCost()
 
 	for res, cost := range upgradeCost {
 		if p.Resources[res] < cost {
-			log.Error("Upgrade failed: insufficient resources for %v on planet %s", res, p.Name)
+			Emit(log, BuildFailed{Planet: p.Name, Building: b.Type, Level: b.Level + 1, Reason: InsufficientResources, Resource: res})
 			return false
 		}
 	}
@@ -22,6 +19,15 @@ func (b *Building) Upgrade(p *Planet, log Log) bool {
 		log.Debug("Resource %v deducted by %d for upgrade on planet %s", res, cost, p.Name)
 	}
 	b.Level++
-	log.Info("Building %v upgraded to level %d on planet %s", b.Type, b.Level, p.Name)
+	Emit(log, BuildingBuilt{Planet: p.Name, Owner: p.OwnerName(), Building: b.Type, Level: b.Level})
 	return true
 }
+
+// UpgradeCost returns the resources required to upgrade the building to its next level.
+func (b *Building) UpgradeCost() map[ResourceType]int {
+	upgradeCost := make(map[ResourceType]int)
+	for res, cost := range b.BuildCost {
+		upgradeCost[res] = cost * (b.Level + 1)
+	}
+	return upgradeCost
+}
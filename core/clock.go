@@ -0,0 +1,34 @@
+package core
+
+import "time"
+
+// WallClock returns the current system time.
+type WallClock struct{}
+
+func NewWallClock() Clock {
+	return &WallClock{}
+}
+
+func (c *WallClock) Now() time.Time {
+	return time.Now()
+}
+
+// SimulationClock is a manually advanced clock, used to run ticks faster than real time
+// while cooldowns still pass as if each tick took a full tick duration.
+type SimulationClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func NewSimulationClock(start time.Time, step time.Duration) *SimulationClock {
+	return &SimulationClock{now: start, step: step}
+}
+
+func (c *SimulationClock) Now() time.Time {
+	return c.now
+}
+
+// Advance moves the clock forward by one step.
+func (c *SimulationClock) Advance() {
+	c.now = c.now.Add(c.step)
+}
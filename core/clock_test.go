@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClockSuite struct {
+	suite.Suite
+}
+
+func TestClockSuite(t *testing.T) {
+	suite.Run(t, new(ClockSuite))
+}
+
+func (s *ClockSuite) TestWallClock() {
+	before := time.Now()
+	now := NewWallClock().Now()
+	s.False(now.Before(before))
+}
+
+func (s *ClockSuite) TestSimulationClock() {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewSimulationClock(start, 3*time.Second)
+	s.Equal(start, clock.Now())
+	clock.Advance()
+	clock.Advance()
+	s.Equal(start.Add(6*time.Second), clock.Now())
+}
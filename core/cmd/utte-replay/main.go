@@ -0,0 +1,117 @@
+// Command utte-replay replays the journal of a game. It restores the latest snapshot before the first
+// requested tick, steps through the journaled ticks and diffs the replayed state against the state
+// journaled by the live run, to detect nondeterminism.
+// With -planet, the history of a planet is written as JSON lines, one for each tick it changed in.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+
+	"github.com/tommzn/go-log"
+	"github.com/tommzn/utte-universe/core"
+)
+
+type options struct {
+	journalDir string
+	from       uint64
+	until      uint64
+	planet     string
+	stopOnDiff bool
+	logLevel   string
+}
+
+// planetHistory is the state of a planet after a tick.
+type planetHistory struct {
+	Tick   uint64
+	Planet core.PlanetSnapshot
+}
+
+func main() {
+
+	opts := options{}
+	flag.StringVar(&opts.journalDir, "journal", "journal", "Directory of the journal to replay.")
+	flag.Uint64Var(&opts.from, "from", 0, "First tick to report, replay starts at the latest snapshot before it.")
+	flag.Uint64Var(&opts.until, "until", math.MaxUint64, "Last tick to replay.")
+	flag.StringVar(&opts.planet, "planet", "", "Write the history of the planet with this name.")
+	flag.BoolVar(&opts.stopOnDiff, "stop-on-diff", false, "Stop at the first tick which differs from the journaled run.")
+	flag.StringVar(&opts.logLevel, "loglevel", "none", "Log level for replay logs: none, error, info or debug.")
+	flag.Parse()
+
+	if err := run(opts, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "utte-replay: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options, out io.Writer) error {
+
+	logger := log.NewLogger(log.LogLevelByName(opts.logLevel), nil, nil)
+	defer logger.Flush()
+
+	replay, err := core.OpenReplay(opts.journalDir, opts.from, core.NewCustomLogger(logger))
+	if err != nil {
+		return err
+	}
+	defer replay.Close()
+
+	encoder := json.NewEncoder(out)
+	var last *core.PlanetSnapshot
+	replayed, diverged := 0, 0
+	for {
+		step, err := replay.Step()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if step.Tick < opts.from {
+			continue
+		}
+		replayed++
+		if step.Diverged() {
+			diverged++
+			fmt.Fprintf(os.Stderr, "Tick %d differs from the journaled run: %v\n", step.Tick, step.Diffs)
+			if opts.stopOnDiff {
+				break
+			}
+		}
+		if opts.planet != "" {
+			planet, err := findPlanet(replay.Game.Snapshot(), opts.planet)
+			if err != nil {
+				return err
+			}
+			if last == nil || !reflect.DeepEqual(*last, planet) {
+				if err := encoder.Encode(planetHistory{Tick: step.Tick, Planet: planet}); err != nil {
+					return err
+				}
+			}
+			last = &planet
+		}
+		if step.Tick >= opts.until {
+			break
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Replayed %d ticks, %d of them differ from the journaled run.\n", replayed, diverged)
+	if diverged > 0 {
+		return fmt.Errorf("replay isn't deterministic")
+	}
+	return nil
+}
+
+func findPlanet(snapshot core.Snapshot, name string) (core.PlanetSnapshot, error) {
+	for _, p := range snapshot.Planets {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return core.PlanetSnapshot{}, fmt.Errorf("unknown planet %s", name)
+}
@@ -0,0 +1,193 @@
+// Command utte-sim runs a headless simulation of the universe for balancing.
+// It seeds a universe from a local config, runs a number of ticks as fast as possible
+// and writes per tick economy statistics as CSV or JSON.
+// A hand-authored scenario can be used instead of a random universe.
+// With -runs > 1 the same config is simulated across many seeds in parallel and an
+// aggregated balance report is written instead, as text or JSON.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/tommzn/go-config"
+	"github.com/tommzn/go-log"
+	"github.com/tommzn/utte-universe/core"
+)
+
+type options struct {
+	configFile   string
+	scenarioFile string
+	seed         int64
+	ticks        int
+	format       string
+	output       string
+	logLevel     string
+	monteCarlo   core.MonteCarloConfig
+}
+
+func main() {
+
+	mc := core.DefaultMonteCarloConfig()
+	opts := options{}
+	flag.StringVar(&opts.configFile, "config", "config.yml", "Path to a local YAML game config.")
+	flag.StringVar(&opts.scenarioFile, "scenario", "", "Path to a YAML or JSON scenario, overrides scenario_file from config.")
+	flag.Int64Var(&opts.seed, "seed", time.Now().UnixNano(), "Seed used to generate the universe, first seed in Monte-Carlo mode.")
+	flag.IntVar(&opts.ticks, "ticks", 1000, "Number of ticks to simulate.")
+	flag.StringVar(&opts.format, "format", "", "Output format, csv or json for a single run, text or json for a balance report.")
+	flag.StringVar(&opts.output, "out", "", "Output file, defaults to stdout.")
+	flag.StringVar(&opts.logLevel, "loglevel", "none", "Log level for simulation logs: none, error, info or debug.")
+	flag.IntVar(&mc.Runs, "runs", 1, "Number of seeds to simulate. More than one run creates a balance report.")
+	flag.IntVar(&mc.Workers, "workers", mc.Workers, "Number of simulations running in parallel.")
+	flag.IntVar(&mc.Samples, "samples", mc.Samples, "Number of checkpoints for colonization over time.")
+	flag.Float64Var(&mc.GrowthLimit, "growth-limit", mc.GrowthLimit, "Final/initial resource ratio flagged as runaway growth.")
+	flag.Parse()
+	opts.monteCarlo = mc
+
+	if err := opts.validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "utte-sim: %v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "utte-sim: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// validate checks the flags before anything is simulated or written.
+func (opts options) validate() error {
+	if opts.ticks <= 0 {
+		return fmt.Errorf("-ticks must be positive, got %d", opts.ticks)
+	}
+	mc := opts.monteCarlo
+	if mc.Runs <= 0 {
+		return fmt.Errorf("-runs must be positive, got %d", mc.Runs)
+	}
+	if mc.Workers <= 0 {
+		return fmt.Errorf("-workers must be positive, got %d", mc.Workers)
+	}
+	if mc.Samples <= 0 {
+		return fmt.Errorf("-samples must be positive, got %d", mc.Samples)
+	}
+	// runs use the seeds from -seed to -seed + runs - 1
+	if opts.seed > math.MaxInt64-int64(mc.Runs-1) {
+		return fmt.Errorf("-seed %d overflows with %d runs", opts.seed, mc.Runs)
+	}
+	formats := []string{"", "csv", "json"}
+	if mc.Runs > 1 {
+		formats = []string{"", "text", "json"}
+	}
+	if !slices.Contains(formats, opts.format) {
+		return fmt.Errorf("unsupported output format: %s", opts.format)
+	}
+	if opts.output != "" {
+		if info, err := os.Stat(opts.output); err == nil && info.IsDir() {
+			return fmt.Errorf("-out %s is a directory", opts.output)
+		}
+		for _, input := range []string{opts.configFile, opts.scenarioFile} {
+			if input != "" && filepath.Clean(input) == filepath.Clean(opts.output) {
+				return fmt.Errorf("-out %s would overwrite an input file", opts.output)
+			}
+		}
+	}
+	return nil
+}
+
+func run(opts options) error {
+
+	gameConfig, err := loadGameConfig(opts.configFile)
+	if err != nil {
+		return err
+	}
+	if opts.scenarioFile != "" {
+		gameConfig.ScenarioFile = opts.scenarioFile
+	}
+
+	logger := log.NewLogger(log.LogLevelByName(opts.logLevel), nil, nil)
+	defer logger.Flush()
+	gameLogger := core.NewCustomLogger(logger)
+
+	out := io.Writer(os.Stdout)
+	if opts.output != "" {
+		file, err := os.Create(opts.output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if opts.monteCarlo.Runs > 1 {
+		return runMonteCarlo(gameConfig, opts, gameLogger, out)
+	}
+	return runSingle(gameConfig, opts, gameLogger, out)
+}
+
+func runSingle(gameConfig core.Config, opts options, logger core.Log, out io.Writer) error {
+
+	var write func(io.Writer, []core.TickStats) error
+	switch opts.format {
+	case "", "csv":
+		write = core.WriteStatsCSV
+	case "json":
+		write = core.WriteStatsJSON
+	default:
+		return fmt.Errorf("unsupported output format: %s", opts.format)
+	}
+
+	stats := make([]core.TickStats, 0, opts.ticks)
+	simulation, err := core.NewSimulationFromConfig(gameConfig, opts.seed, logger)
+	if err != nil {
+		return err
+	}
+	simulation.Run(opts.ticks, func(s core.TickStats) {
+		stats = append(stats, s)
+	})
+	return write(out, stats)
+}
+
+func runMonteCarlo(gameConfig core.Config, opts options, logger core.Log, out io.Writer) error {
+
+	mc := opts.monteCarlo
+	mc.Seed = opts.seed
+	mc.Ticks = opts.ticks
+	report, err := core.RunMonteCarlo(gameConfig, mc, logger)
+	if err != nil {
+		return err
+	}
+
+	switch opts.format {
+	case "", "text":
+		return core.WriteBalanceReport(out, report)
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	default:
+		return fmt.Errorf("unsupported report format: %s", opts.format)
+	}
+}
+
+func loadGameConfig(configFile string) (core.Config, error) {
+
+	gameConfig := core.DefaultConfig()
+	conf, err := config.NewFileConfigSource(&configFile).Load()
+	if err != nil {
+		return gameConfig, fmt.Errorf("unable to load config %s: %w", configFile, err)
+	}
+	if err := gameConfig.LoadFrom(conf); err != nil {
+		return gameConfig, fmt.Errorf("unable to parse config %s: %w", configFile, err)
+	}
+	if err := gameConfig.Validate(); err != nil {
+		return gameConfig, fmt.Errorf("invalid config %s: %w", configFile, err)
+	}
+	return gameConfig, nil
+}
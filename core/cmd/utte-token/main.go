@@ -0,0 +1,56 @@
+// Command utte-token issues tokens for clients of the game backend and the ui-backend.
+// Tokens are signed with the key in AUTH_TOKEN_KEY, the same key both services verify tokens with.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tommzn/utte-universe/core"
+)
+
+func main() {
+
+	var subject, role, issuer string
+	var ttl time.Duration
+	flag.StringVar(&subject, "subject", "", "Account the token is issued for, players are bound to it.")
+	flag.StringVar(&role, "role", core.RoleViewer.String(), "Role of the account: viewer, player or admin.")
+	flag.StringVar(&issuer, "issuer", "", "Issuer of the token, has to match the issuer the services expect.")
+	flag.DurationVar(&ttl, "ttl", 24*time.Hour, "Time until the token expires.")
+	flag.Parse()
+
+	if err := run(subject, role, issuer, ttl); err != nil {
+		fmt.Fprintf(os.Stderr, "utte-token: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(subject, role, issuer string, ttl time.Duration) error {
+
+	key := os.Getenv(core.AuthTokenKey)
+	if key == "" {
+		return fmt.Errorf("%s isn't set", core.AuthTokenKey)
+	}
+	if subject == "" {
+		return fmt.Errorf("subject is required")
+	}
+	if core.RoleFromString(role) < 0 {
+		return fmt.Errorf("unknown role %q", role)
+	}
+
+	now := time.Now()
+	token, err := core.SignToken(core.Claims{
+		Subject:   subject,
+		Role:      core.RoleFromString(role).String(),
+		Issuer:    issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}, []byte(key))
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
@@ -1,14 +1,60 @@
 package core
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/tommzn/go-config"
 )
 
 type Config struct {
-	TickDuration time.Duration
-	SeedConfig   SeedConfig
+	TickDuration    time.Duration
+	CatchUpPolicy   CatchUpPolicy
+	MaxCatchUpTicks int
+	ScenarioFile    string // optional hand-authored universe, used instead of a random one
+	LedgerSize      int    // number of latest entries kept in the trade ledger
+	ResumeBuffer    int    // number of latest stream updates kept for streams resuming after a reconnect
+	SeedConfig      SeedConfig
+	Events          EventConfig
+	Taxes           TaxConfig
+	Lifecycle       LifecycleConfig
+	Conflict        ConflictConfig
+	Piracy          PiracyConfig
+	Players         PlayerConfig
+}
+
+// CatchUpPolicy defines how the game loop reacts if ticks overrun their time budget.
+type CatchUpPolicy int
+
+const (
+	// SkipMissedTicks drops all tick slots which have been missed and continues with the next one in the future.
+	SkipMissedTicks CatchUpPolicy = iota
+	// BurstMissedTicks runs missed ticks back-to-back, up to MaxCatchUpTicks, before skipping the rest.
+	BurstMissedTicks
+)
+
+func (p CatchUpPolicy) String() string {
+	switch p {
+	case SkipMissedTicks:
+		return "skip"
+	case BurstMissedTicks:
+		return "burst"
+	default:
+		return "unknown"
+	}
+}
+
+// CatchUpPolicyFromString converts a string to a CatchUpPolicy.
+func CatchUpPolicyFromString(s string) CatchUpPolicy {
+	switch s {
+	case "skip":
+		return SkipMissedTicks
+	case "burst":
+		return BurstMissedTicks
+	default:
+		return CatchUpPolicy(-1) // Unknown
+	}
 }
 
 type SeedConfig struct {
@@ -26,12 +72,161 @@ type NPCSeedConfig struct {
 	Credits                     intRange
 	MaxCargo                    intRange
 	ColonizationCooldownSeconds int
+	Strategies                  map[string]float64 // weights to choose strategies of new NPCs, by strategy name
+	InvestmentShare             intRange           // percent of their credits NPCs invest into their planets
+	Tariff                      intRange           // percent other traders pay on trades with an NPC's planets
+	Factions                    []string           // names of factions NPCs are assigned to in turn
+}
+
+// EventConfig defines how often and how long randomly triggered events occur.
+type EventConfig struct {
+	BaseChance        float64                // chance per tick of an event on a randomly picked planet
+	Duration          int                    // number of ticks an event lasts
+	ChanceMultipliers map[PlanetType]float64 // factor applied to BaseChance per planet type
+}
+
+// Multiplier returns the factor applied to BaseChance for given planet type, 1 if there's none.
+func (c EventConfig) Multiplier(pt PlanetType) float64 {
+	if multiplier, ok := c.ChanceMultipliers[pt]; ok {
+		return multiplier
+	}
+	return 1.0
+}
+
+// Chance returns the chance of an event on a picked planet of given type.
+func (c EventConfig) Chance(pt PlanetType) float64 {
+	return c.BaseChance * c.Multiplier(pt)
 }
 
 func DefaultConfig() Config {
 	return Config{
-		TickDuration: 2 * time.Second,
-		SeedConfig:   DefaultSeedConfig(),
+		TickDuration:    2 * time.Second,
+		CatchUpPolicy:   SkipMissedTicks,
+		MaxCatchUpTicks: 5,
+		LedgerSize:      1000,
+		ResumeBuffer:    300,
+		SeedConfig:      DefaultSeedConfig(),
+		Events:          DefaultEventConfig(),
+		Taxes:           DefaultTaxConfig(),
+		Lifecycle:       DefaultLifecycleConfig(),
+		Conflict:        DefaultConflictConfig(),
+		Piracy:          DefaultPiracyConfig(),
+		Players:         DefaultPlayerConfig(),
+	}
+}
+
+func DefaultEventConfig() EventConfig {
+	multipliers := make(map[PlanetType]float64)
+	for _, pt := range planetTypes {
+		multipliers[pt] = EventChanceMultiplier(pt)
+	}
+	return EventConfig{
+		BaseChance:        BaseEventChance,
+		Duration:          5,
+		ChanceMultipliers: multipliers,
+	}
+}
+
+// TaxConfig defines the revenue of planet owners.
+type TaxConfig struct {
+	ProductionTax float64 // credits per unit produced on an owned planet, paid into its treasury
+	PayoutRate    float64 // share of a treasury paid out to the planet's owner per tick
+}
+
+func DefaultTaxConfig() TaxConfig {
+	return TaxConfig{
+		ProductionTax: 0.5,
+		PayoutRate:    0.25,
+	}
+}
+
+// LifecycleConfig defines how NPCs enter and leave a running universe.
+type LifecycleConfig struct {
+	InsolvencyThreshold int     // NPCs with fewer credits are insolvent
+	BankruptcyTicks     int     // consecutive insolvent ticks after which an NPC goes bankrupt, 0 disables bankruptcy
+	RetirementChance    float64 // chance per tick of each NPC to retire
+	ArrivalChance       float64 // chance per tick of a new NPC to arrive
+	MaxNPCs             int     // no new NPCs arrive while there are this many
+}
+
+func DefaultLifecycleConfig() LifecycleConfig {
+	return LifecycleConfig{
+		InsolvencyThreshold: 10,
+		BankruptcyTicks:     100,
+		RetirementChance:    0.0002,
+		ArrivalChance:       0.005,
+		MaxNPCs:             12,
+	}
+}
+
+// ConflictConfig defines fleets of NPCs and how battles for planets are fought.
+type ConflictConfig struct {
+	ShipCost         int // credits NPCs pay per ship
+	ShipStrength     int // attack and defence strength of a ship
+	FortressStrength int // defence strength of a fortress per level
+	GarrisonShips    int // surviving ships required to hold a captured planet, planets are razed otherwise
+}
+
+func DefaultConflictConfig() ConflictConfig {
+	return ConflictConfig{
+		ShipCost:         250,
+		ShipStrength:     10,
+		FortressStrength: 50,
+		GarrisonShips:    2,
+	}
+}
+
+// PiracyConfig defines the risk of traders being intercepted by pirates while travelling with cargo
+// between planets, and the escorts they can hire.
+type PiracyConfig struct {
+	BaseRisk         float64                // chance of an interception on a route with a hazard of 1 and cargo of little value
+	Hazards          map[PlanetType]float64 // pirate activity around planets of a type, a route's hazard is the mean of both ends
+	ValueScale       int                    // cargo value in credits which doubles the risk
+	MaxRisk          float64                // upper limit of the risk of an interception
+	LossShare        float64                // share of each resource in cargo pirates take
+	EscortFee        float64                // share of the cargo value an escort costs
+	EscortProtection float64                // share of the risk an escort averts
+}
+
+// Hazard returns the pirate activity around planets of given type, 1 if there's none configured.
+func (c PiracyConfig) Hazard(pt PlanetType) float64 {
+	if hazard, ok := c.Hazards[pt]; ok {
+		return hazard
+	}
+	return 1.0
+}
+
+func DefaultPiracyConfig() PiracyConfig {
+	return PiracyConfig{
+		BaseRisk: 0.01,
+		Hazards: map[PlanetType]float64{
+			TerraLike: 0.5,
+			Desert:    1.5,
+			GasGiant:  2.0,
+			Icy:       1.0,
+		},
+		ValueScale:       5000,
+		MaxRisk:          0.3,
+		LossShare:        0.5,
+		EscortFee:        0.01,
+		EscortProtection: 0.8,
+	}
+}
+
+// PlayerConfig defines how players join the universe and what they pay to trade and colonize.
+type PlayerConfig struct {
+	MaxPlayers       int // no more players can join once there are this many
+	StartingCredits  int // credits of a player joining the universe
+	BasePrice        int // price per unit of a resource at reference stock, market prices of players are based on it
+	ColonizationCost int // credits a player pays to colonize a planet
+}
+
+func DefaultPlayerConfig() PlayerConfig {
+	return PlayerConfig{
+		MaxPlayers:       16,
+		StartingCredits:  1000,
+		BasePrice:        10,
+		ColonizationCost: 500,
 	}
 }
 
@@ -41,19 +236,78 @@ type intRange struct {
 }
 
 type RawConfig struct {
-	TickDuration string        `mapstructure:"tick_duration"`
-	SeedConfig   RawSeedConfig `mapstructure:"universe_seed"`
+	TickDuration    string             `mapstructure:"tick_duration"`
+	CatchUpPolicy   string             `mapstructure:"catch_up_policy"`
+	MaxCatchUpTicks int                `mapstructure:"max_catch_up_ticks"`
+	ScenarioFile    string             `mapstructure:"scenario_file"`
+	LedgerSize      int                `mapstructure:"ledger_size"`
+	ResumeBuffer    int                `mapstructure:"resume_buffer"`
+	SeedConfig      RawSeedConfig      `mapstructure:"universe_seed"`
+	Events          RawEventConfig     `mapstructure:"events"`
+	Taxes           RawTaxConfig       `mapstructure:"taxes"`
+	Lifecycle       RawLifecycleConfig `mapstructure:"lifecycle"`
+	Conflict        RawConflictConfig  `mapstructure:"conflict"`
+	Piracy          RawPiracyConfig    `mapstructure:"piracy"`
+	Players         RawPlayerConfig    `mapstructure:"players"`
 }
 
 type RawSeedConfig struct {
-	NumberOfPlanets RawIntRange            `mapstructure:"number_of_planets"`
+	NumberOfPlanets *RawIntRange           `mapstructure:"number_of_planets"`
 	Resources       []ResourceConfig       `mapstructure:"resources"`
 	BuildingChance  []BuildingChanceConfig `mapstructure:"building_chance"`
 	BuildCosts      []BuildCostConfig      `mapstructure:"build_costs"`
-	Production      RawIntRange            `mapstructure:"production"`
+	Production      *RawIntRange           `mapstructure:"production"`
 	NPC             RawNPCSeedConfig       `mapstructure:"npc"`
 }
 
+type RawEventConfig struct {
+	BaseChance        *float64               `mapstructure:"base_chance"`
+	Duration          int                    `mapstructure:"duration"`
+	ChanceMultipliers []PlanetTypeMultiplier `mapstructure:"chance_multipliers"`
+}
+
+type RawTaxConfig struct {
+	ProductionTax *float64 `mapstructure:"production_tax"`
+	PayoutRate    *float64 `mapstructure:"payout_rate"`
+}
+
+type RawLifecycleConfig struct {
+	InsolvencyThreshold *int     `mapstructure:"insolvency_threshold"`
+	BankruptcyTicks     *int     `mapstructure:"bankruptcy_ticks"`
+	RetirementChance    *float64 `mapstructure:"retirement_chance"`
+	ArrivalChance       *float64 `mapstructure:"arrival_chance"`
+	MaxNPCs             *int     `mapstructure:"max_npcs"`
+}
+
+type RawConflictConfig struct {
+	ShipCost         *int `mapstructure:"ship_cost"`
+	ShipStrength     *int `mapstructure:"ship_strength"`
+	FortressStrength *int `mapstructure:"fortress_strength"`
+	GarrisonShips    *int `mapstructure:"garrison_ships"`
+}
+
+type RawPiracyConfig struct {
+	BaseRisk         *float64               `mapstructure:"base_risk"`
+	Hazards          []PlanetTypeMultiplier `mapstructure:"hazards"`
+	ValueScale       *int                   `mapstructure:"value_scale"`
+	MaxRisk          *float64               `mapstructure:"max_risk"`
+	LossShare        *float64               `mapstructure:"loss_share"`
+	EscortFee        *float64               `mapstructure:"escort_fee"`
+	EscortProtection *float64               `mapstructure:"escort_protection"`
+}
+
+type RawPlayerConfig struct {
+	MaxPlayers       *int `mapstructure:"max_players"`
+	StartingCredits  *int `mapstructure:"starting_credits"`
+	BasePrice        *int `mapstructure:"base_price"`
+	ColonizationCost *int `mapstructure:"colonization_cost"`
+}
+
+type PlanetTypeMultiplier struct {
+	PlanetType string  `mapstructure:"planet_type"`
+	Multiplier float64 `mapstructure:"multiplier"`
+}
+
 type ResourceConfig struct {
 	Resource string `mapstructure:"resource"`
 	Min      int    `mapstructure:"min"`
@@ -78,11 +332,20 @@ type RawIntRange struct {
 	Max int `mapstructure:"max"`
 }
 type RawNPCSeedConfig struct {
-	NumberOfNPCs                RawIntRange      `mapstructure:"number_of_npcs"`
+	NumberOfNPCs                *RawIntRange     `mapstructure:"number_of_npcs"`
 	Offers                      []ResourceConfig `mapstructure:"offers"`
-	Credits                     RawIntRange      `mapstructure:"credits"`
-	MaxCargo                    RawIntRange      `mapstructure:"max_cargo"`
-	ColonizationCooldownSeconds int              `mapstructure:"colonization_cooldown_seconds"`
+	Credits                     *RawIntRange     `mapstructure:"credits"`
+	MaxCargo                    *RawIntRange     `mapstructure:"max_cargo"`
+	ColonizationCooldownSeconds *int             `mapstructure:"colonization_cooldown_seconds"`
+	Strategies                  []StrategyWeight `mapstructure:"strategies"`
+	InvestmentShare             *RawIntRange     `mapstructure:"investment_share"`
+	Tariff                      *RawIntRange     `mapstructure:"tariff"`
+	Factions                    []string         `mapstructure:"factions"`
+}
+
+type StrategyWeight struct {
+	Strategy string  `mapstructure:"strategy"`
+	Weight   float64 `mapstructure:"weight"`
 }
 
 func DefaultSeedConfig() SeedConfig {
@@ -128,6 +391,10 @@ func DefaultBuildCost() map[BuildingType]map[ResourceType]int {
 			Iron: 70,
 			Fuel: 30,
 		},
+		Fortress: map[ResourceType]int{
+			Iron: 80,
+			Fuel: 40,
+		},
 	}
 }
 
@@ -142,9 +409,21 @@ func DefaultNPCSeedConfig() NPCSeedConfig {
 		Credits:                     intRange{Min: 200, Max: 50000},
 		MaxCargo:                    intRange{Min: 50, Max: 600},
 		ColonizationCooldownSeconds: 3600,
+		Strategies: map[string]float64{
+			TraderStrategyName:        1,
+			ColonizerStrategyName:     1,
+			IndustrialistStrategyName: 1,
+			HoarderStrategyName:       1,
+		},
+		InvestmentShare: intRange{Min: 10, Max: 40},
+		Tariff:          intRange{Min: 0, Max: 20},
+		Factions:        []string{"Trade Federation", "Mining Guild", "Free Colonies"},
 	}
 }
 
+// LoadFrom applies all values defined in passed config. Values which are not defined keep their
+// current value, so loading into DefaultConfig() only overrides what's been configured.
+// Entries of resources, building chances, build costs and offers are overridden one by one.
 func (c *Config) LoadFrom(conf config.Config) error {
 
 	rawConfig := RawConfig{}
@@ -154,36 +433,65 @@ func (c *Config) LoadFrom(conf config.Config) error {
 
 	// TickDuration
 	if rawConfig.TickDuration != "" {
-		if d, err := time.ParseDuration(rawConfig.TickDuration); err == nil {
-			c.TickDuration = d
+		d, err := time.ParseDuration(rawConfig.TickDuration)
+		if err != nil {
+			return fmt.Errorf("tick_duration: %w", err)
+		}
+		c.TickDuration = d
+	}
+
+	// Catch-up behaviour for overrunning ticks
+	if rawConfig.CatchUpPolicy != "" {
+		policy := CatchUpPolicyFromString(rawConfig.CatchUpPolicy)
+		if policy < 0 {
+			return fmt.Errorf("catch_up_policy: unknown policy %q", rawConfig.CatchUpPolicy)
 		}
+		c.CatchUpPolicy = policy
+	}
+	if rawConfig.MaxCatchUpTicks > 0 {
+		c.MaxCatchUpTicks = rawConfig.MaxCatchUpTicks
+	}
+
+	if rawConfig.ScenarioFile != "" {
+		c.ScenarioFile = rawConfig.ScenarioFile
+	}
+	if rawConfig.LedgerSize > 0 {
+		c.LedgerSize = rawConfig.LedgerSize
+	}
+	if rawConfig.ResumeBuffer > 0 {
+		c.ResumeBuffer = rawConfig.ResumeBuffer
 	}
 
 	// SeedConfig
 	seed := rawConfig.SeedConfig
 
 	// NumberOfPlanets
-	c.SeedConfig.NumberOfPlanets = intRange{
-		Min: seed.NumberOfPlanets.Min,
-		Max: seed.NumberOfPlanets.Max,
+	if seed.NumberOfPlanets != nil {
+		c.SeedConfig.NumberOfPlanets = seed.NumberOfPlanets.asIntRange()
 	}
 
 	// Resources
-	c.SeedConfig.Resources = make(map[ResourceType]intRange)
+	if c.SeedConfig.Resources == nil {
+		c.SeedConfig.Resources = make(map[ResourceType]intRange)
+	}
 	for _, rc := range seed.Resources {
 		rt := ResourceTypeFromString(rc.Resource)
 		c.SeedConfig.Resources[rt] = intRange{Min: rc.Min, Max: rc.Max}
 	}
 
 	// BuildingChance
-	c.SeedConfig.BuildingChance = make(map[BuildingType]float64)
+	if c.SeedConfig.BuildingChance == nil {
+		c.SeedConfig.BuildingChance = make(map[BuildingType]float64)
+	}
 	for _, bc := range seed.BuildingChance {
 		bt := BuildingTypeFromString(bc.BuildingType)
 		c.SeedConfig.BuildingChance[bt] = bc.Chance
 	}
 
 	// BuildCosts
-	c.SeedConfig.BuildCosts = make(map[BuildingType]map[ResourceType]int)
+	if c.SeedConfig.BuildCosts == nil {
+		c.SeedConfig.BuildCosts = make(map[BuildingType]map[ResourceType]int)
+	}
 	for _, bc := range seed.BuildCosts {
 		bt := BuildingTypeFromString(bc.BuildingType)
 		resMap := make(map[ResourceType]int)
@@ -195,22 +503,346 @@ func (c *Config) LoadFrom(conf config.Config) error {
 	}
 
 	// Production
-	c.SeedConfig.Production = intRange{
-		Min: seed.Production.Min,
-		Max: seed.Production.Max,
+	if seed.Production != nil {
+		c.SeedConfig.Production = seed.Production.asIntRange()
 	}
 
 	// NPCConfig
 	npc := seed.NPC
-	c.SeedConfig.MPCConfig.NumberOfNPCs = intRange{Min: npc.NumberOfNPCs.Min, Max: npc.NumberOfNPCs.Max}
-	c.SeedConfig.MPCConfig.Offers = make(map[ResourceType]intRange)
+	if npc.NumberOfNPCs != nil {
+		c.SeedConfig.MPCConfig.NumberOfNPCs = npc.NumberOfNPCs.asIntRange()
+	}
+	if c.SeedConfig.MPCConfig.Offers == nil {
+		c.SeedConfig.MPCConfig.Offers = make(map[ResourceType]intRange)
+	}
 	for _, offer := range npc.Offers {
 		rt := ResourceTypeFromString(offer.Resource)
 		c.SeedConfig.MPCConfig.Offers[rt] = intRange{Min: offer.Min, Max: offer.Max}
 	}
-	c.SeedConfig.MPCConfig.Credits = intRange{Min: npc.Credits.Min, Max: npc.Credits.Max}
-	c.SeedConfig.MPCConfig.MaxCargo = intRange{Min: npc.MaxCargo.Min, Max: npc.MaxCargo.Max}
-	c.SeedConfig.MPCConfig.ColonizationCooldownSeconds = npc.ColonizationCooldownSeconds
+	if npc.Credits != nil {
+		c.SeedConfig.MPCConfig.Credits = npc.Credits.asIntRange()
+	}
+	if npc.MaxCargo != nil {
+		c.SeedConfig.MPCConfig.MaxCargo = npc.MaxCargo.asIntRange()
+	}
+	if npc.ColonizationCooldownSeconds != nil {
+		c.SeedConfig.MPCConfig.ColonizationCooldownSeconds = *npc.ColonizationCooldownSeconds
+	}
+	if npc.InvestmentShare != nil {
+		c.SeedConfig.MPCConfig.InvestmentShare = npc.InvestmentShare.asIntRange()
+	}
+	if npc.Tariff != nil {
+		c.SeedConfig.MPCConfig.Tariff = npc.Tariff.asIntRange()
+	}
+	// Factions are a list, so configured ones replace all defaults.
+	if len(npc.Factions) > 0 {
+		c.SeedConfig.MPCConfig.Factions = npc.Factions
+	}
+	// Strategies are a distribution of weights, so configured ones replace all defaults.
+	if len(npc.Strategies) > 0 {
+		c.SeedConfig.MPCConfig.Strategies = make(map[string]float64)
+		for _, sw := range npc.Strategies {
+			c.SeedConfig.MPCConfig.Strategies[sw.Strategy] = sw.Weight
+		}
+	}
+
+	// Events
+	events := rawConfig.Events
+	if events.BaseChance != nil {
+		c.Events.BaseChance = *events.BaseChance
+	}
+	if events.Duration > 0 {
+		c.Events.Duration = events.Duration
+	}
+	if c.Events.ChanceMultipliers == nil {
+		c.Events.ChanceMultipliers = make(map[PlanetType]float64)
+	}
+	for _, m := range events.ChanceMultipliers {
+		pt := PlanetTypeFromString(m.PlanetType)
+		c.Events.ChanceMultipliers[pt] = m.Multiplier
+	}
+
+	// Taxes
+	if rawConfig.Taxes.ProductionTax != nil {
+		c.Taxes.ProductionTax = *rawConfig.Taxes.ProductionTax
+	}
+	if rawConfig.Taxes.PayoutRate != nil {
+		c.Taxes.PayoutRate = *rawConfig.Taxes.PayoutRate
+	}
+
+	// Lifecycle
+	lifecycle := rawConfig.Lifecycle
+	if lifecycle.InsolvencyThreshold != nil {
+		c.Lifecycle.InsolvencyThreshold = *lifecycle.InsolvencyThreshold
+	}
+	if lifecycle.BankruptcyTicks != nil {
+		c.Lifecycle.BankruptcyTicks = *lifecycle.BankruptcyTicks
+	}
+	if lifecycle.RetirementChance != nil {
+		c.Lifecycle.RetirementChance = *lifecycle.RetirementChance
+	}
+	if lifecycle.ArrivalChance != nil {
+		c.Lifecycle.ArrivalChance = *lifecycle.ArrivalChance
+	}
+	if lifecycle.MaxNPCs != nil {
+		c.Lifecycle.MaxNPCs = *lifecycle.MaxNPCs
+	}
+
+	// Conflict
+	conflict := rawConfig.Conflict
+	if conflict.ShipCost != nil {
+		c.Conflict.ShipCost = *conflict.ShipCost
+	}
+	if conflict.ShipStrength != nil {
+		c.Conflict.ShipStrength = *conflict.ShipStrength
+	}
+	if conflict.FortressStrength != nil {
+		c.Conflict.FortressStrength = *conflict.FortressStrength
+	}
+	if conflict.GarrisonShips != nil {
+		c.Conflict.GarrisonShips = *conflict.GarrisonShips
+	}
+
+	// Piracy
+	piracy := rawConfig.Piracy
+	if piracy.BaseRisk != nil {
+		c.Piracy.BaseRisk = *piracy.BaseRisk
+	}
+	if c.Piracy.Hazards == nil {
+		c.Piracy.Hazards = make(map[PlanetType]float64)
+	}
+	for _, h := range piracy.Hazards {
+		c.Piracy.Hazards[PlanetTypeFromString(h.PlanetType)] = h.Multiplier
+	}
+	if piracy.ValueScale != nil {
+		c.Piracy.ValueScale = *piracy.ValueScale
+	}
+	if piracy.MaxRisk != nil {
+		c.Piracy.MaxRisk = *piracy.MaxRisk
+	}
+	if piracy.LossShare != nil {
+		c.Piracy.LossShare = *piracy.LossShare
+	}
+	if piracy.EscortFee != nil {
+		c.Piracy.EscortFee = *piracy.EscortFee
+	}
+	if piracy.EscortProtection != nil {
+		c.Piracy.EscortProtection = *piracy.EscortProtection
+	}
+
+	// Players
+	players := rawConfig.Players
+	if players.MaxPlayers != nil {
+		c.Players.MaxPlayers = *players.MaxPlayers
+	}
+	if players.StartingCredits != nil {
+		c.Players.StartingCredits = *players.StartingCredits
+	}
+	if players.BasePrice != nil {
+		c.Players.BasePrice = *players.BasePrice
+	}
+	if players.ColonizationCost != nil {
+		c.Players.ColonizationCost = *players.ColonizationCost
+	}
 
 	return nil
 }
+
+// Validate checks all values of a config and returns an error listing each invalid one.
+func (c Config) Validate() error {
+
+	errs := []error{}
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	checkRange := func(key string, r intRange) {
+		if r.Min < 0 || r.Max < r.Min {
+			invalid("%s: invalid range %d..%d", key, r.Min, r.Max)
+		}
+	}
+
+	if c.TickDuration <= 0 {
+		invalid("tick_duration: has to be positive, got %v", c.TickDuration)
+	}
+	if c.CatchUpPolicy != SkipMissedTicks && c.CatchUpPolicy != BurstMissedTicks {
+		invalid("catch_up_policy: unknown policy %v", c.CatchUpPolicy)
+	}
+	if c.LedgerSize < 0 {
+		invalid("ledger_size: can't be negative, got %d", c.LedgerSize)
+	}
+	if c.ResumeBuffer < 0 {
+		invalid("resume_buffer: can't be negative, got %d", c.ResumeBuffer)
+	}
+	if c.MaxCatchUpTicks < 0 {
+		invalid("max_catch_up_ticks: can't be negative, got %d", c.MaxCatchUpTicks)
+	}
+
+	seed := c.SeedConfig
+	checkRange("universe_seed.number_of_planets", seed.NumberOfPlanets)
+	checkRange("universe_seed.production", seed.Production)
+	for res, r := range seed.Resources {
+		if res < 0 {
+			invalid("universe_seed.resources: unknown resource")
+			continue
+		}
+		checkRange(fmt.Sprintf("universe_seed.resources.%v", res), r)
+	}
+	for bt, chance := range seed.BuildingChance {
+		if bt < 0 {
+			invalid("universe_seed.building_chance: unknown building type")
+			continue
+		}
+		if chance < 0 || chance > 1 {
+			invalid("universe_seed.building_chance.%v: has to be between 0 and 1, got %v", bt, chance)
+		}
+	}
+	for bt, costs := range seed.BuildCosts {
+		if bt < 0 {
+			invalid("universe_seed.build_costs: unknown building type")
+			continue
+		}
+		for res, amount := range costs {
+			if res < 0 {
+				invalid("universe_seed.build_costs.%v: unknown resource", bt)
+			} else if amount < 0 {
+				invalid("universe_seed.build_costs.%v.%v: can't be negative, got %d", bt, res, amount)
+			}
+		}
+	}
+
+	npc := seed.MPCConfig
+	checkRange("universe_seed.npc.number_of_npcs", npc.NumberOfNPCs)
+	checkRange("universe_seed.npc.credits", npc.Credits)
+	checkRange("universe_seed.npc.max_cargo", npc.MaxCargo)
+	for res, r := range npc.Offers {
+		if res < 0 {
+			invalid("universe_seed.npc.offers: unknown resource")
+			continue
+		}
+		checkRange(fmt.Sprintf("universe_seed.npc.offers.%v", res), r)
+	}
+	checkRange("universe_seed.npc.investment_share", npc.InvestmentShare)
+	if npc.InvestmentShare.Max > 100 {
+		invalid("universe_seed.npc.investment_share: can't exceed 100 percent, got %d", npc.InvestmentShare.Max)
+	}
+	checkRange("universe_seed.npc.tariff", npc.Tariff)
+	if npc.Tariff.Max > 100 {
+		invalid("universe_seed.npc.tariff: can't exceed 100 percent, got %d", npc.Tariff.Max)
+	}
+	factions := make(map[string]bool)
+	for _, name := range npc.Factions {
+		if name == "" {
+			invalid("universe_seed.npc.factions: name can't be empty")
+		} else if factions[name] {
+			invalid("universe_seed.npc.factions: duplicate faction %s", name)
+		}
+		factions[name] = true
+	}
+	if npc.ColonizationCooldownSeconds < 0 {
+		invalid("universe_seed.npc.colonization_cooldown_seconds: can't be negative, got %d", npc.ColonizationCooldownSeconds)
+	}
+	for name, weight := range npc.Strategies {
+		if NPCStrategyFromString(name) == nil {
+			invalid("universe_seed.npc.strategies: unknown strategy %s", name)
+		} else if weight < 0 {
+			invalid("universe_seed.npc.strategies.%s: can't be negative, got %v", name, weight)
+		}
+	}
+
+	if c.Events.BaseChance < 0 || c.Events.BaseChance > 1 {
+		invalid("events.base_chance: has to be between 0 and 1, got %v", c.Events.BaseChance)
+	}
+	if c.Events.Duration <= 0 {
+		invalid("events.duration: has to be positive, got %d", c.Events.Duration)
+	}
+	for pt, multiplier := range c.Events.ChanceMultipliers {
+		if pt < 0 {
+			invalid("events.chance_multipliers: unknown planet type")
+		} else if multiplier < 0 {
+			invalid("events.chance_multipliers.%v: can't be negative, got %v", pt, multiplier)
+		}
+	}
+
+	if c.Taxes.ProductionTax < 0 {
+		invalid("taxes.production_tax: can't be negative, got %v", c.Taxes.ProductionTax)
+	}
+	if c.Taxes.PayoutRate < 0 || c.Taxes.PayoutRate > 1 {
+		invalid("taxes.payout_rate: has to be between 0 and 1, got %v", c.Taxes.PayoutRate)
+	}
+
+	lifecycle := c.Lifecycle
+	if lifecycle.InsolvencyThreshold < 0 {
+		invalid("lifecycle.insolvency_threshold: can't be negative, got %d", lifecycle.InsolvencyThreshold)
+	}
+	if lifecycle.BankruptcyTicks < 0 {
+		invalid("lifecycle.bankruptcy_ticks: can't be negative, got %d", lifecycle.BankruptcyTicks)
+	}
+	if lifecycle.RetirementChance < 0 || lifecycle.RetirementChance > 1 {
+		invalid("lifecycle.retirement_chance: has to be between 0 and 1, got %v", lifecycle.RetirementChance)
+	}
+	if lifecycle.ArrivalChance < 0 || lifecycle.ArrivalChance > 1 {
+		invalid("lifecycle.arrival_chance: has to be between 0 and 1, got %v", lifecycle.ArrivalChance)
+	}
+	if lifecycle.MaxNPCs < 0 {
+		invalid("lifecycle.max_npcs: can't be negative, got %d", lifecycle.MaxNPCs)
+	}
+
+	conflict := c.Conflict
+	if conflict.ShipCost <= 0 {
+		invalid("conflict.ship_cost: has to be positive, got %d", conflict.ShipCost)
+	}
+	if conflict.ShipStrength <= 0 {
+		invalid("conflict.ship_strength: has to be positive, got %d", conflict.ShipStrength)
+	}
+	if conflict.FortressStrength < 0 {
+		invalid("conflict.fortress_strength: can't be negative, got %d", conflict.FortressStrength)
+	}
+	if conflict.GarrisonShips < 0 {
+		invalid("conflict.garrison_ships: can't be negative, got %d", conflict.GarrisonShips)
+	}
+
+	piracy := c.Piracy
+	if piracy.BaseRisk < 0 || piracy.BaseRisk > 1 {
+		invalid("piracy.base_risk: has to be between 0 and 1, got %v", piracy.BaseRisk)
+	}
+	for pt, hazard := range piracy.Hazards {
+		if pt < 0 {
+			invalid("piracy.hazards: unknown planet type")
+		} else if hazard < 0 {
+			invalid("piracy.hazards.%v: can't be negative, got %v", pt, hazard)
+		}
+	}
+	if piracy.ValueScale <= 0 {
+		invalid("piracy.value_scale: has to be positive, got %d", piracy.ValueScale)
+	}
+	for name, share := range map[string]float64{
+		"max_risk":          piracy.MaxRisk,
+		"loss_share":        piracy.LossShare,
+		"escort_fee":        piracy.EscortFee,
+		"escort_protection": piracy.EscortProtection,
+	} {
+		if share < 0 || share > 1 {
+			invalid("piracy.%s: has to be between 0 and 1, got %v", name, share)
+		}
+	}
+
+	players := c.Players
+	if players.MaxPlayers < 0 {
+		invalid("players.max_players: can't be negative, got %d", players.MaxPlayers)
+	}
+	if players.StartingCredits < 0 {
+		invalid("players.starting_credits: can't be negative, got %d", players.StartingCredits)
+	}
+	if players.BasePrice <= 0 {
+		invalid("players.base_price: has to be positive, got %d", players.BasePrice)
+	}
+	if players.ColonizationCost < 0 {
+		invalid("players.colonization_cost: can't be negative, got %d", players.ColonizationCost)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *RawIntRange) asIntRange() intRange {
+	return intRange{Min: r.Min, Max: r.Max}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/tommzn/go-config"
 )
 
 type ConfigSuite struct {
@@ -19,7 +20,19 @@ func (s *ConfigSuite) TestDefaultConfig() {
 	cfg := DefaultConfig()
 	s.NotNil(cfg)
 	s.Equal(2*time.Second, cfg.TickDuration)
+	s.Equal(SkipMissedTicks, cfg.CatchUpPolicy)
+	s.Equal(5, cfg.MaxCatchUpTicks)
 	s.Equal(DefaultSeedConfig(), cfg.SeedConfig)
+	s.Equal(DefaultEventConfig(), cfg.Events)
+}
+
+func (s *ConfigSuite) TestDefaultEventConfig() {
+	events := DefaultEventConfig()
+	s.Equal(BaseEventChance, events.BaseChance)
+	s.Equal(5, events.Duration)
+	s.Equal(1.5, events.Multiplier(Desert))
+	s.InDelta(0.075, events.Chance(Desert), 1e-9)
+	s.Equal(1.0, EventConfig{}.Multiplier(Icy))
 }
 
 func (s *ConfigSuite) TestDefaultSeedConfig() {
@@ -65,6 +78,7 @@ func (s *ConfigSuite) TestDefaultNPCSeedConfig() {
 	s.Equal(intRange{Min: 200, Max: 50000}, npc.Credits)
 	s.Equal(intRange{Min: 50, Max: 600}, npc.MaxCargo)
 	s.Equal(3600, npc.ColonizationCooldownSeconds)
+	s.Len(npc.Strategies, 4)
 }
 
 func (s *ConfigSuite) TestLoadFrom() {
@@ -78,6 +92,10 @@ func (s *ConfigSuite) TestLoadFrom() {
 	s.NotEqual(DefaultConfig().TickDuration, cfg.TickDuration, "TickDuration should be overridden")
 	s.Greater(cfg.TickDuration, time.Duration(0), "TickDuration should be positive")
 
+	// Catch-up policy should be overridden
+	s.Equal(BurstMissedTicks, cfg.CatchUpPolicy)
+	s.Equal(3, cfg.MaxCatchUpTicks)
+
 	// NumberOfPlanets should be overridden and valid
 	s.NotEqual(DefaultConfig().SeedConfig.NumberOfPlanets, cfg.SeedConfig.NumberOfPlanets, "NumberOfPlanets should be overridden")
 	s.Greater(cfg.SeedConfig.NumberOfPlanets.Min, 0, "NumberOfPlanets.Min should be > 0")
@@ -111,3 +129,276 @@ func (s *ConfigSuite) TestLoadFrom() {
 		s.GreaterOrEqual(v.Max, v.Min, "NPC Offer %v: Max should be >= Min", k)
 	}
 }
+
+func (s *ConfigSuite) TestLoadFromKeepsUndefinedValues() {
+
+	conf, err := config.NewStaticConfigSource(`
+tick_duration: 1s
+universe_seed:
+  production:
+    min: 1
+    max: 2
+  resources:
+    - resource: Iron
+      min: 10
+      max: 20
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(time.Second, cfg.TickDuration)
+	s.Equal(intRange{Min: 1, Max: 2}, cfg.SeedConfig.Production)
+	s.Equal(intRange{Min: 10, Max: 20}, cfg.SeedConfig.Resources[Iron])
+	s.Equal(DefaultSeedConfig().Resources[Food], cfg.SeedConfig.Resources[Food])
+	s.Equal(DefaultSeedConfig().NumberOfPlanets, cfg.SeedConfig.NumberOfPlanets)
+	s.Equal(DefaultNPCSeedConfig(), cfg.SeedConfig.MPCConfig)
+}
+
+func (s *ConfigSuite) TestLoadFromRejectsUnparsableValues() {
+
+	for key, value := range map[string]string{"tick_duration": "1 second", "catch_up_policy": "skip_mised"} {
+		conf, err := config.NewStaticConfigSource(key + ": " + value).Load()
+		s.NoError(err)
+		cfg := DefaultConfig()
+		err = cfg.LoadFrom(conf)
+		s.Error(err)
+		s.Contains(err.Error(), key)
+	}
+}
+
+func (s *ConfigSuite) TestLoadEventConfig() {
+
+	conf, err := config.NewStaticConfigSource(`
+events:
+  base_chance: 0
+  duration: 3
+  chance_multipliers:
+    - planet_type: Icy
+      multiplier: 2
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(0.0, cfg.Events.BaseChance)
+	s.Equal(3, cfg.Events.Duration)
+	s.Equal(2.0, cfg.Events.Multiplier(Icy))
+	s.Equal(1.5, cfg.Events.Multiplier(Desert))
+}
+
+func (s *ConfigSuite) TestLoadStrategies() {
+
+	conf, err := config.NewStaticConfigSource(`
+universe_seed:
+  npc:
+    strategies:
+      - strategy: trader
+        weight: 3
+      - strategy: hoarder
+        weight: 1
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(map[string]float64{TraderStrategyName: 3, HoarderStrategyName: 1}, cfg.SeedConfig.MPCConfig.Strategies)
+	s.NoError(cfg.Validate())
+
+	cfg.SeedConfig.MPCConfig.Strategies["pirate"] = 1
+	s.ErrorContains(cfg.Validate(), "unknown strategy pirate")
+}
+
+func (s *ConfigSuite) TestLoadInvestmentShare() {
+
+	conf, err := config.NewStaticConfigSource(`
+universe_seed:
+  npc:
+    investment_share:
+      min: 5
+      max: 25
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(intRange{Min: 5, Max: 25}, cfg.SeedConfig.MPCConfig.InvestmentShare)
+	s.NoError(cfg.Validate())
+
+	cfg.SeedConfig.MPCConfig.InvestmentShare.Max = 120
+	s.ErrorContains(cfg.Validate(), "universe_seed.npc.investment_share")
+}
+
+func (s *ConfigSuite) TestLoadConflictConfig() {
+
+	conf, err := config.NewStaticConfigSource(`
+conflict:
+  ship_cost: 100
+  garrison_ships: 0
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(ConflictConfig{ShipCost: 100, ShipStrength: 10, FortressStrength: 50, GarrisonShips: 0}, cfg.Conflict)
+	s.NoError(cfg.Validate())
+
+	cfg.Conflict.ShipStrength = 0
+	cfg.Conflict.FortressStrength = -1
+	err = cfg.Validate()
+	s.ErrorContains(err, "conflict.ship_strength")
+	s.ErrorContains(err, "conflict.fortress_strength")
+}
+
+func (s *ConfigSuite) TestLoadPiracyConfig() {
+
+	conf, err := config.NewStaticConfigSource(`
+ledger_size: 50
+piracy:
+  base_risk: 0.05
+  hazards:
+    - planet_type: Icy
+      multiplier: 3.0
+  escort_fee: 0.02
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(50, cfg.LedgerSize)
+	s.Equal(0.05, cfg.Piracy.BaseRisk)
+	s.Equal(3.0, cfg.Piracy.Hazard(Icy))
+	s.Equal(2.0, cfg.Piracy.Hazard(GasGiant))
+	s.Equal(0.02, cfg.Piracy.EscortFee)
+	s.Equal(DefaultPiracyConfig().LossShare, cfg.Piracy.LossShare)
+	s.NoError(cfg.Validate())
+
+	cfg.Piracy.LossShare = 1.5
+	cfg.Piracy.ValueScale = 0
+	cfg.Piracy.Hazards[Desert] = -1
+	err = cfg.Validate()
+	s.ErrorContains(err, "piracy.loss_share")
+	s.ErrorContains(err, "piracy.value_scale")
+	s.ErrorContains(err, "piracy.hazards.Desert")
+}
+
+func (s *ConfigSuite) TestLoadPlayerConfig() {
+
+	conf, err := config.NewStaticConfigSource(`
+players:
+  max_players: 4
+  starting_credits: 2500
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(4, cfg.Players.MaxPlayers)
+	s.Equal(2500, cfg.Players.StartingCredits)
+	s.Equal(DefaultPlayerConfig().ColonizationCost, cfg.Players.ColonizationCost)
+	s.NoError(cfg.Validate())
+
+	cfg.Players.BasePrice = 0
+	cfg.Players.StartingCredits = -1
+	err = cfg.Validate()
+	s.ErrorContains(err, "players.base_price")
+	s.ErrorContains(err, "players.starting_credits")
+}
+
+func (s *ConfigSuite) TestLoadFactions() {
+
+	conf, err := config.NewStaticConfigSource(`
+universe_seed:
+  npc:
+    factions:
+      - Red
+      - Blue
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal([]string{"Red", "Blue"}, cfg.SeedConfig.MPCConfig.Factions)
+	s.NoError(cfg.Validate())
+
+	cfg.SeedConfig.MPCConfig.Factions = []string{"Red", "", "Red"}
+	err = cfg.Validate()
+	s.ErrorContains(err, "name can't be empty")
+	s.ErrorContains(err, "duplicate faction Red")
+}
+
+func (s *ConfigSuite) TestLoadTaxConfig() {
+
+	conf, err := config.NewStaticConfigSource(`
+taxes:
+  production_tax: 1.5
+universe_seed:
+  npc:
+    tariff:
+      min: 5
+      max: 10
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(TaxConfig{ProductionTax: 1.5, PayoutRate: DefaultTaxConfig().PayoutRate}, cfg.Taxes)
+	s.Equal(intRange{Min: 5, Max: 10}, cfg.SeedConfig.MPCConfig.Tariff)
+	s.NoError(cfg.Validate())
+
+	cfg.Taxes.PayoutRate = 2
+	cfg.SeedConfig.MPCConfig.Tariff.Max = 101
+	err = cfg.Validate()
+	s.ErrorContains(err, "taxes.payout_rate")
+	s.ErrorContains(err, "universe_seed.npc.tariff")
+}
+
+func (s *ConfigSuite) TestLoadLifecycleConfig() {
+
+	conf, err := config.NewStaticConfigSource(`
+lifecycle:
+  bankruptcy_ticks: 0
+  arrival_chance: 0.5
+  max_npcs: 20
+`).Load()
+	s.NoError(err)
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	expected := DefaultLifecycleConfig()
+	expected.BankruptcyTicks = 0
+	expected.ArrivalChance = 0.5
+	expected.MaxNPCs = 20
+	s.Equal(expected, cfg.Lifecycle)
+	s.NoError(cfg.Validate())
+
+	cfg.Lifecycle.RetirementChance = -1
+	s.ErrorContains(cfg.Validate(), "lifecycle.retirement_chance")
+}
+
+func (s *ConfigSuite) TestValidate() {
+	s.NoError(DefaultConfig().Validate())
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(loadConfigForTest(nil)))
+	s.NoError(cfg.Validate())
+
+	cfg = DefaultConfig()
+	cfg.TickDuration = 0
+	cfg.SeedConfig.Production = intRange{Min: 10, Max: 5}
+	cfg.SeedConfig.BuildingChance[Mine] = 1.5
+	cfg.SeedConfig.Resources[ResourceTypeFromString("Gold")] = intRange{Min: 1, Max: 2}
+	cfg.Events.Duration = 0
+	err := cfg.Validate()
+	s.Error(err)
+	for _, key := range []string{"tick_duration", "universe_seed.production", "universe_seed.building_chance.Mine", "universe_seed.resources", "events.duration"} {
+		s.Contains(err.Error(), key)
+	}
+}
+
+func (s *ConfigSuite) TestCatchUpPolicyFromString() {
+	s.Equal(SkipMissedTicks, CatchUpPolicyFromString("skip"))
+	s.Equal(BurstMissedTicks, CatchUpPolicyFromString("burst"))
+	s.Equal(CatchUpPolicy(-1), CatchUpPolicyFromString("unknown"))
+	s.Equal("burst", BurstMissedTicks.String())
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tommzn/go-config"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables to select the config source of backend services.
+const (
+	ConfigSourceEnv      = "CONFIG_SOURCE"
+	ConfigFileEnv        = "CONFIG_FILE"
+	ConfigOverridePrefix = "UTTE_"
+)
+
+// Supported values of CONFIG_SOURCE.
+const (
+	ConfigSourceS3      = "s3"
+	ConfigSourceFile    = "file"
+	ConfigSourceDefault = "default"
+)
+
+// LoadServiceConfig loads the config of a backend service from the source selected by CONFIG_SOURCE.
+// "s3" reads config from S3, see config.NewS3ConfigSourceFromEnv, "file" reads a local YAML or JSON
+// file at CONFIG_FILE and "default" starts with an empty config, so DefaultConfig applies.
+// Without CONFIG_SOURCE, a file is used if CONFIG_FILE is set, S3 if GO_CONFIG_S3_BUCKET is set
+// and the default otherwise. Environment overrides are applied to the loaded config in all cases.
+func LoadServiceConfig() (config.Config, error) {
+
+	conf, err := loadConfigSource(serviceConfigSource())
+	if err != nil {
+		return nil, err
+	}
+	return ApplyEnvOverrides(conf, os.Environ())
+}
+
+func serviceConfigSource() string {
+	if source := os.Getenv(ConfigSourceEnv); source != "" {
+		return strings.ToLower(source)
+	}
+	if os.Getenv(ConfigFileEnv) != "" {
+		return ConfigSourceFile
+	}
+	if os.Getenv("GO_CONFIG_S3_BUCKET") != "" {
+		return ConfigSourceS3
+	}
+	return ConfigSourceDefault
+}
+
+func loadConfigSource(source string) (config.Config, error) {
+	switch source {
+	case ConfigSourceS3:
+		configSource, err := config.NewS3ConfigSourceFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return configSource.Load()
+	case ConfigSourceFile:
+		configFile := os.Getenv(ConfigFileEnv)
+		if configFile == "" {
+			return nil, fmt.Errorf("config source %s requires %s", source, ConfigFileEnv)
+		}
+		conf, err := config.NewFileConfigSource(&configFile).Load()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read config %s: %w", configFile, err)
+		}
+		return conf, nil
+	case ConfigSourceDefault:
+		return config.NewStaticConfigSource("").Load()
+	default:
+		return nil, fmt.Errorf("unknown config source %s", source)
+	}
+}
+
+// ApplyEnvOverrides overrides config values by environment variables, passed as KEY=value like
+// os.Environ returns them. Only variables prefixed with UTTE_ are used. The rest of the name is
+// the lower case config key, double underscores separate nested keys, e.g.
+// UTTE_TICK_DURATION=500ms or UTTE_UNIVERSE_SEED__NUMBER_OF_PLANETS__MAX=4. Lists can't be overridden.
+func ApplyEnvOverrides(conf config.Config, environ []string) (config.Config, error) {
+
+	overrides := make(map[string]string)
+	for _, env := range environ {
+		key, value, ok := strings.Cut(env, "=")
+		if !ok || !strings.HasPrefix(key, ConfigOverridePrefix) || len(key) == len(ConfigOverridePrefix) {
+			continue
+		}
+		overrides[strings.ToLower(strings.TrimPrefix(key, ConfigOverridePrefix))] = value
+	}
+	if len(overrides) == 0 {
+		return conf, nil
+	}
+
+	values := make(map[string]any)
+	if err := conf.Unmarshal(&values); err != nil {
+		return nil, err
+	}
+	for key, value := range overrides {
+		setNestedValue(values, strings.Split(key, "__"), value)
+	}
+
+	yamlConfig, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return config.NewStaticConfigSource(string(yamlConfig)).Load()
+}
+
+// setNestedValue sets a value at passed path, replacing scalars on the way by nested maps.
+func setNestedValue(values map[string]any, path []string, value string) {
+	for _, key := range path[:len(path)-1] {
+		nested, ok := values[key].(map[string]any)
+		if !ok {
+			nested = make(map[string]any)
+			values[key] = nested
+		}
+		values = nested
+	}
+	values[path[len(path)-1]] = value
+}
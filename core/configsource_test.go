@@ -0,0 +1,104 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tommzn/go-config"
+)
+
+type ConfigSourceSuite struct {
+	suite.Suite
+}
+
+func TestConfigSourceSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSourceSuite))
+}
+
+func (s *ConfigSourceSuite) SetupTest() {
+	s.T().Setenv(ConfigSourceEnv, "")
+	s.T().Setenv(ConfigFileEnv, "")
+	s.T().Setenv("GO_CONFIG_S3_BUCKET", "")
+}
+
+func (s *ConfigSourceSuite) TestServiceConfigSource() {
+	s.Equal(ConfigSourceDefault, serviceConfigSource())
+	s.T().Setenv("GO_CONFIG_S3_BUCKET", "utte-config")
+	s.Equal(ConfigSourceS3, serviceConfigSource())
+	s.T().Setenv(ConfigFileEnv, "config.yml")
+	s.Equal(ConfigSourceFile, serviceConfigSource())
+	s.T().Setenv(ConfigSourceEnv, "Default")
+	s.Equal(ConfigSourceDefault, serviceConfigSource())
+}
+
+func (s *ConfigSourceSuite) TestLoadFromFile() {
+	s.T().Setenv(ConfigSourceEnv, ConfigSourceFile)
+	s.T().Setenv(ConfigFileEnv, "fixtures/testconfig.yml")
+	s.T().Setenv("UTTE_TICK_DURATION", "250ms")
+
+	conf, err := LoadServiceConfig()
+	s.NoError(err)
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(250*time.Millisecond, cfg.TickDuration)
+	s.Equal(BurstMissedTicks, cfg.CatchUpPolicy)
+}
+
+func (s *ConfigSourceSuite) TestLoadDefaults() {
+	s.T().Setenv(ConfigSourceEnv, ConfigSourceDefault)
+
+	conf, err := LoadServiceConfig()
+	s.NoError(err)
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(DefaultConfig(), cfg)
+}
+
+func (s *ConfigSourceSuite) TestLoadErrors() {
+	s.T().Setenv(ConfigSourceEnv, ConfigSourceFile)
+	_, err := LoadServiceConfig()
+	s.Error(err)
+
+	s.T().Setenv(ConfigFileEnv, "fixtures/missing.yml")
+	_, err = LoadServiceConfig()
+	s.Error(err)
+
+	s.T().Setenv(ConfigSourceEnv, "ftp")
+	_, err = LoadServiceConfig()
+	s.Error(err)
+}
+
+func (s *ConfigSourceSuite) TestApplyEnvOverrides() {
+	conf := loadConfigForTest(nil)
+	environ := []string{
+		"UTTE_UNIVERSE_SEED__NUMBER_OF_PLANETS__MAX=42",
+		"UTTE_UNIVERSE_SEED__NPC__COLONIZATION_COOLDOWN_SECONDS=0",
+		"UTTE_LOG__LOGLEVEL=debug",
+		"UTTE_=ignored",
+		"HOME=/root",
+	}
+
+	conf, err := ApplyEnvOverrides(conf, environ)
+	s.NoError(err)
+	s.Equal("debug", *conf.Get("log.loglevel", nil))
+
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(42, cfg.SeedConfig.NumberOfPlanets.Max)
+	s.Equal(0, cfg.SeedConfig.MPCConfig.ColonizationCooldownSeconds)
+	s.Len(cfg.SeedConfig.Resources, 3)
+}
+
+func (s *ConfigSourceSuite) TestApplyEnvOverridesOnEmptyConfig() {
+	conf, err := config.NewStaticConfigSource("").Load()
+	s.NoError(err)
+
+	conf, err = ApplyEnvOverrides(conf, []string{"UTTE_CATCH_UP_POLICY=burst", "UTTE_MAX_CATCH_UP_TICKS=7"})
+	s.NoError(err)
+	cfg := DefaultConfig()
+	s.NoError(cfg.LoadFrom(conf))
+	s.Equal(BurstMissedTicks, cfg.CatchUpPolicy)
+	s.Equal(7, cfg.MaxCatchUpTicks)
+	s.Equal(DefaultSeedConfig(), cfg.SeedConfig)
+}
@@ -0,0 +1,188 @@
+package core
+
+import "math"
+
+// ConquestRelationPenalty is the relation lost between the factions of attacker and defender
+// when a planet is captured or razed.
+const ConquestRelationPenalty = 20
+
+// Attack is a fleet dispatched by an NPC to attack a planet.
+type Attack struct {
+	Attacker *NPC
+	Ships    int
+}
+
+// BattleOutcome defines how a battle for a planet ended.
+type BattleOutcome int
+
+const (
+	BattleRepelled BattleOutcome = iota // the defence destroyed the attacking fleet
+	BattleCaptured                      // the attacker took over the planet
+	BattleRazed                         // the attacker won, but had too few ships left to hold the planet
+)
+
+func (o BattleOutcome) String() string {
+	switch o {
+	case BattleRepelled:
+		return "Repelled"
+	case BattleCaptured:
+		return "Captured"
+	case BattleRazed:
+		return "Razed"
+	default:
+		return "Unknown"
+	}
+}
+
+// BattleReport describes a battle for a planet.
+type BattleReport struct {
+	Planet          *Planet
+	Attacker        *NPC
+	Defender        *NPC // owner of the planet before the battle
+	Ships           int  // ships of the attacking fleet
+	AttackStrength  int
+	DefenceStrength int
+	AttackerLosses  int // ships the attacker lost
+	DefenderLosses  int // garrison ships the defender lost
+	Outcome         BattleOutcome
+}
+
+// Defence returns the defence strength of a planet, its garrison and all fortresses.
+func (p *Planet) Defence(config ConflictConfig) int {
+	defence := p.Garrison * config.ShipStrength
+	for _, b := range p.Buildings {
+		if b.Type == Fortress {
+			defence += b.Level * config.FortressStrength
+		}
+	}
+	return defence
+}
+
+// Hostile returns true if an NPC may attack a planet, because it's owned by an NPC of another faction
+// which isn't allied.
+func Hostile(npc *NPC, p *Planet) bool {
+	return p.Owner != nil && p.Owner != npc && !npc.Faction.Allied(p.Owner.Faction)
+}
+
+// ShipsToConquer returns how many ships an NPC needs to defeat the defence of a planet and
+// keep a garrison on it.
+func ShipsToConquer(p *Planet, config ConflictConfig) int {
+	return p.Defence(config)/max(1, config.ShipStrength) + 1 + config.GarrisonShips
+}
+
+// ArmFleet buys ships for an NPC at configured ship cost, as many as its credits allow.
+// Returns the number of ships bought.
+func ArmFleet(npc *NPC, ships int, config ConflictConfig, log Log) int {
+	ships = min(ships, npc.Credits/max(1, config.ShipCost))
+	if ships <= 0 {
+		log.Debug("NPC %s: Can't afford any ships.", npc.Name)
+		return 0
+	}
+	npc.Credits -= ships * config.ShipCost
+	npc.Ships += ships
+	log.Info("NPC %s bought %d ships for %d credits, fleet has %d ships.", npc.Name, ships, ships*config.ShipCost, npc.Ships)
+	return ships
+}
+
+// DispatchFleet sends ships of an NPC to attack a hostile planet. The battle is fought when
+// battles are resolved at the end of the tick. Returns false if the attack isn't possible.
+func DispatchFleet(npc *NPC, p *Planet, ships int, log Log) bool {
+	if !Hostile(npc, p) {
+		log.Debug("NPC %s: Planet %s isn't hostile.", npc.Name, p.Name)
+		return false
+	}
+	if ships <= 0 || ships > npc.Ships {
+		log.Debug("NPC %s: Can't dispatch %d ships, fleet has %d ships.", npc.Name, ships, npc.Ships)
+		return false
+	}
+	npc.Ships -= ships
+	p.Attacks = append(p.Attacks, Attack{Attacker: npc, Ships: ships})
+	log.Info("NPC %s dispatched %d ships to attack planet %s of %s.", npc.Name, ships, p.Name, p.Owner.Name)
+	return true
+}
+
+// ResolveBattles fights all attacks on all planets, in the order they've been dispatched, and
+// returns a report for each battle. An attacker wins if its strength exceeds the planet's defence.
+// It loses ships equal to the defence strength, the garrison is destroyed and each fortress loses a
+// level. If enough ships survive, the planet is captured and garrisoned, otherwise it's razed and
+// loses all buildings and its owner. A repelled attacker loses all ships, the garrison loses ships
+// equal to the attack strength. Surviving ships return to the attacker's fleet.
+func ResolveBattles(planets []*Planet, config ConflictConfig, log Log) []BattleReport {
+	reports := []BattleReport{}
+	for _, p := range planets {
+		for _, attack := range p.Attacks {
+			if !Hostile(attack.Attacker, p) {
+				// captured or razed by an earlier attack in this tick
+				attack.Attacker.Ships += attack.Ships
+				log.Debug("NPC %s: Fleet of %d ships returned from planet %s, it's not hostile anymore.", attack.Attacker.Name, attack.Ships, p.Name)
+				continue
+			}
+			report := fight(p, attack, config)
+			reports = append(reports, report)
+			log.Info("Battle for planet %s: %d ships of %s attacked %s, %v. Attack %d, defence %d, losses %d/%d.",
+				p.Name, report.Ships, report.Attacker.Name, report.Defender.Name, report.Outcome,
+				report.AttackStrength, report.DefenceStrength, report.AttackerLosses, report.DefenderLosses)
+			if report.Outcome != BattleRepelled {
+				ChangeRelation(report.Attacker.Faction, report.Defender.Faction, -ConquestRelationPenalty, log)
+			}
+		}
+		p.Attacks = nil
+	}
+	return reports
+}
+
+// fight resolves a single attack on a planet.
+func fight(p *Planet, attack Attack, config ConflictConfig) BattleReport {
+	report := BattleReport{
+		Planet:          p,
+		Attacker:        attack.Attacker,
+		Defender:        p.Owner,
+		Ships:           attack.Ships,
+		AttackStrength:  attack.Ships * config.ShipStrength,
+		DefenceStrength: p.Defence(config),
+	}
+
+	if report.AttackStrength <= report.DefenceStrength {
+		report.Outcome = BattleRepelled
+		report.AttackerLosses = attack.Ships
+		report.DefenderLosses = min(p.Garrison, report.AttackStrength/max(1, config.ShipStrength))
+		p.Garrison -= report.DefenderLosses
+		return report
+	}
+
+	losses := int(math.Ceil(float64(report.DefenceStrength) / float64(max(1, config.ShipStrength))))
+	report.AttackerLosses = min(attack.Ships, losses)
+	report.DefenderLosses = p.Garrison
+	survivors := attack.Ships - report.AttackerLosses
+	p.Garrison = 0
+	damageFortresses(p)
+
+	if survivors > 0 && survivors >= config.GarrisonShips {
+		report.Outcome = BattleCaptured
+		p.Owner = attack.Attacker
+		p.Garrison = config.GarrisonShips
+		attack.Attacker.Ships += survivors - config.GarrisonShips
+		return report
+	}
+	report.Outcome = BattleRazed
+	p.Owner = nil
+	p.Buildings = []*Building{}
+	attack.Attacker.Ships += survivors
+	return report
+}
+
+// damageFortresses reduces the level of all fortresses on a planet by one, fortresses on
+// level one are destroyed.
+func damageFortresses(p *Planet) {
+	buildings := make([]*Building, 0, len(p.Buildings))
+	for _, b := range p.Buildings {
+		if b.Type == Fortress {
+			if b.Level <= 1 {
+				continue
+			}
+			b.Level--
+		}
+		buildings = append(buildings, b)
+	}
+	p.Buildings = buildings
+}
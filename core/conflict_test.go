@@ -0,0 +1,122 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConflictSuite struct {
+	suite.Suite
+	attacker *NPC
+	defender *NPC
+	fortress *Building
+	planet   *Planet
+	config   ConflictConfig
+	log      *mockLog
+}
+
+func TestConflictSuite(t *testing.T) {
+	suite.Run(t, new(ConflictSuite))
+}
+
+func (s *ConflictSuite) SetupTest() {
+	s.log = &mockLog{}
+	s.config = DefaultConflictConfig()
+	s.attacker = &NPC{Name: "Attacker", Credits: 1000}
+	s.defender = &NPC{Name: "Defender"}
+	factions := SetupFactions([]*NPC{s.attacker, s.defender}, []string{"Red", "Blue"})
+	ChangeRelation(factions[0], factions[1], RivalryThreshold, s.log)
+	s.fortress = &Building{Type: Fortress, Level: 2}
+	s.planet = &Planet{
+		Name:      "Stronghold",
+		Owner:     s.defender,
+		Garrison:  3,
+		Buildings: []*Building{{Type: Mine, Level: 1}, s.fortress},
+	}
+}
+
+func (s *ConflictSuite) TestDefence() {
+	s.Equal(3*10+2*50, s.planet.Defence(s.config))
+	s.Equal(13+1+2, ShipsToConquer(s.planet, s.config))
+}
+
+func (s *ConflictSuite) TestHostile() {
+	s.True(Hostile(s.attacker, s.planet))
+	s.False(Hostile(s.defender, s.planet))
+
+	ChangeRelation(s.attacker.Faction, s.defender.Faction, -RivalryThreshold, s.log)
+	s.True(Hostile(s.attacker, s.planet))
+	ChangeRelation(s.attacker.Faction, s.defender.Faction, AllianceThreshold, s.log)
+	s.False(Hostile(s.attacker, s.planet))
+
+	s.defender.Faction = s.attacker.Faction
+	s.False(Hostile(s.attacker, s.planet))
+}
+
+func (s *ConflictSuite) TestArmFleet() {
+	s.attacker.Credits = 900
+	s.Equal(3, ArmFleet(s.attacker, 5, s.config, s.log))
+	s.Equal(3, s.attacker.Ships)
+	s.Equal(150, s.attacker.Credits)
+	s.Equal(0, ArmFleet(s.attacker, 1, s.config, s.log))
+}
+
+func (s *ConflictSuite) TestDispatchFleet() {
+	s.attacker.Ships = 5
+	s.False(DispatchFleet(s.attacker, s.planet, 6, s.log))
+	s.False(DispatchFleet(s.defender, s.planet, 1, s.log))
+	s.True(DispatchFleet(s.attacker, s.planet, 4, s.log))
+	s.Equal(1, s.attacker.Ships)
+	s.Equal([]Attack{{Attacker: s.attacker, Ships: 4}}, s.planet.Attacks)
+}
+
+func (s *ConflictSuite) TestRepelled() {
+	s.planet.Attacks = []Attack{{Attacker: s.attacker, Ships: 2}}
+	reports := ResolveBattles([]*Planet{s.planet}, s.config, s.log)
+	s.Equal([]BattleReport{{
+		Planet:          s.planet,
+		Attacker:        s.attacker,
+		Defender:        s.defender,
+		Ships:           2,
+		AttackStrength:  20,
+		DefenceStrength: 130,
+		AttackerLosses:  2,
+		DefenderLosses:  2,
+		Outcome:         BattleRepelled,
+	}}, reports)
+	s.Equal(s.defender, s.planet.Owner)
+	s.Equal(1, s.planet.Garrison)
+	s.Equal(0, s.attacker.Ships)
+	s.Empty(s.planet.Attacks)
+}
+
+func (s *ConflictSuite) TestCaptured() {
+	s.planet.Attacks = []Attack{{Attacker: s.attacker, Ships: 16}, {Attacker: s.attacker, Ships: 5}}
+	reports := ResolveBattles([]*Planet{s.planet}, s.config, s.log)
+	s.Len(reports, 1)
+	s.Equal(BattleCaptured, reports[0].Outcome)
+	s.Equal(13, reports[0].AttackerLosses)
+	s.Equal(3, reports[0].DefenderLosses)
+	s.Equal(s.attacker, s.planet.Owner)
+	s.Equal(s.config.GarrisonShips, s.planet.Garrison)
+	s.Equal(1, s.fortress.Level)
+	s.Len(s.planet.Buildings, 2)
+	// one surviving ship and the second fleet, which had nothing left to attack, return
+	s.Equal(1+5, s.attacker.Ships)
+	s.Equal(RivalryThreshold-ConquestRelationPenalty, s.attacker.Faction.Relation(s.defender.Faction))
+}
+
+func (s *ConflictSuite) TestRazed() {
+	s.fortress.Level = 1
+	s.planet.Attacks = []Attack{{Attacker: s.attacker, Ships: 9}}
+	reports := ResolveBattles([]*Planet{s.planet}, s.config, s.log)
+	s.Len(reports, 1)
+	s.Equal(BattleRazed, reports[0].Outcome)
+	s.Equal(8, reports[0].AttackerLosses)
+	s.Nil(s.planet.Owner)
+	s.Empty(s.planet.Buildings)
+	s.Equal(0, s.planet.Garrison)
+	// a single surviving ship can't hold the planet
+	s.Equal(1, s.attacker.Ships)
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxSteps is the number of ticks a single step may run at most.
+const MaxSteps = 1000
+
+var (
+	ErrGameNotPaused = errors.New("game must be paused to step")
+	ErrInvalidSteps  = fmt.Errorf("number of steps must be between 1 and %d", MaxSteps)
+	ErrInvalidSpeed  = errors.New("speed must be positive")
+)
+
+// GameStatus is a snapshot of game loop control state and tick metrics.
+type GameStatus struct {
+	Paused       bool
+	Speed        float64
+	TickInterval time.Duration
+	Metrics      TickMetrics
+}
+
+// Pause stops the game loop from running further ticks until Resume is called.
+func (g *Game) Pause() {
+	g.mu.Lock()
+	g.paused = true
+	g.mu.Unlock()
+	g.log.Info("Game paused.")
+	g.notifyControlChange()
+}
+
+// Resume continues a paused game loop.
+func (g *Game) Resume() {
+	g.mu.Lock()
+	g.paused = false
+	g.mu.Unlock()
+	g.log.Info("Game resumed.")
+	g.notifyControlChange()
+}
+
+// IsPaused returns true if the game loop is currently paused.
+func (g *Game) IsPaused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
+
+// Step runs n ticks immediately, at most MaxSteps. The game has to be paused to avoid interleaving with
+// the game loop. The game is unlocked between ticks, so requests and streams are served while stepping,
+// and stepping stops if the game is resumed meanwhile.
+func (g *Game) Step(n int) error {
+	if n <= 0 || n > MaxSteps {
+		return ErrInvalidSteps
+	}
+	if !g.IsPaused() {
+		return ErrGameNotPaused
+	}
+	g.log.Info("Stepping game by %d ticks.", n)
+	defer g.log.Flush()
+	for i := 0; i < n; i++ {
+		if !g.IsPaused() {
+			g.log.Info("Game resumed after %d of %d steps.", i, n)
+			return ErrGameNotPaused
+		}
+		g.tick(0)
+	}
+	return nil
+}
+
+// SetSpeed changes the time scale of the game loop. A speed of 2 runs ticks twice as often
+// as defined by the configured tick duration, a speed of 0.5 half as often.
+func (g *Game) SetSpeed(speed float64) error {
+	if speed <= 0 {
+		return ErrInvalidSpeed
+	}
+	g.mu.Lock()
+	g.speed = speed
+	g.mu.Unlock()
+	g.log.Info("Game speed set to %.2f.", speed)
+	g.notifyControlChange()
+	return nil
+}
+
+// Status returns current control state and tick metrics of the game.
+func (g *Game) Status() GameStatus {
+	interval := g.tickInterval()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return GameStatus{
+		Paused:       g.paused,
+		Speed:        g.speed,
+		TickInterval: interval,
+		Metrics:      g.metrics,
+	}
+}
+
+// notifyControlChange wakes up the game loop to reschedule its next tick.
+func (g *Game) notifyControlChange() {
+	select {
+	case g.controlChanges <- struct{}{}:
+	default:
+	}
+}
+
+// GameInfo describes a running game.
+type GameInfo struct {
+	Ticks        uint64
+	TickDuration time.Duration
+	Seed         int64
+	Version      string
+}
+
+// Version of the server, set at build time, e.g. with -ldflags "-X github.com/tommzn/utte-universe/core.Version=v1.2.0".
+var Version = "dev"
+
+// SetSeed records the seed the universe and the random generator of the game have been created with.
+func (g *Game) SetSeed(seed int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.seed = seed
+}
+
+// Info returns tick number, tick duration and seed of the game and the version of the server.
+func (g *Game) Info() GameInfo {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return GameInfo{Ticks: g.metrics.Ticks, TickDuration: g.config.TickDuration, Seed: g.seed, Version: Version}
+}
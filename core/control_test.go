@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ControlSuite struct {
+	suite.Suite
+	game *Game
+	log  *mockLog
+}
+
+func TestControlSuite(t *testing.T) {
+	suite.Run(t, new(ControlSuite))
+}
+
+func (s *ControlSuite) SetupTest() {
+	s.log = &mockLog{}
+	config := Config{TickDuration: 10 * time.Millisecond, MaxCatchUpTicks: 2}
+	planets := []*Planet{
+		{
+			Name:      "TestPlanet",
+			Type:      TerraLike,
+			Resources: map[ResourceType]int{Iron: 10, Food: 10, Fuel: 10},
+			Modifiers: map[ResourceType]float64{},
+			Buildings: []*Building{
+				{
+					Type:       Mine,
+					Production: map[ResourceType]int{Iron: 2},
+					Modifiers:  map[ResourceType]float64{},
+					Level:      1,
+				},
+			},
+		},
+	}
+	s.game = NewGameService(config, &mockRand{seekVal: 0.9, ofVal: 0}, s.log, planets, []*NPC{})
+}
+
+func (s *ControlSuite) TestPauseAndResume() {
+	s.False(s.game.IsPaused())
+	s.game.Pause()
+	s.True(s.game.IsPaused())
+	s.True(s.game.Status().Paused)
+	s.game.Resume()
+	s.False(s.game.IsPaused())
+}
+
+func (s *ControlSuite) TestStepRequiresPause() {
+	s.ErrorIs(s.game.Step(1), ErrGameNotPaused)
+	s.game.Pause()
+	s.ErrorIs(s.game.Step(0), ErrInvalidSteps)
+	s.ErrorIs(s.game.Step(MaxSteps+1), ErrInvalidSteps)
+	s.Equal(uint64(0), s.game.Status().Metrics.Ticks)
+}
+
+func (s *ControlSuite) TestStep() {
+	s.game.Pause()
+	s.NoError(s.game.Step(3))
+	s.Equal(uint64(3), s.game.Status().Metrics.Ticks)
+	s.Equal(16, s.game.Planets[0].Resources[Iron])
+}
+
+func (s *ControlSuite) TestSetSpeed() {
+	s.ErrorIs(s.game.SetSpeed(0), ErrInvalidSpeed)
+	s.ErrorIs(s.game.SetSpeed(-1), ErrInvalidSpeed)
+	s.NoError(s.game.SetSpeed(2))
+	status := s.game.Status()
+	s.Equal(2.0, status.Speed)
+	s.Equal(5*time.Millisecond, status.TickInterval)
+}
+
+func (s *ControlSuite) TestPausedGameLoopDoesNotTick() {
+	s.game.Pause()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.game.GameLoop(ctx)
+		close(done)
+	}()
+	time.Sleep(40 * time.Millisecond)
+	s.Equal(uint64(0), s.game.Status().Metrics.Ticks)
+
+	s.game.Resume()
+	time.Sleep(40 * time.Millisecond)
+	cancel()
+	<-done
+	s.Greater(s.game.Status().Metrics.Ticks, uint64(0))
+}
+
+func (s *ControlSuite) TestRecordTickOverrun() {
+	s.game.recordTick(20*time.Millisecond, 10*time.Millisecond)
+	s.game.recordTick(5*time.Millisecond, 10*time.Millisecond)
+	metrics := s.game.Status().Metrics
+	s.Equal(uint64(2), metrics.Ticks)
+	s.Equal(uint64(1), metrics.Overruns)
+	s.Equal(5*time.Millisecond, metrics.LastTickDuration)
+	s.Equal(20*time.Millisecond, metrics.MaxTickDuration)
+	s.Equal(25*time.Millisecond, metrics.TotalTickDuration)
+	s.NotEmpty(s.log.errors)
+}
+
+func (s *ControlSuite) TestNextTickAtOnSchedule() {
+	interval := 10 * time.Millisecond
+	scheduled := time.Now()
+	next := s.game.nextTickAt(scheduled, scheduled.Add(2*time.Millisecond), interval)
+	s.Equal(scheduled.Add(interval), next)
+	s.Equal(0, s.game.metrics.TicksBehind)
+}
+
+func (s *ControlSuite) TestNextTickAtSkipsMissedTicks() {
+	s.game.config.CatchUpPolicy = SkipMissedTicks
+	interval := 10 * time.Millisecond
+	scheduled := time.Now()
+	now := scheduled.Add(35 * time.Millisecond)
+	next := s.game.nextTickAt(scheduled, now, interval)
+	s.Equal(scheduled.Add(40*time.Millisecond), next)
+	s.Equal(3, s.game.metrics.TicksBehind)
+	s.Equal(uint64(3), s.game.metrics.SkippedTicks)
+}
+
+func (s *ControlSuite) TestNextTickAtBurstsMissedTicks() {
+	s.game.config.CatchUpPolicy = BurstMissedTicks
+	interval := 10 * time.Millisecond
+	scheduled := time.Now()
+
+	next := s.game.nextTickAt(scheduled, scheduled.Add(25*time.Millisecond), interval)
+	s.Equal(scheduled.Add(interval), next)
+	s.Equal(2, s.game.metrics.TicksBehind)
+	s.Equal(uint64(0), s.game.metrics.SkippedTicks)
+
+	next = s.game.nextTickAt(scheduled, scheduled.Add(55*time.Millisecond), interval)
+	s.Equal(scheduled.Add(40*time.Millisecond), next)
+	s.Equal(5, s.game.metrics.TicksBehind)
+	s.Equal(uint64(3), s.game.metrics.SkippedTicks)
+}
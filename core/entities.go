@@ -30,6 +30,22 @@ func (pt PlanetType) String() string {
 	}
 }
 
+// PlanetTypeFromString converts a string to a PlanetType.
+func PlanetTypeFromString(s string) PlanetType {
+	switch s {
+	case "Terra-like", "TerraLike":
+		return TerraLike
+	case "Desert":
+		return Desert
+	case "Gas Giant", "GasGiant":
+		return GasGiant
+	case "Icy":
+		return Icy
+	default:
+		return PlanetType(-1) // Unknown
+	}
+}
+
 // BuildingType represents the type of a building.
 type BuildingType int
 
@@ -38,6 +54,7 @@ const (
 	Farm
 	Refinery
 	City
+	Fortress // defends a planet against attacks
 )
 
 func (b BuildingType) String() string {
@@ -50,6 +67,8 @@ func (b BuildingType) String() string {
 		return "Refinery"
 	case City:
 		return "City"
+	case Fortress:
+		return "Fortress"
 	default:
 		return "Unknown"
 	}
@@ -66,6 +85,8 @@ func BuildingTypeFromString(s string) BuildingType {
 		return Refinery
 	case "City":
 		return City
+	case "Fortress":
+		return Fortress
 	default:
 		return BuildingType(-1) // Unknown
 	}
@@ -125,6 +146,10 @@ type Planet struct {
 	Modifiers map[ResourceType]float64 `json:"modifiers"`
 	Buildings []*Building              `json:"buildings"`
 	Owner     *NPC                     `json:"owner"`
+	Treasury  int                      `json:"treasury"` // taxes and tariffs not yet paid out to the owner
+	Garrison  int                      `json:"garrison"` // ships stationed on the planet to defend it
+	Attacks   []Attack                 `json:"-"`        // fleets dispatched to attack the planet, resolved at the end of a tick
+	Player    *Player                  `json:"-"`        // player owning the planet instead of an NPC, nil if there's none
 }
 
 // NPC represents a non-player character, including trading offers, credits, cargo, and cooldowns.
@@ -135,6 +160,25 @@ type NPC struct {
 	Cargo                map[ResourceType]int `json:"cargo"`
 	MaxCargo             int                  `json:"maxCargo"`
 	ColonizationCooldown time.Time            `json:"colonizationCooldown"`
+	Strategy             NPCStrategy          `json:"-"`               // decides actions, RandomStrategy if not set
+	InvestmentShare      int                  `json:"investmentShare"` // percent of credits invested into owned planets
+	Tariff               int                  `json:"tariff"`          // percent other traders pay on trades with owned planets
+	Revenue              int                  `json:"revenue"`         // credits received from treasuries of owned planets
+	InsolventTicks       int                  `json:"insolventTicks"`  // consecutive ticks with credits below insolvency threshold
+	Faction              *Faction             `json:"-"`               // faction the NPC belongs to, none if nil
+	Ships                int                  `json:"ships"`           // fleet available to attack planets
+	Location             *Planet              `json:"-"`               // planet the NPC last traded with, nil before its first trade
+}
+
+// Player represents a human participant, who trades with planets, colonizes and develops them
+// through requests instead of a strategy.
+type Player struct {
+	ID        string               `json:"-"` // secret identifying the player in requests
+	Account   string               `json:"-"` // subject of the token the player joined with, empty without authentication
+	Name      string               `json:"name"`
+	Credits   int                  `json:"credits"`
+	Inventory map[ResourceType]int `json:"inventory"`
+	JoinedAt  time.Time            `json:"joinedAt"`
 }
 
 // TradeAction represents a trade action between an NPC and a planet.
@@ -23,11 +23,21 @@ func (s *EntitiesSuite) TestPlanetTypeString() {
 	s.Equal("Unknown", PlanetType(999).String())
 }
 
+func (s *EntitiesSuite) TestPlanetTypeFromString() {
+	s.Equal(TerraLike, PlanetTypeFromString("Terra-like"))
+	s.Equal(TerraLike, PlanetTypeFromString("TerraLike"))
+	s.Equal(Desert, PlanetTypeFromString("Desert"))
+	s.Equal(GasGiant, PlanetTypeFromString("Gas Giant"))
+	s.Equal(Icy, PlanetTypeFromString("Icy"))
+	s.Equal(PlanetType(-1), PlanetTypeFromString("Lava"))
+}
+
 func (s *EntitiesSuite) TestBuildingTypeString() {
 	s.Equal("Mine", Mine.String())
 	s.Equal("Farm", Farm.String())
 	s.Equal("Refinery", Refinery.String())
 	s.Equal("City", City.String())
+	s.Equal("Fortress", Fortress.String())
 	s.Equal("Unknown", BuildingType(999).String())
 }
 
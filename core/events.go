@@ -1,5 +1,6 @@
 package core
 
+// BaseEventChance is the default chance per tick that an event is triggered on a randomly picked planet.
 const BaseEventChance = 0.05
 
 func ShouldTriggerEvent(rand Random, chance float64, log Log) bool {
@@ -8,7 +9,21 @@ func ShouldTriggerEvent(rand Random, chance float64, log Log) bool {
 	return result
 }
 
-func MaybeTriggerEvent(planets []*Planet, activeEvents []*Event, rand Random, log Log) []*Event {
+// EventChanceMultiplier returns the default factor applied to the base event chance for planets of given type.
+func EventChanceMultiplier(pt PlanetType) float64 {
+	switch pt {
+	case Desert:
+		return 1.5 // more frequent events
+	case GasGiant:
+		return 0.8
+	case Icy:
+		return 1.2
+	default:
+		return 1.0
+	}
+}
+
+func MaybeTriggerEvent(planets []*Planet, activeEvents []*Event, config EventConfig, rand Random, log Log) []*Event {
 
 	if len(planets) == 0 {
 		log.Info("No planets available for event triggering.")
@@ -20,18 +35,7 @@ func MaybeTriggerEvent(planets []*Planet, activeEvents []*Event, rand Random, lo
 	log.Debug("Selected planet %s for event consideration.", p.Name)
 
 	// Adjust chance based on planet type
-	chance := BaseEventChance
-	switch p.Type {
-	case TerraLike:
-		chance *= 1.0
-	case Desert:
-		chance *= 1.5 // more frequent events
-	case GasGiant:
-		chance *= 0.8
-	case Icy:
-		chance *= 1.2
-	}
-	// 5% chance per tick to trigger a new event
+	chance := config.Chance(p.Type)
 	if ShouldTriggerEvent(rand, chance, log) {
 
 		// select whether the event targets a building or the whole planet
@@ -55,42 +59,47 @@ func MaybeTriggerEvent(planets []*Planet, activeEvents []*Event, rand Random, lo
 			TargetPlanet:   p,
 			TargetBuilding: building,
 			ResourceBoost:  boost,
-			Duration:       5,
-			RemainingTicks: 5,
+			Duration:       config.Duration,
+			RemainingTicks: config.Duration,
 		}
 
 		// apply boost immediately
-		if e.Target == PlanetTarget && e.TargetPlanet != nil {
-			if e.TargetPlanet.Modifiers == nil {
-				e.TargetPlanet.Modifiers = make(map[ResourceType]float64)
-			}
-			for res, multiplier := range e.ResourceBoost {
-				if e.TargetPlanet.Modifiers[res] == 0 {
-					e.TargetPlanet.Modifiers[res] = 1.0
-				}
-				e.TargetPlanet.Modifiers[res] *= multiplier
-				log.Info("Applied event '%s' boost %.2f to %v on planet %s.", e.Name, multiplier, res, p.Name)
-			}
-		} else if e.Target == BuildingTarget && e.TargetBuilding != nil {
-			if e.TargetBuilding.Modifiers == nil {
-				e.TargetBuilding.Modifiers = make(map[ResourceType]float64)
-			}
-			for res, multiplier := range e.ResourceBoost {
-				if e.TargetBuilding.Modifiers[res] == 0 {
-					e.TargetBuilding.Modifiers[res] = 1.0
-				}
-				e.TargetBuilding.Modifiers[res] *= multiplier
-				log.Info("Applied event '%s' boost %.2f to %v on building %v.", e.Name, multiplier, res, e.TargetBuilding.Type)
-			}
-		}
+		ApplyEvent(e, log)
 
 		activeEvents = append(activeEvents, e)
-		log.Info("Event '%s' triggered on planet %s.", e.Name, p.Name)
+		Emit(log, eventTriggered(e))
 	}
 
 	return activeEvents
 }
 
+// ApplyEvent applies the resource boost of passed event to its target planet or building.
+func ApplyEvent(e *Event, log Log) {
+	if e.Target == PlanetTarget && e.TargetPlanet != nil {
+		if e.TargetPlanet.Modifiers == nil {
+			e.TargetPlanet.Modifiers = make(map[ResourceType]float64)
+		}
+		for res, multiplier := range e.ResourceBoost {
+			if e.TargetPlanet.Modifiers[res] == 0 {
+				e.TargetPlanet.Modifiers[res] = 1.0
+			}
+			e.TargetPlanet.Modifiers[res] *= multiplier
+			log.Debug("Applied event '%s' boost %.2f to %v on planet %s.", e.Name, multiplier, res, e.TargetPlanet.Name)
+		}
+	} else if e.Target == BuildingTarget && e.TargetBuilding != nil {
+		if e.TargetBuilding.Modifiers == nil {
+			e.TargetBuilding.Modifiers = make(map[ResourceType]float64)
+		}
+		for res, multiplier := range e.ResourceBoost {
+			if e.TargetBuilding.Modifiers[res] == 0 {
+				e.TargetBuilding.Modifiers[res] = 1.0
+			}
+			e.TargetBuilding.Modifiers[res] *= multiplier
+			log.Debug("Applied event '%s' boost %.2f to %v on building %v.", e.Name, multiplier, res, e.TargetBuilding.Type)
+		}
+	}
+}
+
 func UpdateEvents(activeEvents []*Event, log Log) []*Event {
 	var remaining []*Event
 	for _, e := range activeEvents {
@@ -113,7 +122,7 @@ func UpdateEvents(activeEvents []*Event, log Log) []*Event {
 				if e.TargetPlanet.Modifiers[res] == 0 {
 					e.TargetPlanet.Modifiers[res] = 1.0
 				}
-				log.Info("Reverted event '%s' boost %.2f from %v on planet %s.", e.Name, multiplier, res, e.TargetPlanet.Name)
+				log.Debug("Reverted event '%s' boost %.2f from %v on planet %s.", e.Name, multiplier, res, e.TargetPlanet.Name)
 			}
 		} else if e.Target == BuildingTarget && e.TargetBuilding != nil {
 			for res, multiplier := range e.ResourceBoost {
@@ -128,13 +137,30 @@ func UpdateEvents(activeEvents []*Event, log Log) []*Event {
 				if e.TargetBuilding.Modifiers[res] == 0 {
 					e.TargetBuilding.Modifiers[res] = 1.0
 				}
-				log.Info("Reverted event '%s' boost %.2f from %v on building %v.", e.Name, multiplier, res, e.TargetBuilding.Type)
+				log.Debug("Reverted event '%s' boost %.2f from %v on building %v.", e.Name, multiplier, res, e.TargetBuilding.Type)
 			}
 		}
+		var planet string
+		if e.TargetPlanet != nil {
+			planet = e.TargetPlanet.Name
+		}
+		Emit(log, EventExpired{Name: e.Name, Planet: planet})
 	}
 	return remaining
 }
 
+// eventTriggered returns the log event of an event which started.
+func eventTriggered(e *Event) EventTriggered {
+	triggered := EventTriggered{Name: e.Name, Duration: e.Duration}
+	if e.TargetPlanet != nil {
+		triggered.Planet = e.TargetPlanet.Name
+	}
+	if e.TargetBuilding != nil {
+		triggered.Building = e.TargetBuilding.Type.String()
+	}
+	return triggered
+}
+
 func ChooseEventName(p *Planet, target EventTarget, rand Random) string {
 
 	if target == BuildingTarget && len(p.Buildings) > 0 {
@@ -28,13 +28,13 @@ func (s *EventsSuite) SetupTest() {
 
 func (s *EventsSuite) TestMaybeTriggerEventNoPlanets() {
 	r := &mockRand{seekVal: 0.01, ofVal: 0}
-	events := MaybeTriggerEvent([]*Planet{}, s.activeEvents, r, s.log)
+	events := MaybeTriggerEvent([]*Planet{}, s.activeEvents, DefaultEventConfig(), r, s.log)
 	s.Equal(s.activeEvents, events)
 }
 
 func (s *EventsSuite) TestMaybeTriggerEventTriggers() {
 	r := &mockRand{seekVal: 0.01, ofVal: 1}
-	events := MaybeTriggerEvent(s.planets, s.activeEvents, r, s.log)
+	events := MaybeTriggerEvent(s.planets, s.activeEvents, DefaultEventConfig(), r, s.log)
 	s.Len(events, 1)
 	event := events[0]
 	s.Contains([]string{
@@ -58,7 +58,7 @@ func (s *EventsSuite) TestMaybeTriggerEventBuildingTarget() {
 		Modifiers: map[ResourceType]float64{},
 	}
 	r := &mockRand{seekVal: 0.01, ofVal: 0}
-	events := MaybeTriggerEvent([]*Planet{p}, []*Event{}, r, s.log)
+	events := MaybeTriggerEvent([]*Planet{p}, []*Event{}, DefaultEventConfig(), r, s.log)
 	s.Len(events, 1)
 	event := events[0]
 	s.Equal(BuildingTarget, event.Target)
@@ -133,7 +133,7 @@ func (s *EventsSuite) TestMaybeTriggerEventNoBuildings() {
 		Modifiers: map[ResourceType]float64{},
 	}
 	r := &mockRand{seekVal: 0.01, ofVal: 0}
-	events := MaybeTriggerEvent([]*Planet{p}, []*Event{}, r, s.log)
+	events := MaybeTriggerEvent([]*Planet{p}, []*Event{}, DefaultEventConfig(), r, s.log)
 	s.Len(events, 1)
 	event := events[0]
 	s.Equal(PlanetTarget, event.Target)
@@ -148,7 +148,7 @@ func (s *EventsSuite) TestMaybeTriggerEventNilModifiers() {
 		Modifiers: nil,
 	}
 	r := &mockRand{seekVal: 0.01, ofVal: 0}
-	events := MaybeTriggerEvent([]*Planet{p}, []*Event{}, r, s.log)
+	events := MaybeTriggerEvent([]*Planet{p}, []*Event{}, DefaultEventConfig(), r, s.log)
 	s.Len(events, 1)
 	event := events[0]
 	s.NotNil(event.TargetPlanet.Modifiers)
@@ -0,0 +1,152 @@
+package core
+
+// Relation scores between factions and their effects.
+const (
+	MinRelation                 = -100
+	MaxRelation                 = 100
+	AllianceThreshold           = 50  // factions with at least this relation are allied
+	RivalryThreshold            = -50 // factions with at most this relation are rivals and embargo each other
+	TradeRelationBonus          = 1   // relation gained by a trade on a planet of another faction
+	ColonizationRelationPenalty = 5   // relation lost to all other factions by each colonization
+	AllianceTariffDiscount      = 50  // percent of the tariff waived for allies
+)
+
+// Faction is a group of NPCs. Members trade on each other's planets without tariffs.
+type Faction struct {
+	Name      string
+	Relations map[*Faction]int // relation score to each other faction, between MinRelation and MaxRelation
+}
+
+// Relation returns the relation score to passed faction. A faction has the best relation to itself.
+func (f *Faction) Relation(other *Faction) int {
+	if f == other {
+		return MaxRelation
+	}
+	return f.Relations[other]
+}
+
+// Allied returns true if both factions are the same or their relation reached AllianceThreshold.
+func (f *Faction) Allied(other *Faction) bool {
+	return f != nil && other != nil && f.Relation(other) >= AllianceThreshold
+}
+
+// Rival returns true if the relation of both factions dropped to RivalryThreshold.
+func (f *Faction) Rival(other *Faction) bool {
+	return f != nil && other != nil && f.Relation(other) <= RivalryThreshold
+}
+
+// Members returns all NPCs of the faction.
+func (f *Faction) Members(npcs []*NPC) []*NPC {
+	members := []*NPC{}
+	for _, npc := range npcs {
+		if npc.Faction == f {
+			members = append(members, npc)
+		}
+	}
+	return members
+}
+
+// Territory returns all planets owned by members of the faction.
+func (f *Faction) Territory(planets []*Planet) []*Planet {
+	territory := []*Planet{}
+	for _, p := range planets {
+		if p.Owner != nil && p.Owner.Faction == f {
+			territory = append(territory, p)
+		}
+	}
+	return territory
+}
+
+// SetupFactions returns all factions of passed NPCs and all factions with passed names, each with a
+// relation to all others. NPCs without faction are assigned to the configured factions in turn.
+func SetupFactions(npcs []*NPC, names []string) []*Faction {
+
+	factions := []*Faction{}
+	byName := make(map[string]*Faction)
+	add := func(f *Faction) {
+		if _, ok := byName[f.Name]; !ok {
+			byName[f.Name] = f
+			factions = append(factions, f)
+		}
+	}
+	for _, npc := range npcs {
+		if npc.Faction != nil {
+			add(npc.Faction)
+		}
+	}
+	configured := []*Faction{}
+	for _, name := range names {
+		if _, ok := byName[name]; !ok {
+			add(&Faction{Name: name})
+		}
+		configured = append(configured, byName[name])
+	}
+
+	for _, f := range factions {
+		if f.Relations == nil {
+			f.Relations = make(map[*Faction]int)
+		}
+		for _, other := range factions {
+			if _, ok := f.Relations[other]; !ok && other != f {
+				f.Relations[other] = 0
+			}
+		}
+	}
+
+	if len(configured) > 0 {
+		next := 0
+		for _, npc := range npcs {
+			if npc.Faction == nil {
+				npc.Faction = configured[next%len(configured)]
+				next++
+			}
+		}
+	}
+	return factions
+}
+
+// JoinSmallestFaction adds an NPC to the faction with fewest members, the first one on ties.
+func JoinSmallestFaction(npc *NPC, factions []*Faction, npcs []*NPC) {
+	var smallest *Faction
+	fewest := 0
+	for _, f := range factions {
+		if members := len(f.Members(npcs)); smallest == nil || members < fewest {
+			smallest, fewest = f, members
+		}
+	}
+	npc.Faction = smallest
+}
+
+// Embargoed returns true if an NPC refuses to trade on a planet, because it's owned by a rival faction.
+func Embargoed(npc *NPC, p *Planet) bool {
+	return p.Owner != nil && npc.Faction.Rival(p.Owner.Faction)
+}
+
+// ChangeRelation changes the relation between two different factions in both directions,
+// within MinRelation and MaxRelation.
+func ChangeRelation(a, b *Faction, delta int, log Log) {
+	if a == nil || b == nil || a == b {
+		return
+	}
+	relation := min(MaxRelation, max(MinRelation, a.Relations[b]+delta))
+	a.Relations[b], b.Relations[a] = relation, relation
+	log.Debug("Relation between factions %s and %s changed by %d to %d.", a.Name, b.Name, delta, relation)
+}
+
+// updateRelations changes faction relations after an NPC executed an action. Trades on planets of
+// other factions improve relations, colonizations worsen relations to all other factions.
+func updateRelations(npc *NPC, action NPCAction, log Log) {
+	if npc.Faction == nil {
+		return
+	}
+	switch action.Type {
+	case BuyAction, SellAction, ExchangeAction:
+		if action.Planet.Owner != nil {
+			ChangeRelation(npc.Faction, action.Planet.Owner.Faction, TradeRelationBonus, log)
+		}
+	case ColonizeAction:
+		for other := range npc.Faction.Relations {
+			ChangeRelation(npc.Faction, other, -ColonizationRelationPenalty, log)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FactionSuite struct {
+	suite.Suite
+	red    *Faction
+	blue   *Faction
+	trader *NPC
+	owner  *NPC
+	planet *Planet
+	now    time.Time
+	log    *mockLog
+}
+
+func TestFactionSuite(t *testing.T) {
+	suite.Run(t, new(FactionSuite))
+}
+
+func (s *FactionSuite) SetupTest() {
+	s.log = &mockLog{}
+	s.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.trader = &NPC{
+		Name:     "Trader",
+		Offer:    map[ResourceType]int{Iron: 10, Food: 10, Fuel: 10},
+		Credits:  1000,
+		Cargo:    map[ResourceType]int{Iron: 0, Food: 0, Fuel: 0},
+		MaxCargo: 100,
+	}
+	s.owner = &NPC{Name: "Owner", Tariff: 20}
+	factions := SetupFactions([]*NPC{s.trader, s.owner}, []string{"Red", "Blue"})
+	s.red, s.blue = factions[0], factions[1]
+	s.planet = &Planet{
+		Name:      "Colony",
+		Owner:     s.owner,
+		Resources: map[ResourceType]int{Iron: 1000, Food: 1000, Fuel: 1000},
+		Modifiers: map[ResourceType]float64{},
+	}
+}
+
+func (s *FactionSuite) TestSetupFactions() {
+	s.Equal(s.red, s.trader.Faction)
+	s.Equal(s.blue, s.owner.Faction)
+	s.Equal(map[*Faction]int{s.blue: 0}, s.red.Relations)
+	s.Equal(map[*Faction]int{s.red: 0}, s.blue.Relations)
+
+	// factions of scenario NPCs are kept and configured ones with the same name are reused
+	guild := &Faction{Name: "Guild"}
+	npcs := []*NPC{{Name: "A", Faction: guild}, {Name: "B"}, {Name: "C"}}
+	factions := SetupFactions(npcs, []string{"Red", "Guild"})
+	s.Equal([]*Faction{guild, factions[1]}, factions)
+	s.Equal("Red", factions[1].Name)
+	s.Equal(factions[1], npcs[1].Faction)
+	s.Equal(guild, npcs[2].Faction)
+	s.Contains(guild.Relations, factions[1])
+
+	s.Empty(SetupFactions([]*NPC{{Name: "D"}}, nil))
+}
+
+func (s *FactionSuite) TestJoinSmallestFaction() {
+	npcs := []*NPC{s.trader, s.owner, {Name: "Third", Faction: s.red}}
+	arrival := &NPC{Name: "Arrival"}
+	JoinSmallestFaction(arrival, []*Faction{s.red, s.blue}, npcs)
+	s.Equal(s.blue, arrival.Faction)
+}
+
+func (s *FactionSuite) TestChangeRelation() {
+	ChangeRelation(s.red, s.blue, 30, s.log)
+	s.Equal(30, s.red.Relation(s.blue))
+	s.Equal(30, s.blue.Relation(s.red))
+	s.False(s.red.Allied(s.blue))
+
+	ChangeRelation(s.blue, s.red, 200, s.log)
+	s.Equal(MaxRelation, s.red.Relation(s.blue))
+	s.True(s.red.Allied(s.blue))
+
+	ChangeRelation(s.red, s.blue, -300, s.log)
+	s.Equal(MinRelation, s.blue.Relation(s.red))
+	s.True(s.blue.Rival(s.red))
+
+	ChangeRelation(s.red, s.red, -10, s.log)
+	s.Equal(MaxRelation, s.red.Relation(s.red))
+	s.True(s.red.Allied(s.red))
+}
+
+func (s *FactionSuite) TestTariffsBetweenFactions() {
+	s.Equal(20, Tariff(s.trader, s.planet))
+
+	ChangeRelation(s.red, s.blue, AllianceThreshold, s.log)
+	s.Equal(10, Tariff(s.trader, s.planet))
+
+	s.trader.Faction = s.blue
+	s.Equal(0, Tariff(s.trader, s.planet))
+
+	s.trader.Faction = nil
+	s.Equal(20, Tariff(s.trader, s.planet))
+}
+
+func (s *FactionSuite) TestTradesImproveRelations() {
+	s.True(ExecuteNPCAction(s.trader, NPCAction{Type: BuyAction, Planet: s.planet, Resource: Iron, Amount: 10}, DefaultSeedConfig(), DefaultConflictConfig(), s.now, &mockRand{}, s.log))
+	s.Equal(TradeRelationBonus, s.red.Relation(s.blue))
+
+	s.planet.Owner = nil
+	s.True(ExecuteNPCAction(s.trader, NPCAction{Type: SellAction, Planet: s.planet, Resource: Iron, Amount: 10}, DefaultSeedConfig(), DefaultConflictConfig(), s.now, &mockRand{}, s.log))
+	s.Equal(TradeRelationBonus, s.red.Relation(s.blue))
+}
+
+func (s *FactionSuite) TestColonizationWorsensRelations() {
+	free := &Planet{Name: "Free", Resources: map[ResourceType]int{}, Modifiers: map[ResourceType]float64{}}
+	s.True(ExecuteNPCAction(s.trader, NPCAction{Type: ColonizeAction, Planet: free, Building: Mine}, DefaultSeedConfig(), DefaultConflictConfig(), s.now, &mockRand{}, s.log))
+	s.Equal(-ColonizationRelationPenalty, s.blue.Relation(s.red))
+	s.Equal([]*Planet{free}, s.red.Territory([]*Planet{s.planet, free}))
+}
+
+func (s *FactionSuite) TestRivalsEmbargoEachOther() {
+	ChangeRelation(s.red, s.blue, RivalryThreshold, s.log)
+	s.True(Embargoed(s.trader, s.planet))
+	s.False(ExecuteNPCAction(s.trader, NPCAction{Type: BuyAction, Planet: s.planet, Resource: Iron, Amount: 10}, DefaultSeedConfig(), DefaultConflictConfig(), s.now, &mockRand{}, s.log))
+	s.Equal(0, s.trader.Cargo[Iron])
+
+	obs := Observation{NPC: s.trader, Planets: []*Planet{s.planet}, Now: s.now, SeedConfig: DefaultSeedConfig()}
+	s.Empty(TraderStrategy{}.Decide(obs, &mockRand{}))
+}
@@ -0,0 +1,303 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// LogEvent is a typed outcome of the simulation, e.g. an executed trade. Core functions emit events
+// through their Log, the game delivers them to all subscribers of its feed, one of them is the text logger.
+type LogEvent interface {
+	Kind() string                  // type of the event, e.g. TradeExecuted
+	String() string                // human readable description
+	Attributes() map[string]string // details of the event, for clients
+}
+
+// Kinds of log events.
+const (
+	TradeExecutedKind   = "TradeExecuted"
+	BuildingBuiltKind   = "BuildingBuilt"
+	BuildFailedKind     = "BuildFailed"
+	PlanetColonizedKind = "PlanetColonized"
+	EventTriggeredKind  = "EventTriggered"
+	EventExpiredKind    = "EventExpired"
+)
+
+// LogEventKinds lists the kinds of all log events.
+var LogEventKinds = []string{TradeExecutedKind, BuildingBuiltKind, BuildFailedKind, PlanetColonizedKind, EventTriggeredKind, EventExpiredKind}
+
+// TradeExecuted is a purchase, sale or exchange of resources between an NPC, or a player, and a planet.
+type TradeExecuted struct {
+	Trader   string
+	Player   bool // trader is a player
+	Type     LedgerEntryType
+	Planet   string
+	Resource ResourceType
+	Amount   int
+	Price    int // credits per unit paid by purchases or received by sales, total for exchanges
+	Tariff   int // credits per unit paid into the planet's treasury, total for exchanges
+}
+
+func (e TradeExecuted) Kind() string { return TradeExecutedKind }
+
+func (e TradeExecuted) String() string {
+	switch e.Type {
+	case PurchaseEntry:
+		return fmt.Sprintf("%s bought %d units of %v from planet %s for %d credits each, %d of them tariff.", traderName(e.Trader, e.Player), e.Amount, e.Resource, e.Planet, e.Price, e.Tariff)
+	case SaleEntry:
+		return fmt.Sprintf("%s sold %d units of %v to planet %s for %d credits each, after %d tariff.", traderName(e.Trader, e.Player), e.Amount, e.Resource, e.Planet, e.Price, e.Tariff)
+	default:
+		return fmt.Sprintf("%s exchanged %d units of %v with planet %s for %d credits, %d of them tariff.", traderName(e.Trader, e.Player), e.Amount, e.Resource, e.Planet, e.Price, e.Tariff)
+	}
+}
+
+func (e TradeExecuted) Attributes() map[string]string {
+	return map[string]string{
+		"trader":   e.Trader,
+		"player":   strconv.FormatBool(e.Player),
+		"type":     e.Type.String(),
+		"planet":   e.Planet,
+		"resource": e.Resource.String(),
+		"amount":   strconv.Itoa(e.Amount),
+		"price":    strconv.Itoa(e.Price),
+		"tariff":   strconv.Itoa(e.Tariff),
+	}
+}
+
+// BuildingBuilt is a building placed on a planet, or upgraded to a higher level.
+type BuildingBuilt struct {
+	Planet   string
+	Owner    string // name of the planet's owner, empty if it isn't owned
+	Building BuildingType
+	Level    int
+}
+
+func (e BuildingBuilt) Kind() string { return BuildingBuiltKind }
+
+func (e BuildingBuilt) String() string {
+	if e.Level > 1 {
+		return fmt.Sprintf("Building %v upgraded to level %d on planet %s.", e.Building, e.Level, e.Planet)
+	}
+	return fmt.Sprintf("Built %v on planet %s.", e.Building, e.Planet)
+}
+
+func (e BuildingBuilt) Attributes() map[string]string {
+	return map[string]string{
+		"planet":   e.Planet,
+		"owner":    e.Owner,
+		"building": e.Building.String(),
+		"level":    strconv.Itoa(e.Level),
+	}
+}
+
+// BuildFailureReason explains why a building couldn't be built or upgraded.
+type BuildFailureReason int
+
+const (
+	BuildingNotAllowed    BuildFailureReason = iota // planet type doesn't allow the building
+	InsufficientResources                           // planet lacks resources to pay the build costs
+)
+
+func (r BuildFailureReason) String() string {
+	switch r {
+	case BuildingNotAllowed:
+		return "NotAllowed"
+	case InsufficientResources:
+		return "InsufficientResources"
+	default:
+		return "Unknown"
+	}
+}
+
+// BuildFailed is a building which couldn't be built, or upgraded, on a planet. It's a regular outcome,
+// e.g. of NPCs investing in buildings they can't afford yet.
+type BuildFailed struct {
+	Planet   string
+	Building BuildingType
+	Level    int // level the building should have been upgraded to, 1 for new buildings
+	Reason   BuildFailureReason
+	Resource ResourceType // lacking resource, if resources are insufficient
+}
+
+func (e BuildFailed) Kind() string { return BuildFailedKind }
+
+func (e BuildFailed) String() string {
+	if e.Reason == InsufficientResources {
+		return fmt.Sprintf("Insufficient %v to build %v level %d on planet %s.", e.Resource, e.Building, e.Level, e.Planet)
+	}
+	return fmt.Sprintf("Cannot build %v on planet %s.", e.Building, e.Planet)
+}
+
+func (e BuildFailed) Attributes() map[string]string {
+	attributes := map[string]string{
+		"planet":   e.Planet,
+		"building": e.Building.String(),
+		"level":    strconv.Itoa(e.Level),
+		"reason":   e.Reason.String(),
+	}
+	if e.Reason == InsufficientResources {
+		attributes["resource"] = e.Resource.String()
+	}
+	return attributes
+}
+
+// PlanetColonized is a planet taken over by an NPC, or a player.
+type PlanetColonized struct {
+	Planet   string
+	Colonist string
+	Player   bool // colonist is a player
+	Building BuildingType
+}
+
+func (e PlanetColonized) Kind() string { return PlanetColonizedKind }
+
+func (e PlanetColonized) String() string {
+	return fmt.Sprintf("%s colonized planet %s and established a %v.", traderName(e.Colonist, e.Player), e.Planet, e.Building)
+}
+
+func (e PlanetColonized) Attributes() map[string]string {
+	return map[string]string{
+		"planet":   e.Planet,
+		"colonist": e.Colonist,
+		"player":   strconv.FormatBool(e.Player),
+		"building": e.Building.String(),
+	}
+}
+
+// EventTriggered is a random or scheduled event which started on a planet.
+type EventTriggered struct {
+	Name     string
+	Planet   string
+	Building string // type of the targeted building, empty if the event targets the planet
+	Duration int
+}
+
+func (e EventTriggered) Kind() string { return EventTriggeredKind }
+
+func (e EventTriggered) String() string {
+	return fmt.Sprintf("Event '%s' triggered on planet %s.", e.Name, e.Planet)
+}
+
+func (e EventTriggered) Attributes() map[string]string {
+	return map[string]string{
+		"name":     e.Name,
+		"planet":   e.Planet,
+		"building": e.Building,
+		"duration": strconv.Itoa(e.Duration),
+	}
+}
+
+// EventExpired is an event which ended, its boosts have been reverted.
+type EventExpired struct {
+	Name   string
+	Planet string
+}
+
+func (e EventExpired) Kind() string { return EventExpiredKind }
+
+func (e EventExpired) String() string {
+	return fmt.Sprintf("Event '%s' expired on planet %s.", e.Name, e.Planet)
+}
+
+func (e EventExpired) Attributes() map[string]string {
+	return map[string]string{
+		"name":   e.Name,
+		"planet": e.Planet,
+	}
+}
+
+// traderName describes an NPC or a player by its name.
+func traderName(name string, player bool) string {
+	if player {
+		return "Player " + name
+	}
+	return "NPC " + name
+}
+
+// EventEmitter is a Log which delivers log events to subscribers instead of writing them itself.
+type EventEmitter interface {
+	Emit(event LogEvent)
+}
+
+// Emit passes a log event to passed log. Logs which don't deliver events to subscribers write them as text.
+func Emit(log Log, event LogEvent) {
+	if emitter, ok := log.(EventEmitter); ok {
+		emitter.Emit(event)
+		return
+	}
+	WriteEvent(log, event)
+}
+
+// WriteEvent writes the description of a log event to passed log. Failed builds are regular outcomes
+// and written as debug messages.
+func WriteEvent(log Log, event LogEvent) {
+	if event.Kind() == BuildFailedKind {
+		log.Debug("%s", event)
+		return
+	}
+	log.Info("%s", event)
+}
+
+// EventFeed delivers log events to its subscribers. It's safe for concurrent use.
+type EventFeed struct {
+	mu       sync.Mutex
+	next     int
+	handlers map[int]func(LogEvent)
+}
+
+// NewEventFeed returns a feed without subscribers.
+func NewEventFeed() *EventFeed {
+	return &EventFeed{handlers: make(map[int]func(LogEvent))}
+}
+
+// Handle calls passed handler with every published event, until the returned function is called.
+// Handlers are called synchronously and must not block.
+func (f *EventFeed) Handle(handler func(LogEvent)) func() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.next
+	f.next++
+	f.handlers[id] = handler
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.handlers, id)
+	}
+}
+
+// Subscribe returns a channel receiving published events, until the returned function is called.
+// Events are dropped if the channel's buffer is full, so slow subscribers don't hold up the game.
+func (f *EventFeed) Subscribe(buffer int) (<-chan LogEvent, func()) {
+	events := make(chan LogEvent, buffer)
+	return events, f.Handle(func(event LogEvent) {
+		select {
+		case events <- event:
+		default:
+		}
+	})
+}
+
+// Publish delivers an event to all subscribers.
+func (f *EventFeed) Publish(event LogEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, handler := range f.handlers {
+		handler(event)
+	}
+}
+
+// Events returns the feed of log events of the game. The game's log is subscribed to it.
+func (g *Game) Events() *EventFeed {
+	return g.feed
+}
+
+// feedLog is the log of a game, it publishes log events to the game's feed and writes all other messages.
+type feedLog struct {
+	Log
+	feed *EventFeed
+}
+
+func (l *feedLog) Emit(event LogEvent) {
+	l.feed.Publish(event)
+}
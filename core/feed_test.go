@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FeedSuite struct {
+	suite.Suite
+	log *mockLog
+}
+
+func TestFeedSuite(t *testing.T) {
+	suite.Run(t, new(FeedSuite))
+}
+
+func (s *FeedSuite) SetupTest() {
+	s.log = &mockLog{}
+}
+
+func (s *FeedSuite) TestEmitWithoutFeedWritesText() {
+	Emit(s.log, TradeExecuted{Trader: "Trader", Type: PurchaseEntry, Planet: "Mars", Resource: Iron, Amount: 5, Price: 12, Tariff: 2})
+	Emit(s.log, BuildFailed{Planet: "Mars", Building: Farm, Level: 1, Reason: BuildingNotAllowed})
+
+	s.Equal([]string{"NPC Trader bought 5 units of Iron from planet Mars for 12 credits each, 2 of them tariff."}, s.log.infos)
+	s.Equal([]string{"Cannot build Farm on planet Mars."}, s.log.debugs)
+	s.Empty(s.log.errors)
+}
+
+func (s *FeedSuite) TestSubscribe() {
+	feed := NewEventFeed()
+	events, unsubscribe := feed.Subscribe(1)
+
+	feed.Publish(EventExpired{Name: "Solar Flare", Planet: "Mars"})
+	feed.Publish(EventExpired{Name: "Meteor Shower", Planet: "Mars"}) // dropped, buffer is full
+	s.Equal(EventExpired{Name: "Solar Flare", Planet: "Mars"}, <-events)
+	s.Empty(events)
+
+	unsubscribe()
+	feed.Publish(EventExpired{Name: "Solar Flare", Planet: "Venus"})
+	s.Empty(events)
+}
+
+func (s *FeedSuite) TestGameEventsAreWrittenToLog() {
+	planet := &Planet{Name: "Mars", Type: Desert, Resources: map[ResourceType]int{Iron: 10}, Modifiers: map[ResourceType]float64{}}
+	game := NewGameService(DefaultConfig(), &mockRand{}, s.log, []*Planet{planet}, []*NPC{})
+	events, unsubscribe := game.Events().Subscribe(10)
+	defer unsubscribe()
+
+	s.False(planet.Build(&Building{Type: Mine, BuildCost: map[ResourceType]int{Iron: 20}}, game.log))
+	s.True(planet.Build(&Building{Type: Mine, Level: 1, BuildCost: map[ResourceType]int{Iron: 5}}, game.log))
+
+	s.Equal(BuildFailed{Planet: "Mars", Building: Mine, Level: 1, Reason: InsufficientResources, Resource: Iron}, <-events)
+	s.Equal(BuildingBuilt{Planet: "Mars", Building: Mine, Level: 1}, <-events)
+	s.Equal([]string{"Built Mine on planet Mars."}, s.log.infos)
+	s.Contains(s.log.debugs, "Insufficient Iron to build Mine level 1 on planet Mars.")
+	s.Empty(s.log.errors)
+}
+
+func (s *FeedSuite) TestAttributes() {
+	attributes := BuildFailed{Planet: "Mars", Building: Farm, Level: 2, Reason: InsufficientResources, Resource: Food}.Attributes()
+	s.Equal(map[string]string{"planet": "Mars", "building": "Farm", "level": "2", "reason": "InsufficientResources", "resource": "Food"}, attributes)
+
+	s.NotContains(BuildFailed{Reason: BuildingNotAllowed}.Attributes(), "resource")
+	s.Equal("true", PlanetColonized{Player: true}.Attributes()["player"])
+}
@@ -0,0 +1,83 @@
+package core
+
+import "slices"
+
+// StateFilter selects the part of the universe a stream client receives. An empty filter selects everything.
+type StateFilter struct {
+	Planets  []string // names of planets to receive
+	Owners   []string // names of NPCs or players whose planets to receive
+	Events   []string // names of events to receive, e.g. Solar Flare
+	OnlyNPCs bool     // receive NPCs only, without planets, events and battles
+}
+
+// selectsPlanets returns true if the filter is limited to some planets.
+func (f StateFilter) selectsPlanets() bool {
+	return len(f.Planets) > 0 || len(f.Owners) > 0
+}
+
+// IncludesPlanet returns true if passed planet, or events and battles on it, are sent to the client.
+// Planets are selected by name or owner, all planets if neither is set.
+func (f StateFilter) IncludesPlanet(p *Planet) bool {
+	return f.includesPlanet(refOf(p))
+}
+
+func (f StateFilter) includesPlanet(p planetRef) bool {
+	if f.OnlyNPCs {
+		return false
+	}
+	if !f.selectsPlanets() {
+		return true
+	}
+	return slices.Contains(f.Planets, p.name) || (p.owner != "" && slices.Contains(f.Owners, p.owner))
+}
+
+// IncludesEvent returns true if passed event is sent to the client. Events are selected by name
+// and by the planet they target.
+func (f StateFilter) IncludesEvent(e *Event) bool {
+	var target *planetRef
+	if e.TargetPlanet != nil {
+		ref := refOf(e.TargetPlanet)
+		target = &ref
+	}
+	return f.includesEvent(e.Name, target)
+}
+
+func (f StateFilter) includesEvent(name string, target *planetRef) bool {
+	if f.OnlyNPCs {
+		return false
+	}
+	if len(f.Events) > 0 && !slices.Contains(f.Events, name) {
+		return false
+	}
+	return target == nil || f.includesPlanet(*target)
+}
+
+// FilterPlanets returns all planets included by the filter.
+func (f StateFilter) FilterPlanets(planets []*Planet) []*Planet {
+	return slices.DeleteFunc(slices.Clone(planets), func(p *Planet) bool { return !f.IncludesPlanet(p) })
+}
+
+// FilterEvents returns all events included by the filter.
+func (f StateFilter) FilterEvents(events []*Event) []*Event {
+	return slices.DeleteFunc(slices.Clone(events), func(e *Event) bool { return !f.IncludesEvent(e) })
+}
+
+// FilterBattles returns all battle reports of planets included by the filter.
+func (f StateFilter) FilterBattles(reports []BattleReport) []BattleReport {
+	return slices.DeleteFunc(slices.Clone(reports), func(r BattleReport) bool { return !f.IncludesPlanet(r.Planet) })
+}
+
+// planetRef identifies a planet and its owner at the time of an update.
+type planetRef struct {
+	name     string
+	owner    string // name of the owning NPC or player, empty if not colonized
+	playerID string // ID of the owning player, if any
+}
+
+func refOf(p *Planet) planetRef {
+	ref := planetRef{name: p.Name, owner: p.OwnerName()}
+	if p.Player != nil {
+		ref.playerID = p.Player.ID
+	}
+	return ref
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StateFilterSuite struct {
+	suite.Suite
+	planets []*Planet
+	events  []*Event
+}
+
+func TestStateFilterSuite(t *testing.T) {
+	suite.Run(t, new(StateFilterSuite))
+}
+
+func (s *StateFilterSuite) SetupTest() {
+	trader := &NPC{Name: "Trader"}
+	s.planets = []*Planet{
+		{Name: "Vega-B", Owner: trader},
+		{Name: "Aurora-A"},
+		{Name: "Luna-D"},
+	}
+	s.events = []*Event{
+		{Name: "Solar Flare", TargetPlanet: s.planets[0]},
+		{Name: "Meteor Shower", TargetPlanet: s.planets[1]},
+		{Name: "Trade Boom"},
+	}
+}
+
+func eventNames(events []*Event) []string {
+	names := []string{}
+	for _, e := range events {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+func (s *StateFilterSuite) TestEmptyFilterSelectsAll() {
+	filter := StateFilter{}
+	s.Len(filter.FilterPlanets(s.planets), 3)
+	s.Len(filter.FilterEvents(s.events), 3)
+}
+
+func (s *StateFilterSuite) TestSelectPlanets() {
+	filter := StateFilter{Planets: []string{"Luna-D"}, Owners: []string{"Trader"}}
+	s.Equal([]string{"Vega-B", "Luna-D"}, planetNames(filter.FilterPlanets(s.planets)))
+	s.Equal([]string{"Solar Flare", "Trade Boom"}, eventNames(filter.FilterEvents(s.events)))
+	s.Len(s.planets, 3)
+
+	reports := []BattleReport{{Planet: s.planets[0]}, {Planet: s.planets[1]}}
+	s.Equal([]BattleReport{reports[0]}, filter.FilterBattles(reports))
+}
+
+func (s *StateFilterSuite) TestSelectEvents() {
+	filter := StateFilter{Events: []string{"Meteor Shower", "Trade Boom"}}
+	s.Equal([]string{"Meteor Shower", "Trade Boom"}, eventNames(filter.FilterEvents(s.events)))
+	s.Len(filter.FilterPlanets(s.planets), 3)
+
+	filter.Planets = []string{"Vega-B"}
+	s.Equal([]string{"Trade Boom"}, eventNames(filter.FilterEvents(s.events)))
+}
+
+func (s *StateFilterSuite) TestOnlyNPCs() {
+	filter := StateFilter{OnlyNPCs: true}
+	s.Empty(filter.FilterPlanets(s.planets))
+	s.Empty(filter.FilterEvents(s.events))
+	s.Empty(filter.FilterBattles([]BattleReport{{Planet: s.planets[0]}}))
+}
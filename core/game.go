@@ -11,84 +11,269 @@ type Game struct {
 
 	config Config
 	random Random
+	seed   int64 // seed of universe and random generator, if known
+	clock  Clock
 	log    Log
 
 	Planets      []*Planet
 	NPCs         []*NPC
+	Factions     []*Faction
+	Players      []*Player
+	Ledger       *TradeLedger
 	ActiveEvents []*Event
 
-	planetUpdates chan []*Planet
-	npcUpdates    chan []*NPC
-	eventUpdates  chan []*Event
+	scheduledEvents []*ScheduledEvent
+	triggeredEvents []*Event         // events triggered during the last tick
+	lifecycleEvents []LifecycleEvent // NPCs arrived or left during the last tick
+	battleReports   []BattleReport   // battles fought during the last tick
+
+	paused         bool
+	speed          float64
+	metrics        TickMetrics
+	activity       activityCounters
+	exporter       *Metrics // exposes metrics to Prometheus, nil if disabled
+	health         loopHealth
+	feed           *EventFeed                    // log events of the game, written to its log by default
+	journal        *Journal                      // records all state changes, nil if disabled
+	history        *History                      // samples of planets and NPCs, nil if disabled
+	updates        *UpdateBuffer                 // numbers and keeps updates for resuming streams, nil if not streamed
+	subscribers    map[chan stateUpdate]struct{} // update queues of subscribed streams
+	controlChanges chan struct{}
+}
+
+// TickMetrics collects timing information about executed game ticks.
+type TickMetrics struct {
+	Ticks             uint64
+	LastTickDuration  time.Duration
+	MaxTickDuration   time.Duration
+	TotalTickDuration time.Duration
+	Overruns          uint64 // ticks which took longer than the tick interval
+	SkippedTicks      uint64 // tick slots dropped by the catch-up policy
+	TicksBehind       int    // tick slots the loop was behind schedule after the last tick
 }
 
 func NewGameService(config Config, random Random, log Log, planets []*Planet, npcs []*NPC) *Game {
+	feed := NewEventFeed()
+	feed.Handle(func(event LogEvent) { WriteEvent(log, event) })
 	return &Game{
-		config:        config,
-		random:        random,
-		Planets:       planets,
-		NPCs:          npcs,
-		log:           log,
-		ActiveEvents:  []*Event{},
-		planetUpdates: make(chan []*Planet, 10),
-		npcUpdates:    make(chan []*NPC, 10),
-		eventUpdates:  make(chan []*Event, 10),
+		config:         config,
+		random:         random,
+		clock:          NewWallClock(),
+		Planets:        planets,
+		NPCs:           npcs,
+		Factions:       SetupFactions(npcs, config.SeedConfig.MPCConfig.Factions),
+		Players:        []*Player{},
+		Ledger:         NewTradeLedger(config.LedgerSize),
+		log:            &feedLog{Log: log, feed: feed},
+		feed:           feed,
+		ActiveEvents:   []*Event{},
+		speed:          1.0,
+		activity:       newActivityCounters(),
+		controlChanges: make(chan struct{}, 1),
+		updates:        NewUpdateBuffer(config.ResumeBuffer),
+		subscribers:    make(map[chan stateUpdate]struct{}),
 	}
 }
 
 func (g *Game) GameLoop(ctx context.Context) {
 
-	ticker := g.newTimer()
-	defer ticker.Stop()
+	interval := g.tickInterval()
+	scheduled := time.Now().Add(interval)
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	g.health.beat(time.Now(), interval)
+	g.health.lastTick.Store(time.Now().UnixNano())
+	g.health.running.Store(true)
+	defer g.health.running.Store(false)
 
 	for {
 		select {
 		case <-ctx.Done():
 			g.log.Info("Game loop canceled via context.")
 			return
-		case <-ticker.C:
-			g.mu.Lock()
-			g.log.Debug("Game tick started.")
-			ProduceResources(g.Planets, g.log)
-			g.ActiveEvents = MaybeTriggerEvent(g.Planets, g.ActiveEvents, g.random, g.log)
-			g.ActiveEvents = UpdateEvents(g.ActiveEvents, g.log)
-			for _, npc := range g.NPCs {
-				RunNPCLogic(npc, g.Planets, g.random, g.log)
+		case <-g.controlChanges:
+			interval = g.tickInterval()
+			scheduled = time.Now().Add(interval)
+			resetTimer(timer, interval)
+			g.health.beat(time.Now(), interval)
+			g.log.Debug("Game loop rescheduled, tick interval is %v.", interval)
+		case <-timer.C:
+			if g.IsPaused() {
+				scheduled = time.Now().Add(interval)
+				timer.Reset(interval)
+				g.health.beat(time.Now(), interval)
+				continue
 			}
-
-			g.sendUpdates()
-			g.log.Debug("Game tick completed.")
+			g.tick(interval)
+			g.health.beat(time.Now(), interval)
+			g.mu.Lock()
+			scheduled = g.nextTickAt(scheduled, time.Now(), interval)
 			g.mu.Unlock()
+			timer.Reset(time.Until(scheduled))
 			g.log.Flush()
 		}
 	}
 }
 
-func (g *Game) sendUpdates() {
-	g.log.Debug("Sending updates...")
-	g.log.Debug("Planet updates: %d planets", len(g.Planets))
-	g.log.Debug("NPC updates: %d NPCs", len(g.NPCs))
-	g.log.Debug("Event updates: %d events", len(g.ActiveEvents))
-	select {
-	case g.planetUpdates <- g.Planets:
-		g.log.Debug("Planet updates sent.")
-	default:
-		g.log.Debug("Planet updates channel full, skipping send.")
-	}
-	select {
-	case g.npcUpdates <- g.NPCs:
-		g.log.Debug("NPC updates sent.")
-	default:
-		g.log.Debug("NPC updates channel full, skipping send.")
-	}
-	select {
-	case g.eventUpdates <- g.ActiveEvents:
-		g.log.Debug("Event updates sent.")
-	default:
-		g.log.Debug("Event updates channel full, skipping send.")
+// tick runs a single simulation step and records its duration against the passed time budget.
+func (g *Game) tick(budget time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	start := time.Now()
+	now := g.clock.Now()
+	ctx, span := startTickSpan(g.metrics.Ticks + 1)
+	defer span.End()
+	g.log.Debug("Game tick started.")
+
+	endPhase := startPhase(ctx, "production")
+	CollectTaxes(ProduceResources(g.Planets, g.log), g.config.Taxes, g.log)
+	PayOutTreasuries(g.Planets, g.config.Taxes, g.log)
+	endPhase()
+
+	endPhase = startPhase(ctx, "events")
+	numberOfEvents := len(g.ActiveEvents)
+	g.ActiveEvents = MaybeTriggerEvent(g.Planets, g.ActiveEvents, g.config.Events, g.random, g.log)
+	g.ActiveEvents = g.applyScheduledEvents(g.metrics.Ticks+1, g.ActiveEvents)
+	g.triggeredEvents = append([]*Event{}, g.ActiveEvents[numberOfEvents:]...)
+	g.ActiveEvents = UpdateEvents(g.ActiveEvents, g.log)
+	endPhase()
+
+	endPhase = startPhase(ctx, "npc_logic")
+	unowned := unownedPlanets(g.Planets)
+	for _, npc := range g.NPCs {
+		RunNPCLogic(npc, g.Planets, g.config, g.Ledger, now, g.random, g.log)
+	}
+	for _, p := range unowned {
+		if IsPlanetColonized(p) {
+			g.activity.colonizations["npc"]++
+		}
+	}
+	g.battleReports = ResolveBattles(g.Planets, g.config.Conflict, g.log)
+	g.NPCs, g.lifecycleEvents = UpdateLifecycle(g.NPCs, g.Planets, g.config.Lifecycle, g.config.SeedConfig, now, g.random, g.log)
+	for _, event := range g.lifecycleEvents {
+		if event.Type == NPCArrival {
+			JoinSmallestFaction(event.NPC, g.Factions, g.NPCs)
+		}
+	}
+	endPhase()
+
+	endPhase = startPhase(ctx, "broadcast")
+	g.sendUpdates(g.metrics.Ticks + 1)
+	endPhase()
+	g.recordTick(time.Since(start), budget)
+	g.journalTick(now)
+	g.history.record(g.metrics.Ticks, now, g.Planets, g.NPCs)
+	g.log.Debug("Game tick completed.")
+}
+
+// unownedPlanets returns planets which haven't been colonized yet.
+func unownedPlanets(planets []*Planet) []*Planet {
+	unowned := []*Planet{}
+	for _, p := range planets {
+		if !IsPlanetColonized(p) {
+			unowned = append(unowned, p)
+		}
+	}
+	return unowned
+}
+
+// ScheduleEvents adds events which are applied at their tick, e.g. defined by a scenario.
+func (g *Game) ScheduleEvents(events []*ScheduledEvent) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.scheduledEvents = append(g.scheduledEvents, events...)
+	if len(events) > 0 {
+		entry := JournalEntry{Type: ScheduleEntry}
+		for _, se := range events {
+			entry.Events = append(entry.Events, ScheduledEventSnapshot{Tick: se.Tick, Event: eventSnapshot(se.Event)})
+		}
+		g.journalAction(entry)
+	}
+}
+
+func (g *Game) applyScheduledEvents(tick uint64, activeEvents []*Event) []*Event {
+	pending := []*ScheduledEvent{}
+	for _, se := range g.scheduledEvents {
+		if se.Tick > tick {
+			pending = append(pending, se)
+			continue
+		}
+		ApplyEvent(se.Event, g.log)
+		activeEvents = append(activeEvents, se.Event)
+		g.log.Debug("Scheduled event '%s' applied at tick %d.", se.Event.Name, tick)
+		Emit(g.log, eventTriggered(se.Event))
+	}
+	g.scheduledEvents = pending
+	return activeEvents
+}
+
+func (g *Game) recordTick(duration, budget time.Duration) {
+	g.exporter.observeTick(duration)
+	g.metrics.Ticks++
+	g.metrics.LastTickDuration = duration
+	g.health.lastTick.Store(time.Now().UnixNano())
+	g.metrics.TotalTickDuration += duration
+	if duration > g.metrics.MaxTickDuration {
+		g.metrics.MaxTickDuration = duration
+	}
+	if budget > 0 && duration > budget {
+		g.metrics.Overruns++
+		g.log.Error("Game tick %d took %v, exceeding its budget of %v.", g.metrics.Ticks, duration, budget)
+	}
+}
+
+// nextTickAt calculates the next tick slot after the scheduled one, applying the configured
+// catch-up policy if the loop has fallen behind.
+func (g *Game) nextTickAt(scheduled, now time.Time, interval time.Duration) time.Time {
+	next := scheduled.Add(interval)
+	if !now.After(next) {
+		g.metrics.TicksBehind = 0
+		return next
+	}
+
+	behind := int(now.Sub(next)/interval) + 1
+	g.metrics.TicksBehind = behind
+	skip := behind
+	if g.config.CatchUpPolicy == BurstMissedTicks {
+		skip = max(0, behind-g.config.MaxCatchUpTicks)
+	}
+	if skip > 0 {
+		g.metrics.SkippedTicks += uint64(skip)
+		g.log.Info("Game loop is %d ticks behind schedule, skipping %d ticks.", behind, skip)
+	}
+	return next.Add(time.Duration(skip) * interval)
+}
+
+// updateKinds lists the kinds of updates sent to streams.
+var updateKinds = []string{"state", "config"}
+
+// sendUpdates captures the state after passed tick and sends it to all subscribed streams.
+func (g *Game) sendUpdates(tick uint64) {
+	if g.updates == nil && len(g.subscribers) == 0 {
+		return
+	}
+	g.log.Debug("Sending updates of tick %d: %d planets, %d NPCs, %d events", tick, len(g.Planets), len(g.NPCs), len(g.ActiveEvents))
+	g.publish(captureState(tick, g.Planets, g.NPCs, g.ActiveEvents, g.lifecycleEvents, g.battleReports))
+}
+
+// tickInterval returns the wall clock time between two ticks, based on tick duration and speed.
+func (g *Game) tickInterval() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.speed <= 0 {
+		return g.config.TickDuration
 	}
+	return time.Duration(float64(g.config.TickDuration) / g.speed)
 }
 
-func (g *Game) newTimer() *time.Ticker {
-	return time.NewTicker(g.config.TickDuration)
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
 }
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/tommzn/utte-universe/core/proto"
+)
+
+// AuthorizationHeader is the metadata key of the bearer token of a request.
+const AuthorizationHeader = "authorization"
+
+// methodRoles lists the role required to call each method of the universe service.
+// Methods which aren't listed require the admin role.
+var methodRoles = map[string]Role{
+	pb.UniverseService_GetPlanets_FullMethodName:          RoleViewer,
+	pb.UniverseService_GetNPCs_FullMethodName:             RoleViewer,
+	pb.UniverseService_GetFactions_FullMethodName:         RoleViewer,
+	pb.UniverseService_GetTradeLedger_FullMethodName:      RoleViewer,
+	pb.UniverseService_GetGameStatus_FullMethodName:       RoleViewer,
+	pb.UniverseService_StreamUniverseState_FullMethodName: RoleViewer,
+	pb.UniverseService_StreamLogEvents_FullMethodName:     RoleViewer,
+	pb.UniverseService_GetHistory_FullMethodName:          RoleViewer,
+	pb.UniverseService_GetPlanet_FullMethodName:           RoleViewer,
+	pb.UniverseService_GetNPC_FullMethodName:              RoleViewer,
+	pb.UniverseService_ListPlanets_FullMethodName:         RoleViewer,
+	pb.UniverseService_ListNPCs_FullMethodName:            RoleViewer,
+	pb.UniverseService_GetActiveEvents_FullMethodName:     RoleViewer,
+	pb.UniverseService_GetGameInfo_FullMethodName:         RoleViewer,
+	pb.UniverseService_JoinUniverse_FullMethodName:        RolePlayer,
+	pb.UniverseService_GetPlayer_FullMethodName:           RolePlayer,
+	pb.UniverseService_BuyResources_FullMethodName:        RolePlayer,
+	pb.UniverseService_SellResources_FullMethodName:       RolePlayer,
+	pb.UniverseService_CollectResources_FullMethodName:    RolePlayer,
+	pb.UniverseService_ColonizePlanet_FullMethodName:      RolePlayer,
+	pb.UniverseService_BuildOnPlanet_FullMethodName:       RolePlayer,
+	pb.UniverseService_ControlGame_FullMethodName:         RoleAdmin,
+}
+
+// publicServices lists services which can be called without a token, e.g. by probes.
+var publicServices = []string{"/" + healthpb.Health_ServiceDesc.ServiceName + "/"}
+
+// isPublic returns true if passed method can be called without a token.
+func isPublic(method string) bool {
+	for _, service := range publicServices {
+		if strings.HasPrefix(method, service) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredRole returns the role required to call passed method.
+func requiredRole(method string) Role {
+	if role, ok := methodRoles[method]; ok {
+		return role
+	}
+	return RoleAdmin
+}
+
+// UnaryAuthInterceptor verifies the bearer token of each request and checks the caller's role
+// against the method called. The caller's identity is added to the request context.
+func UnaryAuthInterceptor(verifier *TokenVerifier, log Log) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, info.FullMethod, verifier, log)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor verifies the bearer token of a stream, like UnaryAuthInterceptor.
+func StreamAuthInterceptor(verifier *TokenVerifier, log Log) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod, verifier, log)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize returns a context with the verified identity of a request, or an error status
+// if the request has no valid token or the caller's role isn't sufficient. Public methods are
+// passed without identity.
+func authorize(ctx context.Context, method string, verifier *TokenVerifier, log Log) (context.Context, error) {
+	if isPublic(method) {
+		return ctx, nil
+	}
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(AuthorizationHeader); len(values) > 0 {
+			token = BearerToken(values[0])
+		}
+	}
+	identity, err := verifier.Verify(token)
+	if err != nil {
+		log.Error("Rejected %s request: %v", method, err)
+		if errors.Is(err, ErrMissingToken) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	if required := requiredRole(method); !identity.Role.Allows(required) {
+		log.Error("Rejected %s request of %s: role %v, requires %v", method, identity.Subject, identity.Role, required)
+		return nil, status.Errorf(codes.PermissionDenied, "role %v isn't allowed to call %s", identity.Role, method)
+	}
+	return ContextWithIdentity(ctx, identity), nil
+}
+
+// authenticatedStream is a server stream whose context carries the caller's identity.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -2,14 +2,25 @@ package core
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
+	"slices"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	pb "github.com/tommzn/utte-universe/core/proto"
 )
 
+// PlayerIDHeader is the metadata key of the player ID, returned by JoinUniverse, which identifies
+// the player a request is made for if authentication is disabled.
+const PlayerIDHeader = "player-id"
+
 type UniverseServer struct {
 	pb.UnimplementedUniverseServiceServer
 	Game *Game
@@ -18,132 +29,724 @@ type UniverseServer struct {
 
 func (s *UniverseServer) GetPlanets(ctx context.Context, in *pb.Empty) (*pb.PlanetList, error) {
 	s.Log.Info("Received GetPlanets request")
-	planets := make([]*pb.Planet, 0, len(s.Game.Planets))
-	for _, p := range s.Game.Planets {
-		planets = append(planets, planetToProto(p))
-	}
+	planets := s.Game.planetsToProto(s.playerID(ctx))
 	s.Log.Debug("Returning %d planets", len(planets))
 	return &pb.PlanetList{Planets: planets}, nil
 }
 
 func (s *UniverseServer) GetNPCs(ctx context.Context, in *pb.Empty) (*pb.NPCList, error) {
 	s.Log.Info("Received GetNPCs request")
-	npcs := make([]*pb.NPC, 0, len(s.Game.NPCs))
-	for _, n := range s.Game.NPCs {
-		npcs = append(npcs, npcToProto(n))
-	}
+	npcs := s.Game.npcsToProto()
 	s.Log.Debug("Returning %d NPCs", len(npcs))
 	return &pb.NPCList{Npcs: npcs}, nil
 }
 
+func (s *UniverseServer) GetFactions(ctx context.Context, in *pb.Empty) (*pb.FactionList, error) {
+	s.Log.Info("Received GetFactions request")
+	factions := s.Game.factionsToProto()
+	s.Log.Debug("Returning %d factions", len(factions))
+	return &pb.FactionList{Factions: factions}, nil
+}
+
+func (s *UniverseServer) GetTradeLedger(ctx context.Context, in *pb.Empty) (*pb.TradeLedger, error) {
+	s.Log.Info("Received GetTradeLedger request")
+	ledger := tradeLedgerToProto(s.Game.Ledger)
+	s.Log.Debug("Returning %d ledger entries", len(ledger.Entries))
+	return ledger, nil
+}
+
+func (s *UniverseServer) ControlGame(ctx context.Context, in *pb.GameControl) (*pb.GameStatus, error) {
+	s.Log.Info("Received ControlGame request: %v", in.Action)
+	if _, authenticated := IdentityFromContext(ctx); !authenticated && playerIDFromContext(ctx) != "" {
+		s.Log.Error("Rejected ControlGame request of a player")
+		return nil, status.Error(codes.PermissionDenied, "players can't control the game")
+	}
+	switch in.Action {
+	case pb.GameControl_ACTION_UNSPECIFIED:
+		s.Log.Error("Rejected ControlGame request without action")
+		return nil, status.Error(codes.InvalidArgument, "game control action is required")
+	case pb.GameControl_PAUSE:
+		s.Game.Pause()
+	case pb.GameControl_RESUME:
+		s.Game.Resume()
+	case pb.GameControl_STEP:
+		if err := s.Game.Step(int(in.Steps)); err != nil {
+			s.Log.Error("Unable to step game: %v", err)
+			if err == ErrGameNotPaused {
+				return nil, status.Error(codes.FailedPrecondition, err.Error())
+			}
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	case pb.GameControl_SET_SPEED:
+		if err := s.Game.SetSpeed(in.Speed); err != nil {
+			s.Log.Error("Unable to set game speed: %v", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown game control action: %v", in.Action)
+	}
+	return gameStatusToProto(s.Game.Status()), nil
+}
+
+func (s *UniverseServer) GetGameStatus(ctx context.Context, in *pb.Empty) (*pb.GameStatus, error) {
+	s.Log.Info("Received GetGameStatus request")
+	return gameStatusToProto(s.Game.Status()), nil
+}
+
+func (s *UniverseServer) JoinUniverse(ctx context.Context, in *pb.JoinRequest) (*pb.Player, error) {
+	s.Log.Info("Received JoinUniverse request: %s", in.Name)
+	var account string
+	if identity, ok := IdentityFromContext(ctx); ok {
+		account = identity.Subject
+	}
+	player, err := s.Game.JoinUniverse(in.Name, account)
+	if err != nil {
+		s.Log.Error("Unable to join universe: %v", err)
+		return nil, playerErrorToStatus(err)
+	}
+	return playerToProto(player), nil
+}
+
+func (s *UniverseServer) GetPlayer(ctx context.Context, in *pb.Empty) (*pb.Player, error) {
+	s.Log.Info("Received GetPlayer request")
+	player, err := s.Game.FindPlayer(s.playerID(ctx))
+	if err != nil {
+		return nil, playerErrorToStatus(err)
+	}
+	return playerToProto(player), nil
+}
+
+func (s *UniverseServer) BuyResources(ctx context.Context, in *pb.TradeRequest) (*pb.Player, error) {
+	s.Log.Info("Received BuyResources request: %d %s from %s", in.Amount, in.Resource, in.Planet)
+	return s.playerResponse(s.Game.PlayerBuy(s.playerID(ctx), in.Planet, ResourceTypeFromString(in.Resource), int(in.Amount)))
+}
+
+func (s *UniverseServer) SellResources(ctx context.Context, in *pb.TradeRequest) (*pb.Player, error) {
+	s.Log.Info("Received SellResources request: %d %s to %s", in.Amount, in.Resource, in.Planet)
+	return s.playerResponse(s.Game.PlayerSell(s.playerID(ctx), in.Planet, ResourceTypeFromString(in.Resource), int(in.Amount)))
+}
+
+func (s *UniverseServer) CollectResources(ctx context.Context, in *pb.TradeRequest) (*pb.Player, error) {
+	s.Log.Info("Received CollectResources request: %d %s from %s", in.Amount, in.Resource, in.Planet)
+	return s.playerResponse(s.Game.PlayerCollect(s.playerID(ctx), in.Planet, ResourceTypeFromString(in.Resource), int(in.Amount)))
+}
+
+func (s *UniverseServer) ColonizePlanet(ctx context.Context, in *pb.BuildRequest) (*pb.Player, error) {
+	s.Log.Info("Received ColonizePlanet request: %s with %s", in.Planet, in.Building)
+	return s.playerResponse(s.Game.PlayerColonize(s.playerID(ctx), in.Planet, BuildingTypeFromString(in.Building)))
+}
+
+func (s *UniverseServer) BuildOnPlanet(ctx context.Context, in *pb.BuildRequest) (*pb.Player, error) {
+	s.Log.Info("Received BuildOnPlanet request: %s on %s", in.Building, in.Planet)
+	return s.playerResponse(s.Game.PlayerBuild(s.playerID(ctx), in.Planet, BuildingTypeFromString(in.Building)))
+}
+
+// logEventBuffer is the number of log events buffered for a client, further events are dropped
+// until the client catches up.
+const logEventBuffer = 256
+
+// StreamLogEvents streams log events of the game of the kinds passed in the filter, or all events
+// if it contains no kinds, until the client cancels.
+func (s *UniverseServer) StreamLogEvents(in *pb.LogEventFilter, stream pb.UniverseService_StreamLogEventsServer) error {
+	s.Log.Info("Started StreamLogEvents: %v", in.Kinds)
+	type timedEvent struct {
+		event LogEvent
+		time  time.Time
+	}
+	events := make(chan timedEvent, logEventBuffer)
+	unsubscribe := s.Game.Events().Handle(func(event LogEvent) {
+		if len(in.Kinds) > 0 && !slices.Contains(in.Kinds, event.Kind()) {
+			return
+		}
+		select {
+		case events <- timedEvent{event: event, time: time.Now()}:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			s.Log.Info("StreamLogEvents context cancelled")
+			return stream.Context().Err()
+		case e := <-events:
+			if err := stream.Send(logEventToProto(e.event, e.time)); err != nil {
+				s.Log.Error("Failed to send log event: %v", err)
+				return err
+			}
+		}
+	}
+}
+
+// GetHistory returns the sampled values of a metric of a planet or NPC in the requested time range.
+func (s *UniverseServer) GetHistory(ctx context.Context, in *pb.HistoryRequest) (*pb.History, error) {
+	s.Log.Info("Received GetHistory request: %s %s %s", in.Entity, in.Name, in.Metric)
+	query, err := historyQueryFromProto(in)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	points, err := s.Game.History(query)
+	if err != nil {
+		s.Log.Error("Unable to get history: %v", err)
+		return nil, historyErrorToStatus(err)
+	}
+	s.Log.Debug("Returning %d history points", len(points))
+	return historyToProto(query, points), nil
+}
+
+func (s *UniverseServer) GetPlanet(ctx context.Context, in *pb.EntityRequest) (*pb.Planet, error) {
+	s.Log.Info("Received GetPlanet request: %s", in.Name)
+	p, err := s.Game.FindPlanet(in.Name)
+	if err != nil {
+		return nil, queryErrorToStatus(err)
+	}
+	return visiblePlanetToProto(p, s.playerID(ctx)), nil
+}
+
+func (s *UniverseServer) GetNPC(ctx context.Context, in *pb.EntityRequest) (*pb.NPC, error) {
+	s.Log.Info("Received GetNPC request: %s", in.Name)
+	n, err := s.Game.FindNPC(in.Name)
+	if err != nil {
+		return nil, queryErrorToStatus(err)
+	}
+	return npcToProto(n), nil
+}
+
+// ListPlanets returns a page of all planets matching the query.
+func (s *UniverseServer) ListPlanets(ctx context.Context, in *pb.PlanetQuery) (*pb.PlanetPage, error) {
+	s.Log.Info("Received ListPlanets request")
+	query, err := planetQueryFromProto(in)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := s.Game.QueryPlanets(query)
+	if err != nil {
+		s.Log.Error("Unable to list planets: %v", err)
+		return nil, queryErrorToStatus(err)
+	}
+	playerID := s.playerID(ctx)
+	planets := make([]*pb.Planet, 0, len(page.Planets))
+	for _, p := range page.Planets {
+		planets = append(planets, visiblePlanetToProto(p, playerID))
+	}
+	s.Log.Debug("Returning %d of %d planets", len(planets), page.Total)
+	return &pb.PlanetPage{Planets: planets, NextPageToken: page.NextPageToken, Total: int32(page.Total)}, nil
+}
+
+// ListNPCs returns a page of all NPCs matching the query.
+func (s *UniverseServer) ListNPCs(ctx context.Context, in *pb.NPCQuery) (*pb.NPCPage, error) {
+	s.Log.Info("Received ListNPCs request")
+	query, err := npcQueryFromProto(in)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := s.Game.QueryNPCs(query)
+	if err != nil {
+		s.Log.Error("Unable to list NPCs: %v", err)
+		return nil, queryErrorToStatus(err)
+	}
+	npcs := make([]*pb.NPC, 0, len(page.NPCs))
+	for _, n := range page.NPCs {
+		npcs = append(npcs, npcToProto(n))
+	}
+	s.Log.Debug("Returning %d of %d NPCs", len(npcs), page.Total)
+	return &pb.NPCPage{Npcs: npcs, NextPageToken: page.NextPageToken, Total: int32(page.Total)}, nil
+}
+
+func (s *UniverseServer) GetActiveEvents(ctx context.Context, in *pb.Empty) (*pb.EventList, error) {
+	s.Log.Info("Received GetActiveEvents request")
+	active := s.Game.FindActiveEvents()
+	events := make([]*pb.Event, 0, len(active))
+	for _, e := range active {
+		events = append(events, eventToProto(e))
+	}
+	s.Log.Debug("Returning %d events", len(events))
+	return &pb.EventList{Events: events}, nil
+}
+
+func (s *UniverseServer) GetGameInfo(ctx context.Context, in *pb.Empty) (*pb.GameInfo, error) {
+	s.Log.Info("Received GetGameInfo request")
+	info := s.Game.Info()
+	return &pb.GameInfo{
+		Ticks:        info.Ticks,
+		TickDuration: info.TickDuration.String(),
+		Seed:         info.Seed,
+		Version:      info.Version,
+	}, nil
+}
+
+func queryErrorToStatus(err error) error {
+	code := codes.InvalidArgument
+	if errors.Is(err, ErrUnknownPlanet) || errors.Is(err, ErrUnknownNPC) {
+		code = codes.NotFound
+	}
+	return status.Error(code, err.Error())
+}
+
+func historyErrorToStatus(err error) error {
+	code := codes.InvalidArgument
+	switch {
+	case errors.Is(err, ErrHistoryDisabled):
+		code = codes.Unavailable
+	case errors.Is(err, ErrUnknownEntity):
+		code = codes.NotFound
+	}
+	return status.Error(code, err.Error())
+}
+
+// playerResponse converts the result of a player action into a response.
+func (s *UniverseServer) playerResponse(player PlayerState, err error) (*pb.Player, error) {
+	if err != nil {
+		s.Log.Error("Player action failed: %v", err)
+		return nil, playerErrorToStatus(err)
+	}
+	return playerToProto(player), nil
+}
+
+// playerID returns the ID of the player a request is made for, empty if it isn't made for a player.
+// Authenticated requests are made for the player bound to the caller's account, others for the
+// player passed as metadata.
+func (s *UniverseServer) playerID(ctx context.Context) string {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return playerIDFromContext(ctx)
+	}
+	player, err := s.Game.FindPlayerByAccount(identity.Subject)
+	if err != nil {
+		return ""
+	}
+	return player.ID
+}
+
+// playerIDFromContext returns the player ID passed as metadata, empty if there's none.
+func playerIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if ids := md.Get(PlayerIDHeader); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// playerErrorToStatus maps errors of player actions to gRPC status codes.
+func playerErrorToStatus(err error) error {
+	code := codes.InvalidArgument
+	switch {
+	case errors.Is(err, ErrUnknownPlayer):
+		code = codes.Unauthenticated
+	case errors.Is(err, ErrNotOwner):
+		code = codes.PermissionDenied
+	case errors.Is(err, ErrPlayerNameTaken), errors.Is(err, ErrAlreadyJoined):
+		code = codes.AlreadyExists
+	case errors.Is(err, ErrUniverseFull):
+		code = codes.ResourceExhausted
+	case errors.Is(err, ErrUnknownPlanet):
+		code = codes.NotFound
+	case errors.Is(err, ErrPlanetColonized), errors.Is(err, ErrInsufficientCredits), errors.Is(err, ErrInsufficientStock):
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, err.Error())
+}
+
 func (s *UniverseServer) StreamUniverseState(stream pb.UniverseService_StreamUniverseStateServer) error {
 
 	s.Log.Info("Started StreamUniverseState")
-	subscribed := false
-	paused := false
+	defer s.Game.streamOpened()()
+	state := &streamState{}
+
+	// Streams made for a player include its own data, others are spectators.
+	playerID := s.playerID(stream.Context())
+	if playerID != "" {
+		if _, err := s.Game.FindPlayer(playerID); err != nil {
+			s.Log.Error("Rejected StreamUniverseState: %v", err)
+			return playerErrorToStatus(err)
+		}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	commands := receiveCommands(stream, done)
+	defer state.unsubscribe()
 
 	for {
-		// If subscribed and not paused, stream updates as they arrive.
-		if subscribed && !paused {
+		// If subscribed and not paused, stream updates as they're made. Queued updates are sent
+		// before further commands are handled.
+		if state.updates != nil {
+			select {
+			case update := <-state.updates:
+				if err := s.sendUpdate(stream, state, update, playerID); err != nil {
+					return err
+				}
+				continue
+			default:
+			}
 			select {
 			case <-stream.Context().Done():
 				s.Log.Info("StreamUniverseState context cancelled")
 				return stream.Context().Err()
-			case planets := <-s.Game.planetUpdates:
-				npcs := s.readNPCUpdates()
-				events := s.readEventUpdates()
-				s.Log.Debug("Sending universe state update: %d planets, %d NPCs, %d events", len(planets), len(npcs), len(events))
-
-				planetsProto := make([]*pb.Planet, 0, len(planets))
-				for _, p := range planets {
-					planetsProto = append(planetsProto, planetToProto(p))
-				}
-				npcsProto := make([]*pb.NPC, 0, len(npcs))
-				for _, n := range npcs {
-					npcsProto = append(npcsProto, npcToProto(n))
-				}
-				eventsProto := make([]*pb.Event, 0, len(events))
-				for _, e := range events {
-					eventsProto = append(eventsProto, eventToProto(e))
-				}
-				msg := &pb.UniverseState{
-					Planets: &pb.PlanetList{Planets: planetsProto},
-					Npcs:    &pb.NPCList{Npcs: npcsProto},
-					Events:  eventsProto,
-				}
-				if err := stream.Send(msg); err != nil {
-					s.Log.Error("Failed to send universe state: %v", err)
+			case update := <-state.updates:
+				if err := s.sendUpdate(stream, state, update, playerID); err != nil {
 					return err
 				}
-			case cmd := <-receiveCommand(stream):
-				if cmd == nil {
+			case received := <-commands:
+				if received.err != nil {
 					s.Log.Info("StreamUniverseState closed by client")
 					return nil
 				}
-				subscribed, paused = handleClientCommand(cmd, s.Log, subscribed, paused)
+				if err := s.handleCommand(stream, received.cmd, state, playerID); err != nil {
+					return err
+				}
 			}
 		} else {
 			// Not subscribed or paused, wait for client command.
-			cmd, err := stream.Recv()
-			if err != nil {
-				s.Log.Error("StreamUniverseState closed or errored: %v", err)
+			received := <-commands
+			if received.err != nil {
+				s.Log.Error("StreamUniverseState closed or errored: %v", received.err)
+				return received.err
+			}
+			if err := s.handleCommand(stream, received.cmd, state, playerID); err != nil {
 				return err
 			}
-			subscribed, paused = handleClientCommand(cmd, s.Log, subscribed, paused)
 		}
 	}
 }
 
-// Helper to receive commands in a goroutine and send them to a channel.
-func receiveCommand(stream pb.UniverseService_StreamUniverseStateServer) <-chan *pb.ClientCommand {
-	cmdCh := make(chan *pb.ClientCommand, 1)
+// handleCommand applies a client command to the stream. Subscribed streams receive updates as they're
+// made, streams resuming after a reconnect receive the updates they missed, or a keyframe if those
+// aren't buffered anymore.
+func (s *UniverseServer) handleCommand(stream pb.UniverseService_StreamUniverseStateServer, cmd *pb.ClientCommand, state *streamState, playerID string) error {
+	span := startCommandSpan(stream.Context(), cmd)
+	defer span.End()
+	handleClientCommand(cmd, s.Log, state)
+	switch {
+	case state.subscribed && !state.paused && state.updates == nil:
+		state.updates, state.cancel = s.Game.subscribeUpdates()
+	case (!state.subscribed || state.paused) && state.updates != nil:
+		state.unsubscribe()
+	}
+	if cmd.Type != pb.ClientCommand_SUBSCRIBE || cmd.ResumeFrom == 0 {
+		return nil
+	}
+	missed, ok := s.Game.updates.since(cmd.ResumeFrom)
+	if !ok {
+		s.Log.Info("Resuming stream from sequence %d with a keyframe", cmd.ResumeFrom)
+		missed = []stateUpdate{s.Game.keyframe()}
+	} else {
+		s.Log.Info("Resuming stream from sequence %d with %d missed updates", cmd.ResumeFrom, len(missed))
+	}
+	for _, update := range missed {
+		if err := s.sendUpdate(stream, state, update, playerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendUpdate sends an update, selected by the stream's filter and made for its player, if any.
+// Updates already sent, e.g. queued while missed updates were sent on resume, are skipped.
+func (s *UniverseServer) sendUpdate(stream pb.UniverseService_StreamUniverseStateServer, state *streamState, update stateUpdate, playerID string) error {
+	if update.sequence != 0 && update.sequence <= state.sent {
+		return nil
+	}
+	var player *Player
+	if playerID != "" {
+		if p, err := s.Game.FindPlayer(playerID); err == nil {
+			player = &p.Player
+		}
+	}
+	msg := update.render(state.filter, player)
+	if update.config == nil {
+		s.Log.Debug("Sending universe state update %d of tick %d: %d planets, %d NPCs, %d events, %d lifecycle events, %d battles", msg.Sequence, msg.Tick, len(msg.Planets.Planets), len(msg.Npcs.Npcs), len(msg.Events), len(msg.LifecycleEvents), len(msg.BattleReports))
+	}
+	if err := stream.Send(msg); err != nil {
+		s.Log.Error("Failed to send universe state: %v", err)
+		return err
+	}
+	state.sent = update.sequence
+	return nil
+}
+
+// streamState is the subscription state of a universe state stream.
+type streamState struct {
+	subscribed bool
+	paused     bool
+	filter     StateFilter
+	updates    <-chan stateUpdate // queue of updates while subscribed and not paused
+	cancel     func()
+	sent       uint64 // sequence of the last update sent
+}
+
+// unsubscribe stops queueing updates for the stream.
+func (s *streamState) unsubscribe() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.updates, s.cancel = nil, nil
+}
+
+// receivedCommand is a command received from a client, or the error which ended the stream.
+type receivedCommand struct {
+	cmd *pb.ClientCommand
+	err error
+}
+
+// receiveCommands receives commands in a goroutine until the stream ends or done is closed.
+func receiveCommands(stream pb.UniverseService_StreamUniverseStateServer, done <-chan struct{}) <-chan receivedCommand {
+	commands := make(chan receivedCommand)
 	go func() {
-		cmd, err := stream.Recv()
-		if err != nil {
-			cmdCh <- nil
-			return
+		for {
+			cmd, err := stream.Recv()
+			select {
+			case commands <- receivedCommand{cmd: cmd, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
 		}
-		cmdCh <- cmd
 	}()
-	return cmdCh
+	return commands
 }
 
-// Helper to handle client commands and update subscription state.
-func handleClientCommand(cmd *pb.ClientCommand, log Log, subscribed, paused bool) (bool, bool) {
+// Helper to handle client commands and update subscription state. Invalid filters are rejected,
+// the stream keeps its previous filter.
+func handleClientCommand(cmd *pb.ClientCommand, log Log, state *streamState) {
 	log.Debug("Received client command: %v", cmd.Type)
 	switch cmd.Type {
 	case pb.ClientCommand_SUBSCRIBE:
-		subscribed = true
-		paused = false
+		state.subscribed = true
+		state.paused = false
 		log.Info("Client subscribed to universe state stream")
 	case pb.ClientCommand_PAUSE:
-		paused = true
+		state.paused = true
 		log.Info("Client paused universe state stream")
 	case pb.ClientCommand_RESUME:
-		paused = false
+		state.paused = false
 		log.Info("Client resumed universe state stream")
 	case pb.ClientCommand_UNSUBSCRIBE:
-		subscribed = false
+		state.subscribed = false
 		log.Info("Client unsubscribed from universe state stream")
 	}
-	return subscribed, paused
+	if cmd.Type != pb.ClientCommand_SUBSCRIBE && cmd.Type != pb.ClientCommand_FILTER {
+		return
+	}
+	filter, ok, err := stateFilterFromCommand(cmd)
+	switch {
+	case err != nil:
+		log.Error("Rejected universe state filter: %v", err)
+	case ok:
+		state.filter = filter
+		log.Info("Client filters universe state stream: %+v", filter)
+	case cmd.Type == pb.ClientCommand_FILTER:
+		state.filter = StateFilter{}
+		log.Info("Client removed universe state stream filter")
+	}
 }
 
-func (s *UniverseServer) readNPCUpdates() []*NPC {
-	select {
-	case npcs := <-s.Game.npcUpdates:
-		return npcs
-	default:
-		return []*NPC{}
+// stateFilterFromCommand returns the filter passed with a command, either as message or as JSON payload.
+// It returns false if the command has no filter.
+func stateFilterFromCommand(cmd *pb.ClientCommand) (StateFilter, bool, error) {
+	filter := cmd.Filter
+	if filter == nil {
+		if cmd.Payload == "" {
+			return StateFilter{}, false, nil
+		}
+		filter = &pb.StateFilter{}
+		if err := protojson.Unmarshal([]byte(cmd.Payload), filter); err != nil {
+			return StateFilter{}, false, fmt.Errorf("invalid payload: %w", err)
+		}
 	}
+	return StateFilter{
+		Planets:  filter.Planets,
+		Owners:   filter.Owners,
+		Events:   filter.Events,
+		OnlyNPCs: filter.OnlyNpcs,
+	}, true, nil
 }
 
-func (s *UniverseServer) readEventUpdates() []*Event {
-	select {
-	case events := <-s.Game.eventUpdates:
-		return events
-	default:
-		return []*Event{}
+func lifecycleEventToProto(e LifecycleEvent) *pb.LifecycleEvent {
+	released := make([]string, 0, len(e.ReleasedPlanets))
+	for _, p := range e.ReleasedPlanets {
+		released = append(released, p.Name)
+	}
+	return &pb.LifecycleEvent{
+		Type:            e.Type.String(),
+		Npc:             npcToProto(e.NPC),
+		ReleasedPlanets: released,
+	}
+}
+
+func battleReportToProto(r BattleReport) *pb.BattleReport {
+	return &pb.BattleReport{
+		Planet:          r.Planet.Name,
+		Attacker:        r.Attacker.Name,
+		Defender:        r.Defender.Name,
+		Ships:           int32(r.Ships),
+		AttackStrength:  int32(r.AttackStrength),
+		DefenceStrength: int32(r.DefenceStrength),
+		AttackerLosses:  int32(r.AttackerLosses),
+		DefenderLosses:  int32(r.DefenderLosses),
+		Outcome:         r.Outcome.String(),
+	}
+}
+
+// tradeLedgerToProto converts all kept entries of a ledger and totals of all entries by type.
+func tradeLedgerToProto(ledger *TradeLedger) *pb.TradeLedger {
+	entries := ledger.Entries()
+	entriesProto := make([]*pb.LedgerEntry, 0, len(entries))
+	for _, e := range entries {
+		var resource string
+		if e.Amount > 0 {
+			resource = e.Resource.String()
+		}
+		entriesProto = append(entriesProto, &pb.LedgerEntry{
+			Type:     e.Type.String(),
+			Npc:      e.NPCName,
+			Planet:   e.PlanetName,
+			Resource: resource,
+			Amount:   int32(e.Amount),
+			Credits:  int32(e.Credits),
+			Time:     e.Time.Format(time.RFC3339),
+		})
+	}
+	counts := make(map[string]int32)
+	credits := make(map[string]int64)
+	for _, t := range ledgerEntryTypes {
+		counts[t.String()] = int32(ledger.Count(t))
+		credits[t.String()] = int64(ledger.Credits(t))
+	}
+	return &pb.TradeLedger{Entries: entriesProto, Counts: counts, Credits: credits}
+}
+
+// playerToProto converts a player with the names of all planets it owns.
+func playerToProto(player PlayerState) *pb.Player {
+	inventory := make(map[string]int32)
+	for k, v := range player.Inventory {
+		inventory[k.String()] = int32(v)
+	}
+	return &pb.Player{
+		Id:        player.ID,
+		Name:      player.Name,
+		Credits:   int32(player.Credits),
+		Inventory: inventory,
+		Planets:   player.Planets,
+		JoinedAt:  player.JoinedAt.Format(time.RFC3339),
+	}
+}
+
+// visiblePlanetToProto converts a planet as seen by the player with passed ID. Treasuries of planets
+// owned by other players are private.
+func visiblePlanetToProto(p *Planet, playerID string) *pb.Planet {
+	planet := planetToProto(p)
+	if p.Player != nil && p.Player.ID != playerID {
+		planet.Treasury = 0
+	}
+	return planet
+}
+
+func historyQueryFromProto(in *pb.HistoryRequest) (HistoryQuery, error) {
+	query := HistoryQuery{Entity: EntityType(in.Entity), Name: in.Name, Metric: in.Metric}
+	var err error
+	if in.From != "" {
+		if query.From, err = time.Parse(time.RFC3339, in.From); err != nil {
+			return query, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if in.To != "" {
+		if query.To, err = time.Parse(time.RFC3339, in.To); err != nil {
+			return query, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if in.Resolution != "" {
+		if query.Resolution, err = time.ParseDuration(in.Resolution); err != nil {
+			return query, fmt.Errorf("invalid resolution: %w", err)
+		}
+	}
+	return query, nil
+}
+
+func planetQueryFromProto(in *pb.PlanetQuery) (PlanetQuery, error) {
+	query := PlanetQuery{
+		Owner:   in.Owner,
+		Unowned: in.Unowned,
+		Page:    Page{Sort: in.Sort, Descending: in.Descending, Size: int(in.PageSize), Token: in.PageToken},
+	}
+	for _, name := range in.Types {
+		planetType := PlanetTypeFromString(name)
+		if planetType < 0 {
+			return query, fmt.Errorf("unknown planet type: %s", name)
+		}
+		query.Types = append(query.Types, planetType)
+	}
+	for _, name := range in.Buildings {
+		buildingType := BuildingTypeFromString(name)
+		if buildingType < 0 {
+			return query, fmt.Errorf("%w: %s", ErrUnknownBuilding, name)
+		}
+		query.Buildings = append(query.Buildings, buildingType)
+	}
+	var err error
+	if query.MinResources, err = resourceAmountsFromProto(in.MinResources); err != nil {
+		return query, err
+	}
+	query.MaxResources, err = resourceAmountsFromProto(in.MaxResources)
+	return query, err
+}
+
+func npcQueryFromProto(in *pb.NPCQuery) (NPCQuery, error) {
+	query := NPCQuery{
+		Faction:    in.Faction,
+		Strategy:   in.Strategy,
+		MinCredits: int(in.MinCredits),
+		Page:       Page{Sort: in.Sort, Descending: in.Descending, Size: int(in.PageSize), Token: in.PageToken},
+	}
+	var err error
+	query.MinCargo, err = resourceAmountsFromProto(in.MinCargo)
+	return query, err
+}
+
+func resourceAmountsFromProto(in map[string]int32) (map[ResourceType]int, error) {
+	amounts := make(map[ResourceType]int)
+	for name, amount := range in {
+		res := ResourceTypeFromString(name)
+		if res < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownResource, name)
+		}
+		amounts[res] = int(amount)
+	}
+	return amounts, nil
+}
+
+func historyToProto(query HistoryQuery, points []HistoryPoint) *pb.History {
+	history := &pb.History{Entity: string(query.Entity), Name: query.Name, Metric: query.Metric}
+	for _, point := range points {
+		history.Points = append(history.Points, &pb.HistoryPoint{
+			Tick:  point.Tick,
+			Time:  point.Time.Format(time.RFC3339),
+			Value: point.Value,
+			Text:  point.Text,
+		})
+	}
+	return history
+}
+
+func logEventToProto(event LogEvent, at time.Time) *pb.LogEvent {
+	return &pb.LogEvent{
+		Kind:       event.Kind(),
+		Message:    event.String(),
+		Attributes: event.Attributes(),
+		Time:       at.Format(time.RFC3339Nano),
+	}
+}
+
+func configReportToProto(report ConfigReport) *pb.ConfigChange {
+	return &pb.ConfigChange{
+		Applied:  report.Applied,
+		Rejected: report.Rejected,
 	}
 }
 
@@ -179,8 +782,14 @@ func planetToProto(p *Planet) *pb.Planet {
 		})
 	}
 	var owner *pb.NPC
+	var tariff int32
 	if p.Owner != nil {
 		owner = npcToProto(p.Owner)
+		tariff = int32(p.Owner.Tariff)
+	}
+	var player string
+	if p.Player != nil {
+		player = p.Player.Name
 	}
 	return &pb.Planet{
 		Name:      p.Name,
@@ -189,6 +798,10 @@ func planetToProto(p *Planet) *pb.Planet {
 		Modifiers: modifiers,
 		Buildings: buildings,
 		Owner:     owner,
+		Treasury:  int32(p.Treasury),
+		Tariff:    tariff,
+		Garrison:  int32(p.Garrison),
+		Player:    player,
 	}
 }
 
@@ -205,6 +818,18 @@ func npcToProto(n *NPC) *pb.NPC {
 	if !n.ColonizationCooldown.IsZero() {
 		cooldown = n.ColonizationCooldown.Format(time.RFC3339)
 	}
+	var strategy string
+	if n.Strategy != nil {
+		strategy = n.Strategy.Name()
+	}
+	var faction string
+	if n.Faction != nil {
+		faction = n.Faction.Name
+	}
+	var location string
+	if n.Location != nil {
+		location = n.Location.Name
+	}
 	return &pb.NPC{
 		Name:                 n.Name,
 		Offer:                offer,
@@ -212,6 +837,78 @@ func npcToProto(n *NPC) *pb.NPC {
 		Cargo:                cargo,
 		MaxCargo:             int32(n.MaxCargo),
 		ColonizationCooldown: cooldown,
+		Strategy:             strategy,
+		Tariff:               int32(n.Tariff),
+		Revenue:              int32(n.Revenue),
+		Faction:              faction,
+		Ships:                int32(n.Ships),
+		Location:             location,
+	}
+}
+
+// planetsToProto converts all planets, as seen by passed player, while the game is locked.
+func (g *Game) planetsToProto(playerID string) []*pb.Planet {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	planets := make([]*pb.Planet, 0, len(g.Planets))
+	for _, p := range g.Planets {
+		planets = append(planets, visiblePlanetToProto(p, playerID))
+	}
+	return planets
+}
+
+// npcsToProto converts all NPCs while the game is locked, NPCs arrive and leave during ticks.
+func (g *Game) npcsToProto() []*pb.NPC {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	npcs := make([]*pb.NPC, 0, len(g.NPCs))
+	for _, n := range g.NPCs {
+		npcs = append(npcs, npcToProto(n))
+	}
+	return npcs
+}
+
+// factionsToProto converts all factions while the game is locked, members and territories change during ticks.
+func (g *Game) factionsToProto() []*pb.Faction {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	factions := make([]*pb.Faction, 0, len(g.Factions))
+	for _, f := range g.Factions {
+		factions = append(factions, factionToProto(f, g.Factions, g.NPCs, g.Planets))
+	}
+	return factions
+}
+
+// factionToProto converts a faction with its members, territory and relations to all other factions.
+func factionToProto(f *Faction, factions []*Faction, npcs []*NPC, planets []*Planet) *pb.Faction {
+	members := []string{}
+	for _, n := range f.Members(npcs) {
+		members = append(members, n.Name)
+	}
+	territory := []string{}
+	for _, p := range f.Territory(planets) {
+		territory = append(territory, p.Name)
+	}
+	relations := make(map[string]int32)
+	allies, rivals := []string{}, []string{}
+	for _, other := range factions {
+		if other == f {
+			continue
+		}
+		relations[other.Name] = int32(f.Relation(other))
+		if f.Allied(other) {
+			allies = append(allies, other.Name)
+		} else if f.Rival(other) {
+			rivals = append(rivals, other.Name)
+		}
+	}
+	return &pb.Faction{
+		Name:      f.Name,
+		Members:   members,
+		Territory: territory,
+		Relations: relations,
+		Allies:    allies,
+		Rivals:    rivals,
 	}
 }
 
@@ -238,14 +935,42 @@ func eventToProto(e *Event) *pb.Event {
 	}
 }
 
+func gameStatusToProto(st GameStatus) *pb.GameStatus {
+	var avg time.Duration
+	if st.Metrics.Ticks > 0 {
+		avg = st.Metrics.TotalTickDuration / time.Duration(st.Metrics.Ticks)
+	}
+	return &pb.GameStatus{
+		Paused:                 st.Paused,
+		Speed:                  st.Speed,
+		TickInterval:           st.TickInterval.String(),
+		Ticks:                  st.Metrics.Ticks,
+		LastTickDurationMicros: st.Metrics.LastTickDuration.Microseconds(),
+		MaxTickDurationMicros:  st.Metrics.MaxTickDuration.Microseconds(),
+		AvgTickDurationMicros:  avg.Microseconds(),
+		Overruns:               st.Metrics.Overruns,
+		SkippedTicks:           st.Metrics.SkippedTicks,
+		TicksBehind:            int32(st.Metrics.TicksBehind),
+	}
+}
+
 // NewGRPCServer returns a *grpc.Server and net.Listener for graceful shutdown, or errors.
-func NewGRPCServer(game *Game, addr string, log Log) (*grpc.Server, net.Listener, error) {
+// With a token verifier, all requests have to be authenticated and authorized, without one
+// authentication is disabled. Further options, e.g. TLS credentials, are passed to the server.
+func NewGRPCServer(game *Game, addr string, verifier *TokenVerifier, log Log, opts ...grpc.ServerOption) (*grpc.Server, net.Listener, error) {
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		log.Error("Failed to listen on %s: %v", addr, err)
 		return nil, nil, err
 	}
-	grpcServer := grpc.NewServer()
+	if verifier != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(UnaryAuthInterceptor(verifier, log)),
+			grpc.ChainStreamInterceptor(StreamAuthInterceptor(verifier, log)))
+	} else {
+		log.Error("gRPC server started without authentication")
+	}
+	grpcServer := grpc.NewServer(opts...)
 	pb.RegisterUniverseServiceServer(grpcServer, &UniverseServer{Game: game, Log: log})
 	log.Info("gRPC server started on %s", addr)
 	return grpcServer, lis, nil
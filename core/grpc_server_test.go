@@ -3,12 +3,17 @@ package core
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	pb "github.com/tommzn/utte-universe/core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type UniverseServerTestSuite struct {
@@ -76,6 +81,76 @@ func (suite *UniverseServerTestSuite) TestGetNPCs() {
 	suite.Equal("NPC1", resp.Npcs[0].Name)
 }
 
+func (suite *UniverseServerTestSuite) TestGetFactions() {
+	npcs := []*NPC{{Name: "NPC1"}, {Name: "NPC2"}, {Name: "NPC3"}}
+	factions := SetupFactions(npcs, []string{"Red", "Blue", "Green"})
+	red, blue, green := factions[0], factions[1], factions[2]
+	ChangeRelation(red, blue, AllianceThreshold, suite.log)
+	ChangeRelation(red, green, RivalryThreshold, suite.log)
+	planets := []*Planet{{Name: "Mars", Owner: npcs[0]}, {Name: "Venus", Owner: npcs[1]}}
+
+	server := &UniverseServer{Game: &Game{NPCs: npcs, Planets: planets, Factions: factions}, Log: suite.log}
+	resp, err := server.GetFactions(context.Background(), &pb.Empty{})
+	suite.NoError(err)
+	suite.Len(resp.Factions, 3)
+	proto := resp.Factions[0]
+	suite.Equal("Red", proto.Name)
+	suite.Equal([]string{"NPC1"}, proto.Members)
+	suite.Equal([]string{"Mars"}, proto.Territory)
+	suite.Equal(map[string]int32{"Blue": 50, "Green": -50}, proto.Relations)
+	suite.Equal([]string{"Blue"}, proto.Allies)
+	suite.Equal([]string{"Green"}, proto.Rivals)
+	suite.Empty(resp.Factions[2].Allies)
+}
+
+func (suite *UniverseServerTestSuite) TestGetPlanetsAndNPCsWhileTicking() {
+	config := DefaultConfig()
+	config.Lifecycle = LifecycleConfig{ArrivalChance: 1, MaxNPCs: 1000}
+	simulation, err := NewSimulationFromConfig(config, 1, &nopLog{})
+	suite.Require().NoError(err)
+	server := &UniverseServer{Game: simulation.Game, Log: &nopLog{}}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 20 {
+			simulation.Game.tick(0)
+		}
+	}()
+	for ticking := true; ticking; {
+		select {
+		case <-done:
+			ticking = false
+		default:
+		}
+		planets, err := server.GetPlanets(context.Background(), &pb.Empty{})
+		suite.NoError(err)
+		suite.NotEmpty(planets.Planets)
+		_, err = server.GetNPCs(context.Background(), &pb.Empty{})
+		suite.NoError(err)
+	}
+}
+
+func (suite *UniverseServerTestSuite) TestGetTradeLedger() {
+	ledger := NewTradeLedger(10)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ledger.Record(LedgerEntry{TradeAction: TradeAction{NPCName: "NPC1", PlanetName: "Mars", Resource: Fuel, Amount: 5}, Type: PiracyLossEntry, Credits: 50, Time: now})
+	ledger.Record(LedgerEntry{TradeAction: TradeAction{NPCName: "NPC1", PlanetName: "Mars", Resource: ResourceType(-1)}, Type: EscortFeeEntry, Credits: 3, Time: now})
+
+	server := &UniverseServer{Game: &Game{Ledger: ledger}, Log: suite.log}
+	resp, err := server.GetTradeLedger(context.Background(), &pb.Empty{})
+	suite.NoError(err)
+	suite.Len(resp.Entries, 2)
+	suite.Equal("PiracyLoss", resp.Entries[0].Type)
+	suite.Equal("Fuel", resp.Entries[0].Resource)
+	suite.Equal(int32(50), resp.Entries[0].Credits)
+	suite.Equal("2025-01-01T00:00:00Z", resp.Entries[0].Time)
+	suite.Equal("", resp.Entries[1].Resource)
+	suite.Equal(int32(1), resp.Counts["EscortFee"])
+	suite.Equal(int32(0), resp.Counts["Sale"])
+	suite.Equal(int64(3), resp.Credits["EscortFee"])
+}
+
 func (suite *UniverseServerTestSuite) TestPlanetToProto() {
 	planet := &Planet{
 		Name: "Mars",
@@ -95,7 +170,8 @@ func (suite *UniverseServerTestSuite) TestPlanetToProto() {
 				BuildCost:  map[ResourceType]int{ResourceType(2): 100},
 			},
 		},
-		Owner: &NPC{Name: "NPC2"},
+		Owner:    &NPC{Name: "NPC2", Tariff: 15},
+		Treasury: 120,
 	}
 	proto := planetToProto(planet)
 	suite.Equal("Mars", proto.Name)
@@ -103,6 +179,8 @@ func (suite *UniverseServerTestSuite) TestPlanetToProto() {
 	suite.Len(proto.Buildings, 1)
 	suite.NotNil(proto.Owner)
 	suite.Equal("NPC2", proto.Owner.Name)
+	suite.Equal(int32(120), proto.Treasury)
+	suite.Equal(int32(15), proto.Tariff)
 }
 
 func (suite *UniverseServerTestSuite) TestNPCToProto() {
@@ -113,11 +191,54 @@ func (suite *UniverseServerTestSuite) TestNPCToProto() {
 		Cargo:                map[ResourceType]int{ResourceType(3): 30},
 		MaxCargo:             60,
 		ColonizationCooldown: time.Now(),
+		Strategy:             TraderStrategy{},
+		Tariff:               10,
+		Revenue:              250,
+		Faction:              &Faction{Name: "Guild"},
+		Location:             &Planet{Name: "Mars"},
 	}
 	proto := npcToProto(npc)
 	suite.Equal("NPC3", proto.Name)
 	suite.Equal(int32(300), proto.Credits)
 	suite.Equal(int32(60), proto.MaxCargo)
+	suite.Equal("trader", proto.Strategy)
+	suite.Equal(int32(10), proto.Tariff)
+	suite.Equal(int32(250), proto.Revenue)
+	suite.Equal("Guild", proto.Faction)
+	suite.Equal("Mars", proto.Location)
+}
+
+func (suite *UniverseServerTestSuite) TestLifecycleEventToProto() {
+	event := LifecycleEvent{
+		Type:            NPCBankruptcy,
+		NPC:             &NPC{Name: "NPC4"},
+		ReleasedPlanets: []*Planet{{Name: "Venus"}, {Name: "Mars"}},
+	}
+	proto := lifecycleEventToProto(event)
+	suite.Equal("Bankruptcy", proto.Type)
+	suite.Equal("NPC4", proto.Npc.Name)
+	suite.Equal([]string{"Venus", "Mars"}, proto.ReleasedPlanets)
+}
+
+func (suite *UniverseServerTestSuite) TestBattleReportToProto() {
+	report := BattleReport{
+		Planet:          &Planet{Name: "Mars"},
+		Attacker:        &NPC{Name: "Attacker"},
+		Defender:        &NPC{Name: "Defender"},
+		Ships:           16,
+		AttackStrength:  160,
+		DefenceStrength: 130,
+		AttackerLosses:  13,
+		DefenderLosses:  3,
+		Outcome:         BattleCaptured,
+	}
+	proto := battleReportToProto(report)
+	suite.Equal("Mars", proto.Planet)
+	suite.Equal("Attacker", proto.Attacker)
+	suite.Equal("Defender", proto.Defender)
+	suite.Equal(int32(160), proto.AttackStrength)
+	suite.Equal(int32(13), proto.AttackerLosses)
+	suite.Equal("Captured", proto.Outcome)
 }
 
 func (suite *UniverseServerTestSuite) TestEventToProto() {
@@ -165,14 +286,174 @@ func (suite *UniverseServerTestSuite) TestEventToProtoNilTargets() {
 	suite.Empty(proto.TargetBuilding)
 }
 
+func (suite *UniverseServerTestSuite) TestConfigReportToProto() {
+	report := ConfigReport{
+		Applied:  []string{"tick_duration: 2s -> 1s"},
+		Rejected: []string{"scenario_file:  -> tutorial.yml requires a restart"},
+	}
+	proto := configReportToProto(report)
+	suite.Equal(report.Applied, proto.Applied)
+	suite.Equal(report.Rejected, proto.Rejected)
+}
+
+func (suite *UniverseServerTestSuite) TestControlGame() {
+	game := NewGameService(Config{TickDuration: time.Second}, &mockRand{seekVal: 0.9}, suite.log, []*Planet{}, []*NPC{})
+	server := &UniverseServer{Game: game, Log: suite.log}
+
+	_, err := server.ControlGame(context.Background(), &pb.GameControl{})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	suite.False(game.IsPaused())
+
+	_, err = server.ControlGame(context.Background(), &pb.GameControl{Action: pb.GameControl_STEP, Steps: 1})
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+
+	resp, err := server.ControlGame(context.Background(), &pb.GameControl{Action: pb.GameControl_PAUSE})
+	suite.NoError(err)
+	suite.True(resp.Paused)
+
+	resp, err = server.ControlGame(context.Background(), &pb.GameControl{Action: pb.GameControl_STEP, Steps: 2})
+	suite.NoError(err)
+	suite.Equal(uint64(2), resp.Ticks)
+
+	_, err = server.ControlGame(context.Background(), &pb.GameControl{Action: pb.GameControl_SET_SPEED, Speed: 0})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+
+	resp, err = server.ControlGame(context.Background(), &pb.GameControl{Action: pb.GameControl_SET_SPEED, Speed: 4})
+	suite.NoError(err)
+	suite.Equal(4.0, resp.Speed)
+	suite.Equal("250ms", resp.TickInterval)
+
+	resp, err = server.ControlGame(context.Background(), &pb.GameControl{Action: pb.GameControl_RESUME})
+	suite.NoError(err)
+	suite.False(resp.Paused)
+}
+
+func (suite *UniverseServerTestSuite) TestControlGameRejectsPlayers() {
+	game := NewGameService(Config{TickDuration: time.Second}, &mockRand{}, suite.log, []*Planet{}, []*NPC{})
+	server := &UniverseServer{Game: game, Log: suite.log}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(PlayerIDHeader, "player"))
+	_, err := server.ControlGame(ctx, &pb.GameControl{Action: pb.GameControl_PAUSE})
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+	suite.False(game.IsPaused())
+}
+
+func (suite *UniverseServerTestSuite) TestPlayerRequests() {
+	mars := &Planet{Name: "Mars", Type: Desert, Resources: map[ResourceType]int{Iron: 1000, Food: 1000, Fuel: 1000}, Modifiers: map[ResourceType]float64{}}
+	venus := &Planet{Name: "Venus", Type: Desert, Resources: map[ResourceType]int{Iron: 1000, Fuel: 40}, Modifiers: map[ResourceType]float64{}}
+	game := NewGameService(DefaultConfig(), &mockRand{}, suite.log, []*Planet{mars, venus}, []*NPC{})
+	server := &UniverseServer{Game: game, Log: suite.log}
+
+	alice, err := server.JoinUniverse(context.Background(), &pb.JoinRequest{Name: "Alice"})
+	suite.NoError(err)
+	suite.Equal("Alice", alice.Name)
+	suite.Equal(int32(1000), alice.Credits)
+	suite.NotEmpty(alice.Id)
+	_, err = server.JoinUniverse(context.Background(), &pb.JoinRequest{Name: "Alice"})
+	suite.Equal(codes.AlreadyExists, status.Code(err))
+	bob, _ := server.JoinUniverse(context.Background(), &pb.JoinRequest{Name: "Bob"})
+
+	asAlice := metadata.NewIncomingContext(context.Background(), metadata.Pairs(PlayerIDHeader, alice.Id))
+	asBob := metadata.NewIncomingContext(context.Background(), metadata.Pairs(PlayerIDHeader, bob.Id))
+
+	_, err = server.BuyResources(context.Background(), &pb.TradeRequest{Planet: "Mars", Resource: "Iron", Amount: 10})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	resp, err := server.BuyResources(asAlice, &pb.TradeRequest{Planet: "Mars", Resource: "Iron", Amount: 10})
+	suite.NoError(err)
+	suite.Equal(int32(10), resp.Inventory["Iron"])
+	_, err = server.SellResources(asAlice, &pb.TradeRequest{Planet: "Mars", Resource: "Fuel", Amount: 10})
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = server.BuyResources(asAlice, &pb.TradeRequest{Planet: "Pluto", Resource: "Iron", Amount: 10})
+	suite.Equal(codes.NotFound, status.Code(err))
+	_, err = server.BuyResources(asAlice, &pb.TradeRequest{Planet: "Mars", Resource: "Gold", Amount: 10})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+
+	resp, err = server.ColonizePlanet(asAlice, &pb.BuildRequest{Planet: "Venus", Building: "Mine"})
+	suite.NoError(err)
+	suite.Equal([]string{"Venus"}, resp.Planets)
+	_, err = server.ColonizePlanet(asBob, &pb.BuildRequest{Planet: "Venus", Building: "Mine"})
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = server.CollectResources(asBob, &pb.TradeRequest{Planet: "Venus", Resource: "Iron", Amount: 10})
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = server.BuildOnPlanet(asBob, &pb.BuildRequest{Planet: "Venus", Building: "Fortress"})
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+
+	resp, err = server.CollectResources(asAlice, &pb.TradeRequest{Planet: "Venus", Resource: "Iron", Amount: 100})
+	suite.NoError(err)
+	suite.Equal(int32(110), resp.Inventory["Iron"])
+	resp, err = server.BuildOnPlanet(asAlice, &pb.BuildRequest{Planet: "Venus", Building: "Fortress"})
+	suite.NoError(err)
+	suite.Len(venus.Buildings, 2)
+
+	resp, err = server.GetPlayer(asAlice, &pb.Empty{})
+	suite.NoError(err)
+	suite.Equal(alice.Id, resp.Id)
+	_, err = server.GetPlayer(context.Background(), &pb.Empty{})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	// treasuries of player planets are only visible to their owner
+	venus.Treasury = 42
+	planets, _ := server.GetPlanets(asAlice, &pb.Empty{})
+	suite.Equal("Alice", planets.Planets[1].Player)
+	suite.Equal(int32(42), planets.Planets[1].Treasury)
+	planets, _ = server.GetPlanets(asBob, &pb.Empty{})
+	suite.Equal("Alice", planets.Planets[1].Player)
+	suite.Equal(int32(0), planets.Planets[1].Treasury)
+}
+
+func (suite *UniverseServerTestSuite) TestGetGameStatus() {
+	game := NewGameService(Config{TickDuration: time.Second}, &mockRand{}, suite.log, []*Planet{}, []*NPC{})
+	game.recordTick(2*time.Millisecond, time.Second)
+	game.recordTick(4*time.Millisecond, time.Second)
+	server := &UniverseServer{Game: game, Log: suite.log}
+	resp, err := server.GetGameStatus(context.Background(), &pb.Empty{})
+	suite.NoError(err)
+	suite.Equal(uint64(2), resp.Ticks)
+	suite.Equal(int64(3000), resp.AvgTickDurationMicros)
+	suite.Equal(int64(4000), resp.MaxTickDurationMicros)
+	suite.Equal("1s", resp.TickInterval)
+}
+
 func (suite *UniverseServerTestSuite) TestStartGRPCServerError() {
 	game := &Game{}
 	log := &mockLog{}
 	// Use an invalid address to force error
-	_, _, err := NewGRPCServer(game, "invalid_addr", log)
+	_, _, err := NewGRPCServer(game, "invalid_addr", nil, log)
 	suite.Error(err)
 }
 
+func (suite *UniverseServerTestSuite) TestStreamLogEvents() {
+	game := NewGameService(DefaultConfig(), &mockRand{}, &nopLog{}, []*Planet{}, []*NPC{})
+	server, lis, err := NewGRPCServer(game, "127.0.0.1:0", nil, &nopLog{})
+	suite.Require().NoError(err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	suite.Require().NoError(err)
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := pb.NewUniverseServiceClient(conn).StreamLogEvents(ctx, &pb.LogEventFilter{Kinds: []string{PlanetColonizedKind}})
+	suite.Require().NoError(err)
+
+	// wait for the subscription, events published before are missed
+	suite.Eventually(func() bool {
+		game.Events().mu.Lock()
+		defer game.Events().mu.Unlock()
+		return len(game.Events().handlers) == 2
+	}, time.Second, time.Millisecond)
+	Emit(game.log, EventTriggered{Name: "Solar Flare", Planet: "Mars"})
+	Emit(game.log, PlanetColonized{Planet: "Mars", Colonist: "Trader", Building: Mine})
+
+	event, err := stream.Recv()
+	suite.Require().NoError(err)
+	suite.Equal(PlanetColonizedKind, event.Kind)
+	suite.Equal("NPC Trader colonized planet Mars and established a Mine.", event.Message)
+	suite.Equal("Mars", event.Attributes["planet"])
+	suite.NotEmpty(event.Time)
+}
+
 type mockStream struct {
 	recvCalls  int
 	sendCalls  int
@@ -277,55 +558,142 @@ func (m *mockStreamErrorSend) SendHeader(md metadata.MD) error {
 // Implement BidiStreamingServer interface marker method
 func (m *mockStreamErrorSend) BidiStreamingServer() {}
 
-func (suite *UniverseServerTestSuite) TestStreamUniverseStateSubscribePauseResumeUnsubscribe() {
-	// Setup channels
-	planetCh := make(chan []*Planet, 1)
-	npcCh := make(chan []*NPC, 1)
-	eventCh := make(chan []*Event, 1)
+// liveStream is a stream receiving commands passed by the test while it runs, until commands are closed.
+type liveStream struct {
+	mockStream
+	commands chan *pb.ClientCommand
+	mu       sync.Mutex
+	sent     []*pb.UniverseState
+}
 
-	// Prepare updates
-	planetCh <- []*Planet{{Name: "Earth"}}
-	npcCh <- []*NPC{{Name: "NPC1"}}
-	eventCh <- []*Event{{Name: "Event1"}}
+func newLiveStream() *liveStream {
+	return &liveStream{commands: make(chan *pb.ClientCommand)}
+}
 
-	game := &Game{
-		planetUpdates: planetCh,
-		npcUpdates:    npcCh,
-		eventUpdates:  eventCh,
+func (m *liveStream) Recv() (*pb.ClientCommand, error) {
+	cmd, ok := <-m.commands
+	if !ok {
+		return nil, errors.New("stream closed")
 	}
+	return cmd, nil
+}
 
-	server := &UniverseServer{Game: game, Log: suite.log}
+func (m *liveStream) Send(state *pb.UniverseState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, state)
+	return nil
+}
 
-	// Prepare commands: SUBSCRIBE, PAUSE, RESUME, UNSUBSCRIBE, then close
-	cmds := []*pb.ClientCommand{
-		{Type: pb.ClientCommand_SUBSCRIBE},
-		{Type: pb.ClientCommand_PAUSE},
-		{Type: pb.ClientCommand_RESUME},
-		{Type: pb.ClientCommand_UNSUBSCRIBE},
-	}
-	stream := &mockStream{recvCmds: cmds}
+func (m *liveStream) sentStates() []*pb.UniverseState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*pb.UniverseState{}, m.sent...)
+}
+
+// publishTick sends updates of the game as after a tick.
+func publishTick(game *Game) {
+	game.mu.Lock()
+	defer game.mu.Unlock()
+	game.metrics.Ticks++
+	game.sendUpdates(game.metrics.Ticks)
+}
 
-	// Run the method in a goroutine to avoid blocking
+func (suite *UniverseServerTestSuite) waitForSubscribers(game *Game, n int) {
+	suite.Eventually(func() bool {
+		game.mu.Lock()
+		defer game.mu.Unlock()
+		return len(game.subscribers) == n
+	}, time.Second, time.Millisecond)
+}
+
+func (suite *UniverseServerTestSuite) runStream(server *UniverseServer, stream pb.UniverseService_StreamUniverseStateServer) <-chan error {
 	done := make(chan error, 1)
-	go func() {
-		err := server.StreamUniverseState(stream)
-		// Only treat error as failure if it's not "stream closed"
-		if err != nil && err.Error() != "stream closed" && err != nil {
-			done <- err
-		} else {
-			done <- nil
-		}
-	}()
+	go func() { done <- server.StreamUniverseState(stream) }()
+	return done
+}
 
-	// Wait for goroutine to finish
-	err := <-done
-	suite.True(stream.closed)
+func sequencesOf(states []*pb.UniverseState) []uint64 {
+	seqs := []uint64{}
+	for _, state := range states {
+		seqs = append(seqs, state.Sequence)
+	}
+	return seqs
+}
+
+func (suite *UniverseServerTestSuite) TestStreamUniverseStateSubscribePauseResumeUnsubscribe() {
+	game := NewGameService(DefaultConfig(), &mockRand{}, &nopLog{}, []*Planet{{Name: "Earth"}}, []*NPC{{Name: "NPC1"}})
+	game.ActiveEvents = []*Event{{Name: "Event1"}}
+	server := &UniverseServer{Game: game, Log: &nopLog{}}
+	stream := newLiveStream()
+	done := suite.runStream(server, stream)
+
+	stream.commands <- &pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE}
+	suite.waitForSubscribers(game, 1)
+	publishTick(game)
+	suite.Eventually(func() bool { return len(stream.sentStates()) == 1 }, time.Second, time.Millisecond)
+	state := stream.sentStates()[0]
+	suite.Equal("Earth", state.Planets.Planets[0].Name)
+	suite.Equal("NPC1", state.Npcs.Npcs[0].Name)
+	suite.Equal("Event1", state.Events[0].Name)
+	suite.Equal(uint64(1), state.Tick)
+	suite.Equal(uint64(1), state.Sequence)
+
+	stream.commands <- &pb.ClientCommand{Type: pb.ClientCommand_PAUSE}
+	suite.waitForSubscribers(game, 0)
+	publishTick(game)
+	stream.commands <- &pb.ClientCommand{Type: pb.ClientCommand_RESUME}
+	suite.waitForSubscribers(game, 1)
+	publishTick(game)
+	suite.Eventually(func() bool { return len(stream.sentStates()) == 2 }, time.Second, time.Millisecond)
+	suite.Equal([]uint64{1, 3}, sequencesOf(stream.sentStates()), "updates made while paused aren't sent")
+
+	stream.commands <- &pb.ClientCommand{Type: pb.ClientCommand_UNSUBSCRIBE}
+	suite.waitForSubscribers(game, 0)
+	close(stream.commands)
+	suite.EqualError(<-done, "stream closed")
+}
+
+func (suite *UniverseServerTestSuite) TestStreamUniverseStateToConcurrentStreams() {
+	game := NewGameService(DefaultConfig(), &mockRand{}, &nopLog{}, []*Planet{{Name: "Earth"}}, []*NPC{})
+	server := &UniverseServer{Game: game, Log: &nopLog{}}
+	first, second := newLiveStream(), newLiveStream()
+	firstDone, secondDone := suite.runStream(server, first), suite.runStream(server, second)
+	first.commands <- &pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE}
+	second.commands <- &pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE}
+	suite.waitForSubscribers(game, 2)
+
+	for range 3 {
+		publishTick(game)
+	}
+	suite.Eventually(func() bool { return len(first.sentStates()) == 3 && len(second.sentStates()) == 3 }, time.Second, time.Millisecond)
+	suite.Equal([]uint64{1, 2, 3}, sequencesOf(first.sentStates()))
+	suite.Equal([]uint64{1, 2, 3}, sequencesOf(second.sentStates()))
+
+	close(first.commands)
+	close(second.commands)
+	suite.NoError(<-firstDone)
+	suite.NoError(<-secondDone)
+	suite.waitForSubscribers(game, 0)
+}
+
+func (suite *UniverseServerTestSuite) TestStreamUniverseStateConfigChange() {
+	game := NewGameService(DefaultConfig(), &mockRand{}, &nopLog{}, []*Planet{}, []*NPC{})
+	server := &UniverseServer{Game: game, Log: &nopLog{}}
+	stream := newLiveStream()
+	done := suite.runStream(server, stream)
+	stream.commands <- &pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE}
+	suite.waitForSubscribers(game, 1)
+
+	next := DefaultConfig()
+	next.Taxes.ProductionTax = 2
+	_, err := game.ReloadConfig(next)
 	suite.NoError(err)
-	// Only one update should be sent (on SUBSCRIBE, since only one set of updates in channel)
-	suite.GreaterOrEqual(stream.sendCalls, 1)
-	suite.Equal("Earth", stream.sentStates[0].Planets.Planets[0].Name)
-	suite.Equal("NPC1", stream.sentStates[0].Npcs.Npcs[0].Name)
-	suite.Equal("Event1", stream.sentStates[0].Events[0].Name)
+	suite.Eventually(func() bool { return len(stream.sentStates()) == 1 }, time.Second, time.Millisecond)
+	suite.NotEmpty(stream.sentStates()[0].ConfigChange.Applied)
+
+	close(stream.commands)
+	suite.NoError(<-done)
 }
 
 func (suite *UniverseServerTestSuite) TestStreamUniverseStateErrorOnRecv() {
@@ -337,22 +705,198 @@ func (suite *UniverseServerTestSuite) TestStreamUniverseStateErrorOnRecv() {
 }
 
 func (suite *UniverseServerTestSuite) TestStreamUniverseStateErrorOnSend() {
-	planetCh := make(chan []*Planet, 1)
-	npcCh := make(chan []*NPC, 1)
-	eventCh := make(chan []*Event, 1)
-	planetCh <- []*Planet{{Name: "Earth"}}
-	npcCh <- []*NPC{{Name: "NPC1"}}
-	eventCh <- []*Event{{Name: "Event1"}}
-	game := &Game{
-		planetUpdates: planetCh,
-		npcUpdates:    npcCh,
-		eventUpdates:  eventCh,
-	}
+	game := NewGameService(DefaultConfig(), &mockRand{}, suite.log, []*Planet{{Name: "Earth"}}, []*NPC{})
 	server := &UniverseServer{Game: game, Log: suite.log}
-	// Stream that returns error on Send
+	// Stream that returns error on Send, of the keyframe sent on resume
 	stream := &mockStreamErrorSend{
-		recvCmds: []*pb.ClientCommand{{Type: pb.ClientCommand_SUBSCRIBE}},
+		recvCmds: []*pb.ClientCommand{{Type: pb.ClientCommand_SUBSCRIBE, ResumeFrom: 42}},
 	}
 	err := server.StreamUniverseState(stream)
 	suite.Error(err)
 }
+
+func (suite *UniverseServerTestSuite) TestStreamUniverseStateForPlayer() {
+	planet := &Planet{Name: "Earth", Treasury: 42}
+	game := NewGameService(DefaultConfig(), &mockRand{}, suite.log, []*Planet{planet}, []*NPC{})
+	alice, _ := game.JoinUniverse("Alice", "")
+	bob, _ := game.JoinUniverse("Bob", "")
+	planet.Player = game.Players[0]
+	publishTick(game)
+	publishTick(game)
+	server := &UniverseServer{Game: game, Log: suite.log}
+
+	stream := &mockStream{
+		recvCmds: []*pb.ClientCommand{{Type: pb.ClientCommand_SUBSCRIBE, ResumeFrom: 1}},
+		ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs(PlayerIDHeader, bob.ID)),
+	}
+	server.StreamUniverseState(stream)
+	suite.Len(stream.sentStates, 1)
+	suite.Equal("Bob", stream.sentStates[0].Player.Name)
+	suite.Equal("Alice", stream.sentStates[0].Planets.Planets[0].Player)
+	suite.Equal(int32(0), stream.sentStates[0].Planets.Planets[0].Treasury)
+
+	stream = &mockStream{
+		recvCmds: []*pb.ClientCommand{{Type: pb.ClientCommand_SUBSCRIBE, ResumeFrom: 1}},
+		ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs(PlayerIDHeader, alice.ID)),
+	}
+	server.StreamUniverseState(stream)
+	suite.Equal([]string{"Earth"}, stream.sentStates[0].Player.Planets)
+	suite.Equal(int32(42), stream.sentStates[0].Planets.Planets[0].Treasury)
+
+	stream = &mockStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(PlayerIDHeader, "unknown"))}
+	err := server.StreamUniverseState(stream)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *UniverseServerTestSuite) TestStreamUniverseStateWithFilter() {
+	trader := &NPC{Name: "Trader"}
+	planets := []*Planet{{Name: "Vega-B", Owner: trader}, {Name: "Aurora-A"}, {Name: "Luna-D"}}
+	game := NewGameService(DefaultConfig(), &mockRand{}, suite.log, planets, []*NPC{trader})
+	game.ActiveEvents = []*Event{{Name: "Solar Flare", TargetPlanet: planets[1]}, {Name: "Trade Boom"}}
+	publishTick(game)
+	publishTick(game)
+	server := &UniverseServer{Game: game, Log: suite.log}
+
+	filter := &pb.StateFilter{Planets: []string{"Luna-D"}, Owners: []string{"Trader"}}
+	stream := &mockStream{recvCmds: []*pb.ClientCommand{{Type: pb.ClientCommand_SUBSCRIBE, Filter: filter, ResumeFrom: 1}}}
+	suite.NoError(server.StreamUniverseState(stream))
+	suite.Len(stream.sentStates, 1)
+	state := stream.sentStates[0]
+	suite.Len(state.Planets.Planets, 2)
+	suite.Equal("Vega-B", state.Planets.Planets[0].Name)
+	suite.Equal("Luna-D", state.Planets.Planets[1].Name)
+	suite.Len(state.Npcs.Npcs, 1)
+	suite.Len(state.Events, 1)
+	suite.Equal("Trade Boom", state.Events[0].Name)
+}
+
+func (suite *UniverseServerTestSuite) TestStreamUniverseStateResume() {
+	config := DefaultConfig()
+	config.ResumeBuffer = 2
+	game := NewGameService(config, &mockRand{}, suite.log, []*Planet{{Name: "Earth"}}, []*NPC{})
+	server := &UniverseServer{Game: game, Log: suite.log}
+	stream := func(cmd *pb.ClientCommand) []*pb.UniverseState {
+		stream := &mockStream{recvCmds: []*pb.ClientCommand{cmd}}
+		suite.NoError(server.StreamUniverseState(stream))
+		return stream.sentStates
+	}
+
+	// updates are buffered without streams
+	for range 3 {
+		publishTick(game)
+	}
+	missed := stream(&pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE, ResumeFrom: 1})
+	suite.Equal([]uint64{2, 3}, sequencesOf(missed))
+	suite.Equal([]uint64{2, 3}, []uint64{missed[0].Tick, missed[1].Tick})
+	suite.False(missed[0].Keyframe)
+	suite.Empty(stream(&pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE, ResumeFrom: 3}))
+
+	publishTick(game)
+	resumed := stream(&pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE, ResumeFrom: 1})
+	suite.Len(resumed, 1, "update 2 isn't buffered anymore")
+	suite.True(resumed[0].Keyframe)
+	suite.Equal(uint64(4), resumed[0].Sequence)
+	suite.Equal("Earth", resumed[0].Planets.Planets[0].Name)
+
+	resumed = stream(&pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE, ResumeFrom: 42})
+	suite.Len(resumed, 1)
+	suite.True(resumed[0].Keyframe)
+}
+
+func (suite *UniverseServerTestSuite) TestHandleClientCommandFilter() {
+	state := &streamState{}
+	handleClientCommand(&pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE, Payload: `{"events": ["Solar Flare"]}`}, suite.log, state)
+	suite.True(state.subscribed)
+	suite.Equal(StateFilter{Events: []string{"Solar Flare"}}, state.filter)
+
+	handleClientCommand(&pb.ClientCommand{Type: pb.ClientCommand_FILTER, Filter: &pb.StateFilter{OnlyNpcs: true}}, suite.log, state)
+	suite.True(state.subscribed)
+	suite.Equal(StateFilter{OnlyNPCs: true}, state.filter)
+
+	handleClientCommand(&pb.ClientCommand{Type: pb.ClientCommand_FILTER, Payload: "{invalid"}, suite.log, state)
+	suite.Equal(StateFilter{OnlyNPCs: true}, state.filter)
+
+	handleClientCommand(&pb.ClientCommand{Type: pb.ClientCommand_PAUSE, Payload: `{"planets": ["Vega-B"]}`}, suite.log, state)
+	suite.True(state.paused)
+	suite.Equal(StateFilter{OnlyNPCs: true}, state.filter)
+
+	handleClientCommand(&pb.ClientCommand{Type: pb.ClientCommand_FILTER}, suite.log, state)
+	suite.Equal(StateFilter{}, state.filter)
+}
+
+func (suite *UniverseServerTestSuite) TestGetHistory() {
+	planets := []*Planet{{Name: "Vega-B", Resources: map[ResourceType]int{Iron: 10}, Modifiers: map[ResourceType]float64{}}}
+	game := NewGameService(Config{TickDuration: time.Second}, &mockRand{}, &nopLog{}, planets, []*NPC{})
+	server := &UniverseServer{Game: game, Log: suite.log}
+
+	_, err := server.GetHistory(context.Background(), &pb.HistoryRequest{Entity: "planet", Name: "Vega-B", Metric: "resources"})
+	suite.Equal(codes.Unavailable, status.Code(err))
+
+	game.EnableHistory(HistoryConfig{SampleTicks: 1, Samples: 10})
+	game.tick(0)
+	game.tick(0)
+	resp, err := server.GetHistory(context.Background(), &pb.HistoryRequest{Entity: "planet", Name: "Vega-B", Metric: "resources.Iron", Resolution: "1h"})
+	suite.Require().NoError(err)
+	suite.Require().Len(resp.Points, 1)
+	suite.Equal(uint64(1), resp.Points[0].Tick)
+	suite.Equal(float64(10), resp.Points[0].Value)
+
+	_, err = server.GetHistory(context.Background(), &pb.HistoryRequest{Entity: "planet", Name: "Rigel", Metric: "resources"})
+	suite.Equal(codes.NotFound, status.Code(err))
+	_, err = server.GetHistory(context.Background(), &pb.HistoryRequest{Entity: "planet", Name: "Vega-B", Metric: "credits"})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = server.GetHistory(context.Background(), &pb.HistoryRequest{Entity: "planet", Name: "Vega-B", Metric: "resources", From: "yesterday"})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *UniverseServerTestSuite) TestQueryRPCs() {
+	planets := []*Planet{
+		{Name: "Vega-B", Type: Desert, Resources: map[ResourceType]int{Iron: 100}, Modifiers: map[ResourceType]float64{}, Buildings: []*Building{{Type: Mine}}},
+		{Name: "Luna-D", Type: Icy, Resources: map[ResourceType]int{Iron: 20}, Modifiers: map[ResourceType]float64{}},
+	}
+	npcs := []*NPC{{Name: "Trader", Credits: 300}}
+	game := NewGameService(Config{TickDuration: time.Second}, &mockRand{}, &nopLog{}, planets, npcs)
+	game.SetSeed(7)
+	game.ActiveEvents = []*Event{{Name: "Solar Flare", TargetPlanet: planets[0], Duration: 3}}
+	server := &UniverseServer{Game: game, Log: suite.log}
+
+	planet, err := server.GetPlanet(context.Background(), &pb.EntityRequest{Name: "Luna-D"})
+	suite.Require().NoError(err)
+	suite.Equal("Icy", planet.Type)
+	_, err = server.GetPlanet(context.Background(), &pb.EntityRequest{Name: "Rigel"})
+	suite.Equal(codes.NotFound, status.Code(err))
+
+	npc, err := server.GetNPC(context.Background(), &pb.EntityRequest{Name: "Trader"})
+	suite.Require().NoError(err)
+	suite.Equal(int32(300), npc.Credits)
+	_, err = server.GetNPC(context.Background(), &pb.EntityRequest{Name: "Nobody"})
+	suite.Equal(codes.NotFound, status.Code(err))
+
+	page, err := server.ListPlanets(context.Background(), &pb.PlanetQuery{Buildings: []string{"Mine"}, MinResources: map[string]int32{"Iron": 50}})
+	suite.Require().NoError(err)
+	suite.Require().Len(page.Planets, 1)
+	suite.Equal("Vega-B", page.Planets[0].Name)
+	suite.Equal(int32(1), page.Total)
+	_, err = server.ListPlanets(context.Background(), &pb.PlanetQuery{Types: []string{"Volcanic"}})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = server.ListPlanets(context.Background(), &pb.PlanetQuery{PageToken: "x"})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+
+	npcPage, err := server.ListNPCs(context.Background(), &pb.NPCQuery{Sort: "credits", PageSize: 1})
+	suite.Require().NoError(err)
+	suite.Len(npcPage.Npcs, 1)
+	suite.Empty(npcPage.NextPageToken)
+	_, err = server.ListNPCs(context.Background(), &pb.NPCQuery{MinCargo: map[string]int32{"Gold": 1}})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+
+	events, err := server.GetActiveEvents(context.Background(), &pb.Empty{})
+	suite.Require().NoError(err)
+	suite.Require().Len(events.Events, 1)
+	suite.Equal("Vega-B", events.Events[0].TargetPlanet)
+
+	info, err := server.GetGameInfo(context.Background(), &pb.Empty{})
+	suite.Require().NoError(err)
+	suite.Equal(int64(7), info.Seed)
+	suite.Equal("1s", info.TickDuration)
+	suite.Equal(Version, info.Version)
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "github.com/tommzn/utte-universe/core/proto"
+)
+
+var (
+	ErrGameLoopNotRunning = errors.New("game loop isn't running")
+	ErrGameLoopStalled    = errors.New("game loop stalled")
+	ErrTicksOverdue       = errors.New("game ticks are overdue")
+)
+
+// stalledTicks is the number of tick intervals the game loop may be silent, or behind with its ticks,
+// until it's considered stalled. It's never considered stalled earlier than minStallTimeout.
+const (
+	stalledTicks    = 3
+	minStallTimeout = 5 * time.Second
+)
+
+// HealthCheck reports a failure of a component by returning an error.
+type HealthCheck func() error
+
+// loopHealth is written by the game loop and read by health checks without locking the game,
+// so checks still respond if a tick hangs while holding the lock.
+type loopHealth struct {
+	running   atomic.Bool
+	heartbeat atomic.Int64 // unix nanos the game loop was active last
+	lastTick  atomic.Int64 // unix nanos the last tick completed, or the game loop started
+	interval  atomic.Int64 // current tick interval in nanos
+}
+
+// beat records that the game loop is active, running ticks at passed interval.
+func (h *loopHealth) beat(now time.Time, interval time.Duration) {
+	h.heartbeat.Store(now.UnixNano())
+	h.interval.Store(int64(interval))
+}
+
+// stallTimeout returns how long the game loop may be silent until it's considered stalled.
+func (h *loopHealth) stallTimeout() time.Duration {
+	return max(stalledTicks*time.Duration(h.interval.Load()), minStallTimeout)
+}
+
+// since returns the time passed since passed unix nanos.
+func since(now time.Time, unixNanos int64) time.Duration {
+	return now.Sub(time.Unix(0, unixNanos))
+}
+
+// CheckAlive fails if the game loop has stopped or hasn't been active for several tick intervals,
+// e.g. because a tick hangs. A paused game is alive.
+func (g *Game) CheckAlive() error {
+	if !g.health.running.Load() {
+		return ErrGameLoopNotRunning
+	}
+	if age := since(time.Now(), g.health.heartbeat.Load()); age > g.health.stallTimeout() {
+		return fmt.Errorf("%w: last active %v ago", ErrGameLoopStalled, age.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// CheckReady fails if the game isn't alive or, unless it's paused, its last tick completed more
+// than several tick intervals ago.
+func (g *Game) CheckReady() error {
+	if err := g.CheckAlive(); err != nil {
+		return err
+	}
+	if g.IsPaused() {
+		return nil
+	}
+	if age := since(time.Now(), g.health.lastTick.Load()); age > g.health.stallTimeout() {
+		return fmt.Errorf("%w: last tick %v ago", ErrTicksOverdue, age.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// RegisterHealthService registers the standard gRPC health service at passed server. The universe
+// service, and the server overall, serve while passed check succeeds, it's repeated every interval
+// until ctx is done. The returned health server lets callers mark the service as not serving on shutdown.
+func RegisterHealthService(ctx context.Context, server *grpc.Server, check HealthCheck, interval time.Duration, log Log) *health.Server {
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	serving := true
+	update := func() {
+		err := check()
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			if serving {
+				log.Error("Universe service isn't serving: %v", err)
+			}
+		}
+		serving = err == nil
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(pb.UniverseService_ServiceDesc.ServiceName, status)
+	}
+	update()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				update()
+			}
+		}
+	}()
+	return healthServer
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	pb "github.com/tommzn/utte-universe/core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type HealthSuite struct {
+	suite.Suite
+	game *Game
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthSuite))
+}
+
+func (s *HealthSuite) SetupTest() {
+	s.game = NewGameService(Config{TickDuration: 10 * time.Millisecond}, &mockRand{}, &nopLog{}, []*Planet{}, []*NPC{})
+}
+
+// startLoop runs the game loop until the returned function is called.
+func (s *HealthSuite) startLoop() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.game.GameLoop(ctx)
+		close(done)
+	}()
+	s.Eventually(func() bool { return s.game.CheckAlive() == nil }, time.Second, time.Millisecond)
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (s *HealthSuite) TestGameLoopNotRunning() {
+	s.ErrorIs(s.game.CheckAlive(), ErrGameLoopNotRunning)
+	s.ErrorIs(s.game.CheckReady(), ErrGameLoopNotRunning)
+
+	stop := s.startLoop()
+	s.NoError(s.game.CheckReady())
+	stop()
+	s.ErrorIs(s.game.CheckAlive(), ErrGameLoopNotRunning)
+}
+
+func (s *HealthSuite) TestStalledGameLoop() {
+	defer s.startLoop()()
+
+	// A tick holding the lock keeps the loop from beating, checks still respond.
+	s.game.mu.Lock()
+	s.game.health.heartbeat.Store(time.Now().Add(-time.Minute).UnixNano())
+	s.ErrorIs(s.game.CheckAlive(), ErrGameLoopStalled)
+	s.game.mu.Unlock()
+}
+
+func (s *HealthSuite) TestOverdueTicks() {
+	defer s.startLoop()()
+
+	s.game.Pause()
+	s.game.health.lastTick.Store(time.Now().Add(-time.Minute).UnixNano())
+	s.NoError(s.game.CheckReady())
+
+	s.game.mu.Lock()
+	s.game.paused = false
+	s.game.mu.Unlock()
+	s.ErrorIs(s.game.CheckReady(), ErrTicksOverdue)
+	s.NoError(s.game.CheckAlive())
+	s.Eventually(func() bool { return s.game.CheckReady() == nil }, time.Second, time.Millisecond)
+}
+
+func (s *HealthSuite) TestHealthService() {
+	var stalled atomic.Bool
+	check := func() error {
+		if stalled.Load() {
+			return ErrGameLoopStalled
+		}
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server, lis, err := NewGRPCServer(s.game, "127.0.0.1:0", NewTokenVerifier([]byte("secret"), ""), &nopLog{})
+	s.Require().NoError(err)
+	RegisterHealthService(ctx, server, check, time.Millisecond, &nopLog{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	// health checks don't require a token
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: pb.UniverseService_ServiceDesc.ServiceName})
+	s.Require().NoError(err)
+	s.Equal(healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	stalled.Store(true)
+	s.Eventually(func() bool {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err == nil && resp.Status == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,262 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tommzn/go-config"
+)
+
+var (
+	ErrHistoryDisabled = errors.New("history is disabled")
+	ErrUnknownEntity   = errors.New("unknown entity")
+	ErrUnknownMetric   = errors.New("unknown metric")
+)
+
+// HistoryConfig defines how much history of planets and NPCs the game keeps. History is disabled without samples.
+type HistoryConfig struct {
+	SampleTicks int // ticks between two samples
+	Samples     int // samples kept per planet and NPC, older samples are overwritten
+}
+
+// LoadHistoryConfig reads history config below passed key, e.g. history.samples. By default, every
+// 10th tick is sampled and 8640 samples are kept, a day of history at a tick duration of one second.
+func LoadHistoryConfig(conf config.Config, key string) (HistoryConfig, error) {
+	history := HistoryConfig{
+		SampleTicks: *conf.GetAsInt(key+".sample_ticks", config.AsIntPtr(10)),
+		Samples:     *conf.GetAsInt(key+".samples", config.AsIntPtr(8640)),
+	}
+	if history.SampleTicks <= 0 {
+		return HistoryConfig{}, fmt.Errorf("history: sample_ticks has to be positive")
+	}
+	if history.Samples < 0 {
+		return HistoryConfig{}, fmt.Errorf("history: samples can't be negative")
+	}
+	return history, nil
+}
+
+// EntityType is the type of entity a history is kept for.
+type EntityType string
+
+const (
+	PlanetEntity EntityType = "planet"
+	NPCEntity    EntityType = "npc"
+)
+
+// Metrics kept in history. Resources, production and cargo are totals over all resources,
+// a single resource is selected by its name, e.g. resources.Iron.
+const (
+	ResourcesMetric  = "resources"
+	ProductionMetric = "production"
+	OwnerMetric      = "owner" // name of the NPC, or player, owning a planet, a text metric
+	TreasuryMetric   = "treasury"
+	GarrisonMetric   = "garrison"
+	CreditsMetric    = "credits"
+	CargoMetric      = "cargo"
+	ShipsMetric      = "ships"
+)
+
+// HistoryPoint is the value of a metric at a sampled tick. Text metrics have a text instead of a value.
+type HistoryPoint struct {
+	Tick  uint64
+	Time  time.Time
+	Value float64
+	Text  string
+}
+
+// HistoryQuery selects the history of a metric of a planet or NPC. Zero times don't limit the range,
+// points are at least Resolution apart, all points are returned without resolution.
+type HistoryQuery struct {
+	Entity     EntityType
+	Name       string
+	Metric     string
+	From       time.Time
+	To         time.Time
+	Resolution time.Duration
+}
+
+// History keeps samples of planets and NPCs in a ring buffer for each of them. It has its own lock,
+// so queries don't hold up the game.
+type History struct {
+	mu     sync.Mutex
+	config HistoryConfig
+	rounds uint64 // samples taken
+	series map[string]*historySeries
+}
+
+type historySeries struct {
+	samples   []historySample // ring buffer, next is the oldest sample once it's full
+	next      int
+	lastRound uint64
+}
+
+type historySample struct {
+	Tick   uint64
+	Time   time.Time
+	Values map[string]float64
+	Texts  map[string]string
+}
+
+// NewHistory returns an empty history, nil if passed config disables history.
+func NewHistory(config HistoryConfig) *History {
+	if config.Samples <= 0 || config.SampleTicks <= 0 {
+		return nil
+	}
+	return &History{config: config, series: make(map[string]*historySeries)}
+}
+
+// EnableHistory keeps history of planets and NPCs from the next tick on, as configured.
+func (g *Game) EnableHistory(config HistoryConfig) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.history = NewHistory(config)
+}
+
+// History returns the sampled values of a metric of a planet or NPC, oldest first.
+func (g *Game) History(query HistoryQuery) ([]HistoryPoint, error) {
+	g.mu.Lock()
+	history := g.history
+	g.mu.Unlock()
+	if history == nil {
+		return nil, ErrHistoryDisabled
+	}
+	return history.Query(query)
+}
+
+// record samples all planets and NPCs if passed tick is due. Histories of NPCs which left the universe
+// are removed once all their samples would have been overwritten.
+func (h *History) record(tick uint64, now time.Time, planets []*Planet, npcs []*NPC) {
+	if h == nil || tick%uint64(h.config.SampleTicks) != 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.rounds++
+	for _, p := range planets {
+		h.add(historyKey(PlanetEntity, p.Name), planetSample(p, tick, now))
+	}
+	for _, n := range npcs {
+		h.add(historyKey(NPCEntity, n.Name), npcSample(n, tick, now))
+	}
+	for key, series := range h.series {
+		if h.rounds-series.lastRound >= uint64(h.config.Samples) {
+			delete(h.series, key)
+		}
+	}
+}
+
+func (h *History) add(key string, sample historySample) {
+	series, ok := h.series[key]
+	if !ok {
+		series = &historySeries{}
+		h.series[key] = series
+	}
+	if len(series.samples) < h.config.Samples {
+		series.samples = append(series.samples, sample)
+	} else {
+		series.samples[series.next] = sample
+		series.next = (series.next + 1) % len(series.samples)
+	}
+	series.lastRound = h.rounds
+}
+
+// Query returns the sampled values of a metric of a planet or NPC, oldest first.
+func (h *History) Query(query HistoryQuery) ([]HistoryPoint, error) {
+	if !slices.Contains(HistoryMetrics(query.Entity), query.Metric) {
+		return nil, fmt.Errorf("%w: %s of %s", ErrUnknownMetric, query.Metric, query.Entity)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[historyKey(query.Entity, query.Name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrUnknownEntity, query.Entity, query.Name)
+	}
+	points := []HistoryPoint{}
+	for i := range series.samples {
+		sample := series.samples[(series.next+i)%len(series.samples)]
+		if (!query.From.IsZero() && sample.Time.Before(query.From)) || (!query.To.IsZero() && sample.Time.After(query.To)) {
+			continue
+		}
+		if n := len(points); n > 0 && sample.Time.Sub(points[n-1].Time) < query.Resolution {
+			continue
+		}
+		points = append(points, HistoryPoint{
+			Tick:  sample.Tick,
+			Time:  sample.Time,
+			Value: sample.Values[query.Metric],
+			Text:  sample.Texts[query.Metric],
+		})
+	}
+	return points, nil
+}
+
+// HistoryMetrics returns all metrics kept for passed entity type.
+func HistoryMetrics(entity EntityType) []string {
+	switch entity {
+	case PlanetEntity:
+		metrics := []string{ResourcesMetric, ProductionMetric, OwnerMetric, TreasuryMetric, GarrisonMetric}
+		for _, res := range resourceTypes {
+			metrics = append(metrics, resourceMetric(ResourcesMetric, res), resourceMetric(ProductionMetric, res))
+		}
+		return metrics
+	case NPCEntity:
+		metrics := []string{CreditsMetric, CargoMetric, ShipsMetric}
+		for _, res := range resourceTypes {
+			metrics = append(metrics, resourceMetric(CargoMetric, res))
+		}
+		return metrics
+	default:
+		return nil
+	}
+}
+
+func planetSample(p *Planet, tick uint64, now time.Time) historySample {
+	sample := historySample{
+		Tick: tick,
+		Time: now,
+		Values: map[string]float64{
+			TreasuryMetric: float64(p.Treasury),
+			GarrisonMetric: float64(p.Garrison),
+		},
+		Texts: map[string]string{OwnerMetric: p.OwnerName()},
+	}
+	addResources(sample.Values, ResourcesMetric, p.Resources)
+	addResources(sample.Values, ProductionMetric, ProductionOf(p))
+	return sample
+}
+
+func npcSample(n *NPC, tick uint64, now time.Time) historySample {
+	sample := historySample{
+		Tick: tick,
+		Time: now,
+		Values: map[string]float64{
+			CreditsMetric: float64(n.Credits),
+			ShipsMetric:   float64(n.Ships),
+		},
+	}
+	addResources(sample.Values, CargoMetric, n.Cargo)
+	return sample
+}
+
+// addResources adds the amount of each resource, and their total, as values of passed metric.
+func addResources(values map[string]float64, metric string, amounts map[ResourceType]int) {
+	total := 0
+	for _, res := range resourceTypes {
+		values[resourceMetric(metric, res)] = float64(amounts[res])
+		total += amounts[res]
+	}
+	values[metric] = float64(total)
+}
+
+func resourceMetric(metric string, res ResourceType) string {
+	return metric + "." + res.String()
+}
+
+func historyKey(entity EntityType, name string) string {
+	return string(entity) + ":" + name
+}
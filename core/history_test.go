@@ -0,0 +1,136 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HistorySuite struct {
+	suite.Suite
+	start   time.Time
+	planet  *Planet
+	npc     *NPC
+	history *History
+}
+
+func TestHistorySuite(t *testing.T) {
+	suite.Run(t, new(HistorySuite))
+}
+
+func (s *HistorySuite) SetupTest() {
+	s.start = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.planet = &Planet{
+		Name:      "Vega-B",
+		Type:      TerraLike,
+		Resources: map[ResourceType]int{Iron: 10, Food: 5},
+		Modifiers: map[ResourceType]float64{},
+		Buildings: []*Building{{Type: Mine, Level: 2, Production: map[ResourceType]int{Iron: 3}}},
+	}
+	s.npc = &NPC{Name: "Trader", Credits: 100, Cargo: map[ResourceType]int{Fuel: 7}}
+	s.history = NewHistory(HistoryConfig{SampleTicks: 2, Samples: 3})
+}
+
+// recordTicks records passed ticks, one second apart, increasing the planet's iron by one each tick.
+func (s *HistorySuite) recordTicks(from, to uint64) {
+	for tick := from; tick <= to; tick++ {
+		s.planet.Resources[Iron]++
+		s.history.record(tick, s.start.Add(time.Duration(tick)*time.Second), []*Planet{s.planet}, []*NPC{s.npc})
+	}
+}
+
+func (s *HistorySuite) TestDisabled() {
+	s.Nil(NewHistory(HistoryConfig{SampleTicks: 10}))
+	game := NewGameService(DefaultConfig(), &mockRand{}, &nopLog{}, []*Planet{}, []*NPC{})
+	_, err := game.History(HistoryQuery{Entity: PlanetEntity, Name: "Vega-B", Metric: ResourcesMetric})
+	s.ErrorIs(err, ErrHistoryDisabled)
+}
+
+func (s *HistorySuite) TestRingBuffer() {
+	s.recordTicks(1, 8)
+
+	points, err := s.history.Query(HistoryQuery{Entity: PlanetEntity, Name: "Vega-B", Metric: "resources.Iron"})
+	s.Require().NoError(err)
+	s.Require().Len(points, 3)
+	s.Equal([]uint64{4, 6, 8}, []uint64{points[0].Tick, points[1].Tick, points[2].Tick})
+	s.Equal(float64(18), points[2].Value)
+	s.Equal(s.start.Add(8*time.Second), points[2].Time)
+
+	points, err = s.history.Query(HistoryQuery{Entity: PlanetEntity, Name: "Vega-B", Metric: ResourcesMetric})
+	s.Require().NoError(err)
+	s.Equal(float64(23), points[2].Value)
+
+	points, err = s.history.Query(HistoryQuery{Entity: PlanetEntity, Name: "Vega-B", Metric: "production.Iron"})
+	s.Require().NoError(err)
+	s.Equal(float64(6), points[0].Value)
+}
+
+func (s *HistorySuite) TestTimeRangeAndResolution() {
+	s.history = NewHistory(HistoryConfig{SampleTicks: 1, Samples: 100})
+	s.recordTicks(1, 10)
+
+	query := HistoryQuery{Entity: PlanetEntity, Name: "Vega-B", Metric: ResourcesMetric, From: s.start.Add(3 * time.Second), To: s.start.Add(8 * time.Second)}
+	points, err := s.history.Query(query)
+	s.Require().NoError(err)
+	s.Len(points, 6)
+
+	query.Resolution = 2500 * time.Millisecond
+	points, err = s.history.Query(query)
+	s.Require().NoError(err)
+	s.Require().Len(points, 2)
+	s.Equal(uint64(3), points[0].Tick)
+	s.Equal(uint64(6), points[1].Tick)
+}
+
+func (s *HistorySuite) TestOwnerAndNPCMetrics() {
+	s.recordTicks(1, 2)
+	s.planet.Owner = s.npc
+	s.npc.Credits = 50
+	s.recordTicks(3, 4)
+
+	points, err := s.history.Query(HistoryQuery{Entity: PlanetEntity, Name: "Vega-B", Metric: OwnerMetric})
+	s.Require().NoError(err)
+	s.Equal("", points[0].Text)
+	s.Equal("Trader", points[1].Text)
+
+	points, err = s.history.Query(HistoryQuery{Entity: NPCEntity, Name: "Trader", Metric: CreditsMetric})
+	s.Require().NoError(err)
+	s.Equal([]float64{100, 50}, []float64{points[0].Value, points[1].Value})
+
+	points, err = s.history.Query(HistoryQuery{Entity: NPCEntity, Name: "Trader", Metric: "cargo.Fuel"})
+	s.Require().NoError(err)
+	s.Equal(float64(7), points[0].Value)
+}
+
+func (s *HistorySuite) TestUnknownEntityAndMetric() {
+	s.recordTicks(1, 2)
+
+	_, err := s.history.Query(HistoryQuery{Entity: PlanetEntity, Name: "Rigel", Metric: ResourcesMetric})
+	s.ErrorIs(err, ErrUnknownEntity)
+	_, err = s.history.Query(HistoryQuery{Entity: NPCEntity, Name: "Trader", Metric: OwnerMetric})
+	s.ErrorIs(err, ErrUnknownMetric)
+	_, err = s.history.Query(HistoryQuery{Entity: "fleet", Name: "Trader", Metric: CreditsMetric})
+	s.ErrorIs(err, ErrUnknownMetric)
+}
+
+func (s *HistorySuite) TestDepartedNPCsExpire() {
+	s.recordTicks(1, 2)
+	for tick := uint64(3); tick <= 8; tick++ {
+		s.history.record(tick, s.start, []*Planet{s.planet}, []*NPC{})
+	}
+	_, err := s.history.Query(HistoryQuery{Entity: NPCEntity, Name: "Trader", Metric: CreditsMetric})
+	s.ErrorIs(err, ErrUnknownEntity)
+}
+
+func (s *HistorySuite) TestGameRecordsHistory() {
+	game := NewGameService(Config{TickDuration: time.Second}, &mockRand{}, &nopLog{}, []*Planet{s.planet}, []*NPC{})
+	game.EnableHistory(HistoryConfig{SampleTicks: 1, Samples: 10})
+	game.tick(0)
+	game.tick(0)
+
+	points, err := game.History(HistoryQuery{Entity: PlanetEntity, Name: "Vega-B", Metric: "resources.Iron"})
+	s.Require().NoError(err)
+	s.Require().Len(points, 2)
+	s.Equal(float64(22), points[1].Value)
+}
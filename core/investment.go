@@ -0,0 +1,61 @@
+package core
+
+// InvestmentBudget returns the credits an NPC is willing to spend at once to develop its planets,
+// its investment share of current credits.
+func (n *NPC) InvestmentBudget() int {
+	return n.Credits * n.InvestmentShare / 100
+}
+
+// Investment is what an NPC contributes to pay construction or upgrade costs on one of its planets.
+// Resources the planet lacks are unloaded from cargo first, the rest is bought at market price.
+type Investment struct {
+	Cargo   map[ResourceType]int // resources unloaded from cargo
+	Bought  map[ResourceType]int // resources bought at market price
+	Credits int                  // credits paid for bought resources
+}
+
+// PlanInvestment returns the investment an NPC has to make so a planet can pay passed costs.
+func PlanInvestment(npc *NPC, p *Planet, cost map[ResourceType]int) Investment {
+	investment := Investment{Cargo: make(map[ResourceType]int), Bought: make(map[ResourceType]int)}
+	for res, amount := range cost {
+		missing := amount - p.Resources[res]
+		if missing <= 0 {
+			continue
+		}
+		fromCargo := min(missing, npc.Cargo[res])
+		if fromCargo > 0 {
+			investment.Cargo[res] = fromCargo
+		}
+		if bought := missing - fromCargo; bought > 0 {
+			investment.Bought[res] = bought
+			investment.Credits += bought * MarketPrice(p, res, npc.Offer[res])
+		}
+	}
+	return investment
+}
+
+// Invest delivers all resources a planet lacks to pay passed costs, if the credits required
+// don't exceed the NPC's investment budget. Returns false if the NPC can't afford it.
+func Invest(npc *NPC, p *Planet, cost map[ResourceType]int, log Log) bool {
+
+	investment := PlanInvestment(npc, p, cost)
+	budget := npc.InvestmentBudget()
+	if investment.Credits > budget {
+		log.Debug("NPC %s: Investment of %d credits into planet %s exceeds budget of %d credits.", npc.Name, investment.Credits, p.Name, budget)
+		return false
+	}
+
+	for res, amount := range investment.Cargo {
+		npc.Cargo[res] -= amount
+		p.Resources[res] += amount
+	}
+	for res, amount := range investment.Bought {
+		p.Resources[res] += amount
+	}
+	npc.Credits -= investment.Credits
+	if investment.Credits > 0 || len(investment.Cargo) > 0 {
+		log.Info("NPC %s invested %d credits and %d units of cargo into planet %s, budget was %d credits.",
+			npc.Name, investment.Credits, sumAmounts(investment.Cargo), p.Name, budget)
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InvestmentSuite struct {
+	suite.Suite
+	npc    *NPC
+	planet *Planet
+	log    *mockLog
+}
+
+func TestInvestmentSuite(t *testing.T) {
+	suite.Run(t, new(InvestmentSuite))
+}
+
+func (s *InvestmentSuite) SetupTest() {
+	s.log = &mockLog{}
+	s.npc = &NPC{
+		Name:            "Tester",
+		Offer:           map[ResourceType]int{Iron: 10, Food: 10, Fuel: 10},
+		Credits:         1000,
+		Cargo:           map[ResourceType]int{Iron: 20, Food: 0, Fuel: 0},
+		MaxCargo:        100,
+		InvestmentShare: 50,
+	}
+	s.planet = &Planet{
+		Name:      "Colony",
+		Type:      TerraLike,
+		Owner:     s.npc,
+		Resources: map[ResourceType]int{Iron: 1000, Food: 0, Fuel: 500},
+	}
+}
+
+func (s *InvestmentSuite) TestInvestmentBudget() {
+	s.Equal(500, s.npc.InvestmentBudget())
+	s.npc.InvestmentShare = 0
+	s.Equal(0, s.npc.InvestmentBudget())
+}
+
+func (s *InvestmentSuite) TestPlanInvestment() {
+	s.planet.Resources[Iron] = 10
+	investment := PlanInvestment(s.npc, s.planet, map[ResourceType]int{Iron: 50, Food: 10, Fuel: 100})
+	s.Equal(map[ResourceType]int{Iron: 20}, investment.Cargo)
+	s.Equal(map[ResourceType]int{Iron: 20, Food: 10}, investment.Bought)
+	// both resources are scarce, so they cost twice the offer
+	s.Equal(20*20+10*20, investment.Credits)
+}
+
+func (s *InvestmentSuite) TestInvest() {
+	s.True(Invest(s.npc, s.planet, map[ResourceType]int{Iron: 50, Food: 10}, s.log))
+	s.Equal(10, s.planet.Resources[Food])
+	s.Equal(1000, s.planet.Resources[Iron])
+	s.Equal(20, s.npc.Cargo[Iron])
+	s.Equal(1000-200, s.npc.Credits)
+
+	s.False(Invest(s.npc, s.planet, map[ResourceType]int{Food: 100}, s.log))
+	s.Equal(10, s.planet.Resources[Food])
+	s.Equal(800, s.npc.Credits)
+}
+
+func (s *InvestmentSuite) TestNPCDevelopsWithInvestment() {
+	config := DefaultSeedConfig()
+	rand := &mockRand{}
+	build := NPCAction{Type: BuildAction, Planet: s.planet, Building: Farm}
+	s.True(ExecuteNPCAction(s.npc, build, config, DefaultConflictConfig(), time.Time{}, rand, s.log))
+	s.Len(s.planet.Buildings, 1)
+	s.Equal(0, s.planet.Resources[Food])
+	s.Equal(1000-30, s.planet.Resources[Iron])
+	s.Equal(1000-200, s.npc.Credits)
+
+	upgrade := NPCAction{Type: UpgradeAction, Planet: s.planet, Target: s.planet.Buildings[0]}
+	s.True(ExecuteNPCAction(s.npc, upgrade, config, DefaultConflictConfig(), time.Time{}, rand, s.log))
+	s.Equal(2, s.planet.Buildings[0].Level)
+	s.Equal(800-400, s.npc.Credits)
+
+	// the next upgrade needs 30 Food for 600 credits, but the budget is down to 200
+	s.False(ExecuteNPCAction(s.npc, upgrade, config, DefaultConflictConfig(), time.Time{}, rand, s.log))
+	s.Equal(2, s.planet.Buildings[0].Level)
+	s.Equal(400, s.npc.Credits)
+}
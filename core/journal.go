@@ -0,0 +1,374 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/tommzn/go-config"
+)
+
+var ErrJournalExists = errors.New("journal directory already contains a journal")
+
+// JournalConfig defines where the journal of a game is written to. The journal is disabled without directory.
+type JournalConfig struct {
+	Dir          string // directory of snapshots and journal segments
+	SegmentTicks int    // ticks per segment, a snapshot is written at the start of each segment
+	MaxSegments  int    // segments kept, older segments and their snapshots are removed, 0 keeps all
+}
+
+// LoadJournalConfig reads journal config below passed key, e.g. journal.dir.
+func LoadJournalConfig(conf config.Config, key string) (JournalConfig, error) {
+	journal := JournalConfig{
+		Dir:          *conf.Get(key+".dir", config.AsStringPtr("")),
+		SegmentTicks: *conf.GetAsInt(key+".segment_ticks", config.AsIntPtr(1000)),
+		MaxSegments:  *conf.GetAsInt(key+".max_segments", config.AsIntPtr(0)),
+	}
+	if journal.SegmentTicks <= 0 {
+		return JournalConfig{}, fmt.Errorf("journal: segment_ticks has to be positive")
+	}
+	if journal.MaxSegments < 0 {
+		return JournalConfig{}, fmt.Errorf("journal: max_segments can't be negative")
+	}
+	return journal, nil
+}
+
+// JournalEntryType is the kind of a state change recorded in a journal.
+type JournalEntryType string
+
+const (
+	TickEntry     JournalEntryType = "tick"
+	JoinEntry     JournalEntryType = "join"
+	BuyEntry      JournalEntryType = "buy"
+	SellEntry     JournalEntryType = "sell"
+	CollectEntry  JournalEntryType = "collect"
+	ColonizeEntry JournalEntryType = "colonize"
+	BuildEntry    JournalEntryType = "build"
+	ConfigEntry   JournalEntryType = "config"
+	ScheduleEntry JournalEntryType = "schedule"
+)
+
+// JournalEntry is a tick, or an action applied between two ticks, together with all random numbers
+// drawn while it changed the state of the game. Fields not used by an entry's type are empty.
+type JournalEntry struct {
+	Type     JournalEntryType
+	Tick     uint64 // ticks completed, including the tick of a tick entry
+	Time     time.Time
+	Draws    []Draw                   `json:",omitempty"`
+	Digest   StateDigest              `json:",omitempty"` // state after a tick
+	Player   string                   `json:",omitempty"` // ID of the acting player
+	Name     string                   `json:",omitempty"` // name of a joining player
+	Account  string                   `json:",omitempty"`
+	Planet   string                   `json:",omitempty"`
+	Resource ResourceType             `json:",omitempty"`
+	Amount   int                      `json:",omitempty"`
+	Building BuildingType             `json:",omitempty"`
+	Config   *Config                  `json:",omitempty"` // reloaded config
+	Events   []ScheduledEventSnapshot `json:",omitempty"`
+}
+
+// Draw is a random number drawn by the game. N is the bound of an integer draw, 0 for floats.
+type Draw struct {
+	N int     `json:"n,omitempty"`
+	V float64 `json:"v"`
+}
+
+// recordingRandom passes random numbers of another Random through and keeps them until they're taken.
+// Ranges are drawn as integers, with the same result as BuiltInRand and SeededRand.
+type recordingRandom struct {
+	Random
+	draws []Draw
+}
+
+func (r *recordingRandom) Seek() float64 {
+	v := r.Random.Seek()
+	r.draws = append(r.draws, Draw{V: v})
+	return v
+}
+
+func (r *recordingRandom) Of(n int) int {
+	v := r.Random.Of(n)
+	r.draws = append(r.draws, Draw{N: n, V: float64(v)})
+	return v
+}
+
+func (r *recordingRandom) OfRange(min, max int) int {
+	return ofRange(r, min, max)
+}
+
+func (r *recordingRandom) OfIntRange(rng intRange) int {
+	return ofRange(r, rng.Min, rng.Max)
+}
+
+// take returns all draws since the last call.
+func (r *recordingRandom) take() []Draw {
+	draws := r.draws
+	r.draws = nil
+	return draws
+}
+
+func ofRange(r Random, min, max int) int {
+	if max <= min {
+		return min
+	}
+	return r.Of(max-min) + min
+}
+
+// StateDigest contains a hash of each planet, NPC, faction and player, and of all events, keyed by
+// entity type and name, e.g. planet:Vega-B. Digests of two games differ where their states differ.
+type StateDigest map[string]uint64
+
+// DigestSnapshot returns the digest of the state of passed snapshot.
+func DigestSnapshot(s Snapshot) StateDigest {
+	digest := StateDigest{}
+	for _, p := range s.Planets {
+		digest.add("planet:"+p.Name, p)
+	}
+	for _, n := range s.NPCs {
+		digest.add("npc:"+n.Name, n)
+	}
+	for _, f := range s.Factions {
+		digest.add("faction:"+f.Name, f)
+	}
+	for _, pl := range s.Players {
+		digest.add("player:"+pl.Name, pl)
+	}
+	digest.add("events", []any{s.ActiveEvents, s.ScheduledEvents})
+	return digest
+}
+
+func (d StateDigest) add(key string, v any) {
+	data, _ := json.Marshal(v)
+	hash := fnv.New64a()
+	hash.Write(data)
+	d[key] = hash.Sum64()
+}
+
+// Diff returns the sorted keys of all entities which differ from, or are missing in, the other digest.
+func (d StateDigest) Diff(other StateDigest) []string {
+	diffs := []string{}
+	for key, hash := range d {
+		if otherHash, ok := other[key]; !ok || otherHash != hash {
+			diffs = append(diffs, key)
+		}
+	}
+	for key := range other {
+		if _, ok := d[key]; !ok {
+			diffs = append(diffs, key)
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+// Journal appends all state changes of a game, with the random numbers drawn by them, to segment files.
+// Each segment starts with a snapshot, so the game can be replayed from the start of any segment kept.
+type Journal struct {
+	config       JournalConfig
+	random       *recordingRandom
+	file         *os.File
+	writer       *bufio.Writer
+	segmentStart uint64
+	failed       bool
+	log          Log
+}
+
+// StartJournal records all further state changes of the game into a new journal in the configured directory.
+func (g *Game) StartJournal(config JournalConfig) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.journal != nil {
+		return fmt.Errorf("%w: game is already journaled to %s", ErrJournalExists, g.journal.config.Dir)
+	}
+	if config.SegmentTicks <= 0 {
+		return fmt.Errorf("journal: segment_ticks has to be positive")
+	}
+	if err := os.MkdirAll(config.Dir, 0o700); err != nil {
+		return err
+	}
+	segments, err := journalFiles(config.Dir, segmentPattern)
+	if err != nil {
+		return err
+	}
+	if len(segments) > 0 {
+		return fmt.Errorf("%w: %s", ErrJournalExists, config.Dir)
+	}
+	journal := &Journal{config: config, random: &recordingRandom{Random: g.random}, log: g.log}
+	if err := journal.rotate(g.snapshot()); err != nil {
+		return err
+	}
+	g.random = journal.random
+	g.journal = journal
+	g.log.Info("Journal of the game is written to %s.", config.Dir)
+	return nil
+}
+
+// StopJournal writes all pending entries and closes the journal.
+func (g *Game) StopJournal() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.journal == nil {
+		return nil
+	}
+	g.random = g.journal.random.Random
+	err := g.journal.close()
+	g.journal = nil
+	return err
+}
+
+// journalAction records an action applied between two ticks, together with the random numbers it drew.
+// The caller has to hold the game's lock.
+func (g *Game) journalAction(entry JournalEntry) {
+	if g.journal == nil {
+		return
+	}
+	entry.Tick = g.metrics.Ticks
+	if entry.Time.IsZero() {
+		entry.Time = g.clock.Now()
+	}
+	entry.Draws = g.journal.random.take()
+	g.journal.write(entry)
+}
+
+// discardDraws drops the random numbers drawn by a failed action, which didn't change the state of the game.
+func (g *Game) discardDraws() {
+	if g.journal != nil {
+		g.journal.random.take()
+	}
+}
+
+// journalTick records a completed tick which started at passed time, and starts a new segment if the current one is full.
+// The caller has to hold the game's lock.
+func (g *Game) journalTick(now time.Time) {
+	if g.journal == nil {
+		return
+	}
+	snapshot := g.snapshot()
+	g.journal.write(JournalEntry{
+		Type:   TickEntry,
+		Tick:   g.metrics.Ticks,
+		Time:   now,
+		Draws:  g.journal.random.take(),
+		Digest: DigestSnapshot(snapshot),
+	})
+	g.journal.flush()
+	if g.metrics.Ticks-g.journal.segmentStart >= uint64(g.journal.config.SegmentTicks) {
+		if err := g.journal.rotate(snapshot); err != nil {
+			g.journal.fail(err)
+		}
+	}
+}
+
+func (j *Journal) write(entry JournalEntry) {
+	if j.failed {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err == nil {
+		_, err = j.writer.Write(append(data, '\n'))
+	}
+	if err != nil {
+		j.fail(err)
+	}
+}
+
+func (j *Journal) flush() {
+	if j.failed {
+		return
+	}
+	if err := j.writer.Flush(); err != nil {
+		j.fail(err)
+	}
+}
+
+// fail stops writing the journal, it can't be replayed beyond a missing entry.
+func (j *Journal) fail(err error) {
+	j.failed = true
+	j.log.Error("Unable to write journal, no further entries are recorded: %v", err)
+}
+
+// rotate closes the current segment and starts a new one with passed snapshot. Old segments beyond
+// the configured maximum are removed.
+func (j *Journal) rotate(snapshot Snapshot) error {
+	if err := j.close(); err != nil {
+		return err
+	}
+	if err := WriteSnapshotFile(snapshotFile(j.config.Dir, snapshot.Tick), snapshot); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(segmentFile(j.config.Dir, snapshot.Tick), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	j.file, j.writer, j.segmentStart = file, bufio.NewWriter(file), snapshot.Tick
+	return j.prune()
+}
+
+func (j *Journal) close() error {
+	if j.file == nil {
+		return nil
+	}
+	err := errors.Join(j.writer.Flush(), j.file.Close())
+	j.file, j.writer = nil, nil
+	return err
+}
+
+// prune removes the oldest segments and their snapshots, keeping the configured number of segments.
+func (j *Journal) prune() error {
+	if j.config.MaxSegments <= 0 {
+		return nil
+	}
+	segments, err := journalFiles(j.config.Dir, segmentPattern)
+	if err != nil || len(segments) <= j.config.MaxSegments {
+		return err
+	}
+	for _, tick := range segments[:len(segments)-j.config.MaxSegments] {
+		if err := os.Remove(segmentFile(j.config.Dir, tick)); err != nil {
+			return err
+		}
+		if err := os.Remove(snapshotFile(j.config.Dir, tick)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// File names of journal segments and snapshots, numbered by the tick they start at.
+const (
+	segmentPattern  = "segment-%010d.jsonl"
+	snapshotPattern = "snapshot-%010d.json"
+)
+
+func segmentFile(dir string, tick uint64) string {
+	return filepath.Join(dir, fmt.Sprintf(segmentPattern, tick))
+}
+
+func snapshotFile(dir string, tick uint64) string {
+	return filepath.Join(dir, fmt.Sprintf(snapshotPattern, tick))
+}
+
+// journalFiles returns the sorted ticks of all files in passed directory matching passed pattern.
+func journalFiles(dir, pattern string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	ticks := []uint64{}
+	for _, entry := range entries {
+		var tick uint64
+		if _, err := fmt.Sscanf(entry.Name(), pattern, &tick); err == nil && entry.Name() == fmt.Sprintf(pattern, tick) {
+			ticks = append(ticks, tick)
+		}
+	}
+	slices.Sort(ticks)
+	return ticks, nil
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JournalSuite struct {
+	suite.Suite
+	dir        string
+	simulation *Simulation
+}
+
+func TestJournalSuite(t *testing.T) {
+	suite.Run(t, new(JournalSuite))
+}
+
+func (s *JournalSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.simulation = NewSimulation(DefaultConfig(), 42, &nopLog{})
+}
+
+// journalRun runs a journaled simulation with a player trading and building between ticks.
+func (s *JournalSuite) journalRun(config JournalConfig, ticks int) {
+	game := s.simulation.Game
+	s.Require().NoError(game.StartJournal(config))
+	player, err := game.JoinUniverse("Ripley", "")
+	s.Require().NoError(err)
+
+	planet := unownedPlanets(game.Planets)[0]
+	for i := 0; i < ticks; i++ {
+		switch i {
+		case 1:
+			_, err = game.PlayerColonize(player.ID, planet.Name, Mine)
+			s.Require().NoError(err)
+		case 2:
+			_, err = game.PlayerBuild(player.ID, planet.Name, City)
+			s.Error(err)
+			_, err = game.PlayerBuild(player.ID, planet.Name, Mine)
+			s.Require().NoError(err)
+		case 3:
+			_, err = game.PlayerCollect(player.ID, planet.Name, Iron, 1)
+			s.Require().NoError(err)
+		}
+		s.simulation.Run(1, nil)
+	}
+	s.Require().NoError(game.StopJournal())
+}
+
+// replayAll steps through the whole journal and returns all steps.
+func (s *JournalSuite) replayAll(from uint64) []ReplayStep {
+	replay, err := OpenReplay(s.dir, from, &nopLog{})
+	s.Require().NoError(err)
+	defer replay.Close()
+	steps := []ReplayStep{}
+	for {
+		step, err := replay.Step()
+		if errors.Is(err, io.EOF) {
+			return steps
+		}
+		s.Require().NoError(err)
+		steps = append(steps, step)
+	}
+}
+
+func (s *JournalSuite) TestSnapshotRoundTrip() {
+	s.simulation.Run(20, nil)
+	snapshot := s.simulation.Game.Snapshot()
+
+	game, err := RestoreGame(snapshot, &mockRand{}, &nopLog{})
+	s.Require().NoError(err)
+	s.Empty(DigestSnapshot(snapshot).Diff(DigestSnapshot(game.Snapshot())))
+	s.Equal(snapshot.Tick, game.Status().Metrics.Ticks)
+}
+
+func (s *JournalSuite) TestReplayMatchesJournaledRun() {
+	s.journalRun(JournalConfig{Dir: s.dir, SegmentTicks: 5}, 12)
+
+	steps := s.replayAll(0)
+	s.Require().Len(steps, 12)
+	for i, step := range steps {
+		s.Equal(uint64(i+1), step.Tick)
+		s.False(step.Diverged(), "tick %d diverged: %v", step.Tick, step.Diffs)
+	}
+	s.Equal(JoinEntry, steps[0].Entries[0].Type)
+	s.Equal(ColonizeEntry, steps[1].Entries[0].Type)
+	s.Len(steps[2].Entries, 2, "failed build isn't journaled")
+}
+
+func (s *JournalSuite) TestOnlyAppliedConfigChangesAreJournaled() {
+	game := s.simulation.Game
+	s.Require().NoError(game.StartJournal(JournalConfig{Dir: s.dir, SegmentTicks: 5}))
+	_, err := game.ReloadConfig(DefaultConfig())
+	s.Require().NoError(err)
+	next := DefaultConfig()
+	next.Taxes.ProductionTax = 2
+	next.SeedConfig.NumberOfPlanets = intRange{Min: 1, Max: 2}
+	_, err = game.ReloadConfig(next)
+	s.Require().NoError(err)
+	s.simulation.Run(1, nil)
+	s.Require().NoError(game.StopJournal())
+
+	steps := s.replayAll(0)
+	s.Require().Len(steps, 1)
+	s.False(steps[0].Diverged())
+	s.Require().Len(steps[0].Entries, 2, "reload without changes isn't journaled")
+	s.Equal(ConfigEntry, steps[0].Entries[0].Type)
+	config := steps[0].Entries[0].Config
+	s.Equal(2.0, config.Taxes.ProductionTax)
+	s.Equal(DefaultSeedConfig().NumberOfPlanets, config.SeedConfig.NumberOfPlanets)
+}
+
+func (s *JournalSuite) TestReplayFromLaterSnapshot() {
+	s.journalRun(JournalConfig{Dir: s.dir, SegmentTicks: 5}, 12)
+
+	steps := s.replayAll(7)
+	s.Require().Len(steps, 7)
+	s.Equal(uint64(6), steps[0].Tick)
+	for _, step := range steps {
+		s.False(step.Diverged(), "tick %d diverged: %v", step.Tick, step.Diffs)
+	}
+
+	_, err := OpenReplay(s.T().TempDir(), 0, &nopLog{})
+	s.ErrorIs(err, ErrNoSnapshot)
+}
+
+func (s *JournalSuite) TestSegmentRotation() {
+	s.journalRun(JournalConfig{Dir: s.dir, SegmentTicks: 5, MaxSegments: 2}, 12)
+
+	segments, err := journalFiles(s.dir, segmentPattern)
+	s.Require().NoError(err)
+	s.Equal([]uint64{5, 10}, segments)
+	snapshots, err := journalFiles(s.dir, snapshotPattern)
+	s.Require().NoError(err)
+	s.Equal([]uint64{5, 10}, snapshots)
+
+	s.ErrorIs(NewSimulation(DefaultConfig(), 42, &nopLog{}).Game.StartJournal(JournalConfig{Dir: s.dir, SegmentTicks: 5}), ErrJournalExists)
+}
+
+func (s *JournalSuite) TestDetectsDivergence() {
+	s.journalRun(JournalConfig{Dir: s.dir, SegmentTicks: 100}, 6)
+
+	// a tampered draw changes the outcome of the tick it belongs to
+	file := segmentFile(s.dir, 0)
+	content, err := os.ReadFile(file)
+	s.Require().NoError(err)
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		if strings.Contains(line, `"Tick":4,`) && strings.Contains(line, `"Type":"tick"`) {
+			lines[i] = strings.Replace(line, `"Draws":[`, `"Draws":[{"v":0.999},`, 1)
+		}
+	}
+	s.Require().NoError(os.WriteFile(file, []byte(strings.Join(lines, "\n")), 0o600))
+
+	steps := s.replayAll(0)
+	s.Require().Len(steps, 6)
+	s.False(steps[2].Diverged())
+	s.True(steps[3].Diverged())
+	s.Contains(steps[3].Diffs[0], "tick draws")
+}
+
+func (s *JournalSuite) TestJournalFiles() {
+	for _, name := range []string{"segment-0000000010.jsonl", "segment-0000000002.jsonl", "segment-x.jsonl", "snapshot-0000000002.json"} {
+		s.Require().NoError(os.WriteFile(filepath.Join(s.dir, name), nil, 0o600))
+	}
+	segments, err := journalFiles(s.dir, segmentPattern)
+	s.Require().NoError(err)
+	s.Equal([]uint64{2, 10}, segments)
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// LedgerEntryType defines what has been recorded in the trade ledger.
+type LedgerEntryType int
+
+const (
+	PurchaseEntry   LedgerEntryType = iota // resources bought from a planet
+	SaleEntry                              // resources sold to a planet
+	ExchangeEntry                          // trade with a planet via ExecuteTrade
+	PiracyLossEntry                        // resources taken by pirates on the way to a planet
+	EscortFeeEntry                         // credits paid for an escort on the way to a planet
+)
+
+var ledgerEntryTypes = []LedgerEntryType{PurchaseEntry, SaleEntry, ExchangeEntry, PiracyLossEntry, EscortFeeEntry}
+
+func (t LedgerEntryType) String() string {
+	switch t {
+	case PurchaseEntry:
+		return "Purchase"
+	case SaleEntry:
+		return "Sale"
+	case ExchangeEntry:
+		return "Exchange"
+	case PiracyLossEntry:
+		return "PiracyLoss"
+	case EscortFeeEntry:
+		return "EscortFee"
+	default:
+		return "Unknown"
+	}
+}
+
+// LedgerEntry records resources and credits of an NPC changing hands in a trade or on the way to it.
+// Entries without resources, like escort fees, have an amount of zero.
+type LedgerEntry struct {
+	TradeAction
+	Type    LedgerEntryType
+	Credits int // paid for purchases and escorts, received for sales, value of lost cargo, balance of exchanges
+	Time    time.Time
+}
+
+// TradeLedger keeps the latest entries, up to its size, and totals of all entries ever recorded.
+// It's safe for concurrent use.
+type TradeLedger struct {
+	mu      sync.Mutex
+	size    int
+	entries []LedgerEntry
+	counts  map[LedgerEntryType]int
+	credits map[LedgerEntryType]int
+}
+
+// NewTradeLedger returns an empty ledger keeping passed number of latest entries.
+func NewTradeLedger(size int) *TradeLedger {
+	return &TradeLedger{
+		size:    size,
+		entries: make([]LedgerEntry, 0, size),
+		counts:  make(map[LedgerEntryType]int),
+		credits: make(map[LedgerEntryType]int),
+	}
+}
+
+// Record adds an entry to the ledger, dropping the oldest one if the ledger is full.
+// Recording into a nil ledger does nothing.
+func (l *TradeLedger) Record(entry LedgerEntry) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.counts[entry.Type]++
+	l.credits[entry.Type] += entry.Credits
+	if l.size <= 0 {
+		return
+	}
+	if len(l.entries) == l.size {
+		copy(l.entries, l.entries[1:])
+		l.entries = l.entries[:l.size-1]
+	}
+	l.entries = append(l.entries, entry)
+}
+
+// Entries returns a copy of all kept entries, oldest first.
+func (l *TradeLedger) Entries() []LedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]LedgerEntry{}, l.entries...)
+}
+
+// Count returns the number of all entries of given type ever recorded.
+func (l *TradeLedger) Count(t LedgerEntryType) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.counts[t]
+}
+
+// Credits returns the sum of credits of all entries of given type ever recorded.
+func (l *TradeLedger) Credits(t LedgerEntryType) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.credits[t]
+}
+
+// recordTrade adds an executed trade action to the ledger, based on the NPC's credits and cargo of
+// the traded resource before the action. Other actions aren't recorded.
+func recordTrade(ledger *TradeLedger, npc *NPC, action NPCAction, credits, cargo int, now time.Time) {
+	entry := LedgerEntry{
+		TradeAction: TradeAction{NPCName: npc.Name, PlanetName: action.Planet.Name, Resource: action.Resource},
+		Time:        now,
+	}
+	switch action.Type {
+	case BuyAction:
+		entry.Type, entry.Amount, entry.Credits = PurchaseEntry, npc.Cargo[action.Resource]-cargo, credits-npc.Credits
+	case SellAction:
+		entry.Type, entry.Amount, entry.Credits = SaleEntry, cargo-npc.Cargo[action.Resource], npc.Credits-credits
+	case ExchangeAction:
+		entry.Type, entry.Resource, entry.Credits = ExchangeEntry, ResourceType(-1), npc.Credits-credits
+	default:
+		return
+	}
+	ledger.Record(entry)
+}
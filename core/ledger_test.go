@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerSuite struct {
+	suite.Suite
+	npc    *NPC
+	planet *Planet
+	now    time.Time
+}
+
+func TestLedgerSuite(t *testing.T) {
+	suite.Run(t, new(LedgerSuite))
+}
+
+func (s *LedgerSuite) SetupTest() {
+	s.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.npc = &NPC{Name: "Trader", Credits: 100, Cargo: map[ResourceType]int{Iron: 20}}
+	s.planet = &Planet{Name: "Mars"}
+}
+
+func (s *LedgerSuite) TestKeepsLatestEntries() {
+	ledger := NewTradeLedger(2)
+	for credits := 1; credits <= 3; credits++ {
+		ledger.Record(LedgerEntry{Type: SaleEntry, Credits: credits})
+	}
+	ledger.Record(LedgerEntry{Type: EscortFeeEntry, Credits: 7})
+
+	entries := ledger.Entries()
+	s.Len(entries, 2)
+	s.Equal(3, entries[0].Credits)
+	s.Equal(EscortFeeEntry, entries[1].Type)
+	s.Equal(3, ledger.Count(SaleEntry))
+	s.Equal(6, ledger.Credits(SaleEntry))
+
+	empty := NewTradeLedger(0)
+	empty.Record(LedgerEntry{Type: SaleEntry, Credits: 5})
+	s.Empty(empty.Entries())
+	s.Equal(1, empty.Count(SaleEntry))
+
+	var none *TradeLedger
+	none.Record(LedgerEntry{Type: SaleEntry})
+}
+
+func (s *LedgerSuite) TestRecordTrade() {
+	ledger := NewTradeLedger(10)
+
+	// the NPC sold 15 units of iron for 60 credits and gained 40 credits in an exchange
+	s.npc.Cargo[Iron], s.npc.Credits = 5, 160
+	recordTrade(ledger, s.npc, NPCAction{Type: SellAction, Planet: s.planet, Resource: Iron, Amount: 15}, 100, 20, s.now)
+	s.npc.Credits = 200
+	recordTrade(ledger, s.npc, NPCAction{Type: ExchangeAction, Planet: s.planet}, 160, 5, s.now)
+	recordTrade(ledger, s.npc, NPCAction{Type: CollectAction, Planet: s.planet, Resource: Iron, Amount: 5}, 200, 0, s.now)
+
+	s.Equal([]LedgerEntry{
+		{TradeAction: TradeAction{NPCName: "Trader", PlanetName: "Mars", Resource: Iron, Amount: 15}, Type: SaleEntry, Credits: 60, Time: s.now},
+		{TradeAction: TradeAction{NPCName: "Trader", PlanetName: "Mars", Resource: ResourceType(-1)}, Type: ExchangeEntry, Credits: 40, Time: s.now},
+	}, ledger.Entries())
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// LifecycleEventType defines how an NPC enters or leaves the universe.
+type LifecycleEventType int
+
+const (
+	NPCArrival    LifecycleEventType = iota // a new NPC arrived
+	NPCRetirement                           // an NPC retired
+	NPCBankruptcy                           // an NPC stayed insolvent for too long
+)
+
+func (t LifecycleEventType) String() string {
+	switch t {
+	case NPCArrival:
+		return "Arrival"
+	case NPCRetirement:
+		return "Retirement"
+	case NPCBankruptcy:
+		return "Bankruptcy"
+	default:
+		return "Unknown"
+	}
+}
+
+// LifecycleEvent is an NPC entering or leaving the universe, with all planets it had to release.
+type LifecycleEvent struct {
+	Type            LifecycleEventType
+	NPC             *NPC
+	ReleasedPlanets []*Planet
+}
+
+// UpdateLifecycle applies lifecycle rules to all NPCs and returns the NPCs left in the universe and
+// what happened. NPCs go bankrupt if they've been insolvent for configured number of ticks,
+// otherwise they retire by chance. Leaving NPCs release their planets, treasuries are kept for
+// the next owner. A new NPC arrives by chance, as long as the maximum number of NPCs isn't reached.
+func UpdateLifecycle(npcs []*NPC, planets []*Planet, config LifecycleConfig, seedConfig SeedConfig, now time.Time, rand Random, log Log) ([]*NPC, []LifecycleEvent) {
+
+	remaining := make([]*NPC, 0, len(npcs))
+	events := []LifecycleEvent{}
+	for _, npc := range npcs {
+		if npc.Credits < config.InsolvencyThreshold {
+			npc.InsolventTicks++
+		} else {
+			npc.InsolventTicks = 0
+		}
+
+		switch {
+		case config.BankruptcyTicks > 0 && npc.InsolventTicks >= config.BankruptcyTicks:
+			events = append(events, LifecycleEvent{Type: NPCBankruptcy, NPC: npc, ReleasedPlanets: releasePlanets(npc, planets)})
+			log.Info("NPC %s went bankrupt after %d insolvent ticks.", npc.Name, npc.InsolventTicks)
+		case config.RetirementChance > 0 && rand.Seek() < config.RetirementChance:
+			events = append(events, LifecycleEvent{Type: NPCRetirement, NPC: npc, ReleasedPlanets: releasePlanets(npc, planets)})
+			log.Info("NPC %s retired with %d credits.", npc.Name, npc.Credits)
+		default:
+			remaining = append(remaining, npc)
+		}
+	}
+
+	if config.ArrivalChance > 0 && len(remaining) < config.MaxNPCs && rand.Seek() < config.ArrivalChance {
+		npc := NewNPC(uniqueNPCName(remaining), seedConfig, now, rand)
+		remaining = append(remaining, npc)
+		events = append(events, LifecycleEvent{Type: NPCArrival, NPC: npc})
+		log.Info("NPC %s arrived with %d credits.", npc.Name, npc.Credits)
+	}
+	return remaining, events
+}
+
+// releasePlanets removes passed NPC as owner and its garrisons from all its planets and returns them.
+func releasePlanets(npc *NPC, planets []*Planet) []*Planet {
+	released := []*Planet{}
+	for _, p := range planets {
+		if p.Owner == npc {
+			p.Owner = nil
+			p.Garrison = 0
+			released = append(released, p)
+		}
+	}
+	return released
+}
+
+// uniqueNPCName returns the first generated NPC name not used by passed NPCs.
+func uniqueNPCName(npcs []*NPC) string {
+	used := make(map[string]bool)
+	for _, npc := range npcs {
+		used[npc.Name] = true
+	}
+	for idx := len(npcs); ; idx++ {
+		name := GenerateNPCName(idx)
+		if idx >= 130 {
+			// generated names repeat after 130 NPCs
+			name = fmt.Sprintf("%s-%d", name, idx)
+		}
+		if !used[name] {
+			return name
+		}
+	}
+}
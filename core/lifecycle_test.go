@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LifecycleSuite struct {
+	suite.Suite
+	npc       *NPC
+	planet    *Planet
+	config    LifecycleConfig
+	seed      SeedConfig
+	now       time.Time
+	log       *mockLog
+	neverRand *mockRand
+}
+
+func TestLifecycleSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleSuite))
+}
+
+func (s *LifecycleSuite) SetupTest() {
+	s.log = &mockLog{}
+	s.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.seed = DefaultSeedConfig()
+	s.config = LifecycleConfig{
+		InsolvencyThreshold: 10,
+		BankruptcyTicks:     2,
+		RetirementChance:    0.1,
+		ArrivalChance:       0.1,
+		MaxNPCs:             2,
+	}
+	s.npc = &NPC{Name: GenerateNPCName(0), Credits: 5}
+	s.planet = &Planet{Name: "Colony", Owner: s.npc, Treasury: 20}
+	s.neverRand = &mockRand{seekVal: 0.5}
+}
+
+func (s *LifecycleSuite) TestBankruptcy() {
+	npcs, events := UpdateLifecycle([]*NPC{s.npc}, []*Planet{s.planet}, s.config, s.seed, s.now, s.neverRand, s.log)
+	s.Equal([]*NPC{s.npc}, npcs)
+	s.Empty(events)
+	s.Equal(1, s.npc.InsolventTicks)
+
+	npcs, events = UpdateLifecycle(npcs, []*Planet{s.planet}, s.config, s.seed, s.now, s.neverRand, s.log)
+	s.Empty(npcs)
+	s.Equal([]LifecycleEvent{{Type: NPCBankruptcy, NPC: s.npc, ReleasedPlanets: []*Planet{s.planet}}}, events)
+	s.Nil(s.planet.Owner)
+	s.Equal(20, s.planet.Treasury)
+}
+
+func (s *LifecycleSuite) TestSolventNPCRecovers() {
+	s.npc.InsolventTicks = 1
+	s.npc.Credits = 10
+	npcs, events := UpdateLifecycle([]*NPC{s.npc}, []*Planet{s.planet}, s.config, s.seed, s.now, s.neverRand, s.log)
+	s.Len(npcs, 1)
+	s.Empty(events)
+	s.Equal(0, s.npc.InsolventTicks)
+
+	s.config.BankruptcyTicks = 0
+	s.npc.Credits = 0
+	s.npc.InsolventTicks = 1000
+	npcs, _ = UpdateLifecycle([]*NPC{s.npc}, []*Planet{s.planet}, s.config, s.seed, s.now, s.neverRand, s.log)
+	s.Len(npcs, 1)
+}
+
+func (s *LifecycleSuite) TestRetirementAndArrival() {
+	s.npc.Credits = 100
+	rand := &mockRand{seekVal: 0.05}
+	npcs, events := UpdateLifecycle([]*NPC{s.npc}, []*Planet{s.planet}, s.config, s.seed, s.now, rand, s.log)
+	s.Len(events, 2)
+	s.Equal(NPCRetirement, events[0].Type)
+	s.Equal([]*Planet{s.planet}, events[0].ReleasedPlanets)
+	s.Equal(NPCArrival, events[1].Type)
+	s.Empty(events[1].ReleasedPlanets)
+	s.Equal([]*NPC{events[1].NPC}, npcs)
+	s.Equal(GenerateNPCName(0), npcs[0].Name)
+	s.Nil(s.planet.Owner)
+
+	// one arrival per tick, none beyond max NPCs
+	s.config.RetirementChance = 0
+	npcs, _ = UpdateLifecycle(npcs, nil, s.config, s.seed, s.now, rand, s.log)
+	s.Len(npcs, 2)
+	s.NotEqual(npcs[0].Name, npcs[1].Name)
+	npcs, events = UpdateLifecycle(npcs, nil, s.config, s.seed, s.now, rand, s.log)
+	s.Len(npcs, 2)
+	s.Empty(events)
+}
+
+func (s *LifecycleSuite) TestUniqueNPCName() {
+	s.Equal(GenerateNPCName(1), uniqueNPCName([]*NPC{s.npc}))
+	s.Equal(GenerateNPCName(2), uniqueNPCName([]*NPC{{Name: GenerateNPCName(1)}}))
+}
+
+func (s *LifecycleSuite) TestLifecycleEventsAreStreamed() {
+	cfg := DefaultConfig()
+	cfg.Lifecycle = s.config
+	s.planet.Treasury = 0
+	game := NewGameService(cfg, s.neverRand, s.log, []*Planet{s.planet}, []*NPC{s.npc})
+	updates, unsubscribe := game.subscribeUpdates()
+	defer unsubscribe()
+	game.tick(time.Second)
+	game.tick(time.Second)
+	s.Empty(game.NPCs)
+	s.Require().Len(updates, 2)
+	s.Empty((<-updates).lifecycle)
+	events := (<-updates).lifecycle
+	s.Require().Len(events, 1)
+	s.Equal(NPCBankruptcy.String(), events[0].Type)
+}
@@ -0,0 +1,113 @@
+package core
+
+import "math"
+
+// ReferenceStock is the stock of a resource on a planet at which it's traded at an NPC's offer price.
+const ReferenceStock = 1000
+
+// minTradeStock is the stock of a resource a planet keeps, NPCs can't buy below it.
+const minTradeStock = 5
+
+// MarketPrice returns the price per unit of a resource on a planet, based on the offer of an NPC.
+// Scarce resources are more expensive, up to twice the offer, plentiful ones cheaper, down to half of it.
+func MarketPrice(p *Planet, res ResourceType, offer int) int {
+	stock := max(1, p.Resources[res])
+	factor := math.Max(0.5, math.Min(2.0, float64(ReferenceStock)/float64(stock)))
+	return max(1, int(math.Round(float64(offer)*factor)))
+}
+
+// Tariff returns the tariff in percent an NPC pays on trades with a planet, set by the planet's owner.
+// Owners and members of their faction trade for free, allies get a discount, unowned planets don't charge tariffs.
+func Tariff(npc *NPC, p *Planet) int {
+	if p.Owner == nil || p.Owner == npc {
+		return 0
+	}
+	if npc.Faction != nil && npc.Faction == p.Owner.Faction {
+		return 0
+	}
+	if npc.Faction.Allied(p.Owner.Faction) {
+		return p.Owner.Tariff * (100 - AllianceTariffDiscount) / 100
+	}
+	return p.Owner.Tariff
+}
+
+// BuyPrice returns the price per unit an NPC pays for a resource on a planet, market price plus tariff.
+func BuyPrice(npc *NPC, p *Planet, res ResourceType) int {
+	price := MarketPrice(p, res, npc.Offer[res])
+	return price + duty(price, Tariff(npc, p))
+}
+
+// SellPrice returns the price per unit an NPC gets for a resource on a planet, market price minus tariff.
+func SellPrice(npc *NPC, p *Planet, res ResourceType) int {
+	price := MarketPrice(p, res, npc.Offer[res])
+	return price - duty(price, Tariff(npc, p))
+}
+
+// duty returns the part of passed value paid as tariff.
+func duty(value, tariff int) int {
+	return value * tariff / 100
+}
+
+// buyableStock returns the amount of a resource an NPC can buy on a planet at once,
+// a tenth of its stock, so traders don't strip planets.
+func buyableStock(p *Planet, res ResourceType) int {
+	if p.Resources[res] <= minTradeStock {
+		return 0
+	}
+	return max(1, p.Resources[res]/10)
+}
+
+// BuyResources buys up to passed amount of a resource from a planet at its market price,
+// limited by stock, free cargo and credits. Tariffs are paid into the planet's treasury.
+// Returns the amount bought.
+func BuyResources(npc *NPC, p *Planet, res ResourceType, amount int, log Log) int {
+	price := BuyPrice(npc, p, res)
+	tariff := price - MarketPrice(p, res, npc.Offer[res])
+	amount = min(amount, min(buyableStock(p, res), npc.MaxCargo-cargoLoad(npc)))
+	amount = min(amount, npc.Credits/price)
+	if amount <= 0 {
+		log.Debug("NPC %s: Unable to buy %v from planet %s.", npc.Name, res, p.Name)
+		return 0
+	}
+	p.Resources[res] -= amount
+	npc.Cargo[res] += amount
+	npc.Credits -= price * amount
+	p.Treasury += tariff * amount
+	Emit(log, TradeExecuted{Trader: npc.Name, Type: PurchaseEntry, Planet: p.Name, Resource: res, Amount: amount, Price: price, Tariff: tariff})
+	return amount
+}
+
+// SellResources sells up to passed amount of a resource from cargo to a planet at its market price.
+// Tariffs are paid into the planet's treasury. Returns the amount sold.
+func SellResources(npc *NPC, p *Planet, res ResourceType, amount int, log Log) int {
+	price := SellPrice(npc, p, res)
+	tariff := MarketPrice(p, res, npc.Offer[res]) - price
+	amount = min(amount, npc.Cargo[res])
+	if amount <= 0 {
+		log.Debug("NPC %s: No %v to sell to planet %s.", npc.Name, res, p.Name)
+		return 0
+	}
+	p.Resources[res] += amount
+	npc.Cargo[res] -= amount
+	npc.Credits += price * amount
+	p.Treasury += tariff * amount
+	Emit(log, TradeExecuted{Trader: npc.Name, Type: SaleEntry, Planet: p.Name, Resource: res, Amount: amount, Price: price, Tariff: tariff})
+	return amount
+}
+
+// CollectResources moves up to passed amount of a resource from an owned planet into cargo, for free.
+// Returns the amount collected.
+func CollectResources(npc *NPC, p *Planet, res ResourceType, amount int, log Log) int {
+	if p.Owner != npc {
+		log.Debug("NPC %s: Can't collect from planet %s, it's not owned.", npc.Name, p.Name)
+		return 0
+	}
+	amount = min(amount, min(p.Resources[res], npc.MaxCargo-cargoLoad(npc)))
+	if amount <= 0 {
+		return 0
+	}
+	p.Resources[res] -= amount
+	npc.Cargo[res] += amount
+	log.Info("NPC %s collected %d units of %v from planet %s.", npc.Name, amount, res, p.Name)
+	return amount
+}
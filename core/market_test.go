@@ -0,0 +1,95 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MarketSuite struct {
+	suite.Suite
+	npc    *NPC
+	planet *Planet
+	log    *mockLog
+}
+
+func TestMarketSuite(t *testing.T) {
+	suite.Run(t, new(MarketSuite))
+}
+
+func (s *MarketSuite) SetupTest() {
+	s.log = &mockLog{}
+	s.npc = &NPC{
+		Name:     "Tester",
+		Offer:    map[ResourceType]int{Iron: 10, Food: 10, Fuel: 10},
+		Credits:  100,
+		Cargo:    map[ResourceType]int{Iron: 0, Food: 0, Fuel: 0},
+		MaxCargo: 20,
+	}
+	s.planet = &Planet{
+		Name:      "Market",
+		Resources: map[ResourceType]int{Iron: 1000, Food: 200, Fuel: 3},
+	}
+}
+
+func (s *MarketSuite) TestMarketPrice() {
+	s.Equal(10, MarketPrice(s.planet, Iron, 10))
+	s.Equal(20, MarketPrice(s.planet, Food, 10))
+	s.Equal(20, MarketPrice(s.planet, Fuel, 10))
+	s.planet.Resources[Iron] = 4000
+	s.Equal(5, MarketPrice(s.planet, Iron, 10))
+	s.Equal(1, MarketPrice(s.planet, Iron, 1))
+}
+
+func (s *MarketSuite) TestBuyResources() {
+	// limited by credits
+	s.Equal(10, BuyResources(s.npc, s.planet, Iron, 50, s.log))
+	s.Equal(0, s.npc.Credits)
+	s.Equal(990, s.planet.Resources[Iron])
+
+	// limited by cargo
+	s.npc.Credits = 1000
+	s.Equal(10, BuyResources(s.npc, s.planet, Iron, 50, s.log))
+	s.Equal(0, BuyResources(s.npc, s.planet, Iron, 50, s.log))
+
+	// planets keep a minimum stock
+	s.npc.Cargo[Iron] = 0
+	s.Equal(0, BuyResources(s.npc, s.planet, Fuel, 1, s.log))
+}
+
+func (s *MarketSuite) TestSellResources() {
+	s.npc.Cargo[Food] = 5
+	s.Equal(5, SellResources(s.npc, s.planet, Food, 10, s.log))
+	s.Equal(200, s.npc.Credits)
+	s.Equal(205, s.planet.Resources[Food])
+	s.Equal(0, SellResources(s.npc, s.planet, Food, 10, s.log))
+}
+
+func (s *MarketSuite) TestTariffs() {
+	owner := &NPC{Name: "Owner", Tariff: 20}
+	s.Equal(0, Tariff(s.npc, s.planet))
+	s.planet.Owner = owner
+	s.Equal(20, Tariff(s.npc, s.planet))
+	s.Equal(0, Tariff(owner, s.planet))
+	s.Equal(12, BuyPrice(s.npc, s.planet, Iron))
+	s.Equal(8, SellPrice(s.npc, s.planet, Iron))
+
+	s.Equal(8, BuyResources(s.npc, s.planet, Iron, 50, s.log))
+	s.Equal(100-8*12, s.npc.Credits)
+	s.Equal(8*2, s.planet.Treasury)
+
+	s.Equal(8, SellResources(s.npc, s.planet, Iron, 8, s.log))
+	s.Equal(4+8*8, s.npc.Credits)
+	s.Equal(16+8*2, s.planet.Treasury)
+}
+
+func (s *MarketSuite) TestCollectResources() {
+	s.Equal(0, CollectResources(s.npc, s.planet, Iron, 10, s.log))
+	s.NotEmpty(s.log.debugs)
+	s.Empty(s.log.errors)
+
+	s.planet.Owner = s.npc
+	s.Equal(20, CollectResources(s.npc, s.planet, Iron, 50, s.log))
+	s.Equal(980, s.planet.Resources[Iron])
+	s.Equal(100, s.npc.Credits)
+}
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// activityCounters counts actions of a game which aren't recorded elsewhere, e.g. in the trade ledger.
+type activityCounters struct {
+	colonizations  map[string]uint64          // by colonist, npc or player
+	playerTrades   map[LedgerEntryType]uint64 // purchases and sales of players
+	droppedUpdates map[string]uint64          // updates skipped because the queue of a stream was full, by kind
+}
+
+func newActivityCounters() activityCounters {
+	return activityCounters{
+		colonizations:  make(map[string]uint64),
+		playerTrades:   make(map[LedgerEntryType]uint64),
+		droppedUpdates: make(map[string]uint64),
+	}
+}
+
+var (
+	ticksDesc          = prometheus.NewDesc("utte_ticks_total", "Game ticks executed.", nil, nil)
+	ticksBehindDesc    = prometheus.NewDesc("utte_ticks_behind", "Tick slots the game loop was behind schedule after the last tick.", nil, nil)
+	skippedTicksDesc   = prometheus.NewDesc("utte_skipped_ticks_total", "Tick slots dropped by the catch-up policy.", nil, nil)
+	tickOverrunsDesc   = prometheus.NewDesc("utte_tick_overruns_total", "Ticks which took longer than the tick interval.", nil, nil)
+	planetsDesc        = prometheus.NewDesc("utte_planets", "Planets in the universe, by owner.", []string{"owner"}, nil)
+	npcsDesc           = prometheus.NewDesc("utte_npcs", "NPCs in the universe.", nil, nil)
+	playersDesc        = prometheus.NewDesc("utte_players", "Players who joined the universe.", nil, nil)
+	activeEventsDesc   = prometheus.NewDesc("utte_active_events", "Events currently active.", nil, nil)
+	resourcesDesc      = prometheus.NewDesc("utte_resources", "Resources in stock on all planets, by type.", []string{"resource"}, nil)
+	tradesDesc         = prometheus.NewDesc("utte_trades_total", "Trades executed, by trader and type.", []string{"trader", "type"}, nil)
+	colonizationsDesc  = prometheus.NewDesc("utte_colonizations_total", "Planets colonized, by colonist.", []string{"colonist"}, nil)
+	droppedUpdatesDesc = prometheus.NewDesc("utte_dropped_updates_total", "Updates dropped because the queue of a stream was full, by kind.", []string{"kind"}, nil)
+)
+
+// Metrics exposes the state of a game and the requests of its gRPC server to Prometheus. State is
+// read from the game on each scrape, while tick durations, streams and requests are observed as they happen.
+type Metrics struct {
+	game            *Game
+	tickDuration    prometheus.Histogram
+	subscribers     prometheus.Gauge
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+}
+
+// NewMetrics creates metrics of passed game and lets the game observe its ticks and streams with them.
+// Metrics have to be registered at a Prometheus registry to be exposed.
+func NewMetrics(game *Game) *Metrics {
+	m := &Metrics{
+		game: game,
+		tickDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "utte_tick_duration_seconds",
+			Help:    "Duration of game ticks.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}),
+		subscribers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "utte_stream_subscribers",
+			Help: "Open universe state streams.",
+		}),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "utte_grpc_requests_total",
+			Help: "gRPC requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "utte_grpc_request_duration_seconds",
+			Help:    "Duration of gRPC requests, by method. Streams last until they're closed.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+	game.mu.Lock()
+	defer game.mu.Unlock()
+	game.exporter = m
+	return m
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{ticksDesc, ticksBehindDesc, skippedTicksDesc, tickOverrunsDesc,
+		planetsDesc, npcsDesc, playersDesc, activeEventsDesc, resourcesDesc, tradesDesc, colonizationsDesc, droppedUpdatesDesc} {
+		ch <- desc
+	}
+	m.tickDuration.Describe(ch)
+	m.subscribers.Describe(ch)
+	m.requests.Describe(ch)
+	m.requestDuration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.collectGame(ch)
+	m.tickDuration.Collect(ch)
+	m.subscribers.Collect(ch)
+	m.requests.Collect(ch)
+	m.requestDuration.Collect(ch)
+}
+
+// collectGame reads the current state of the game.
+func (m *Metrics) collectGame(ch chan<- prometheus.Metric) {
+	g := m.game
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(ticksDesc, prometheus.CounterValue, float64(g.metrics.Ticks))
+	ch <- prometheus.MustNewConstMetric(ticksBehindDesc, prometheus.GaugeValue, float64(g.metrics.TicksBehind))
+	ch <- prometheus.MustNewConstMetric(skippedTicksDesc, prometheus.CounterValue, float64(g.metrics.SkippedTicks))
+	ch <- prometheus.MustNewConstMetric(tickOverrunsDesc, prometheus.CounterValue, float64(g.metrics.Overruns))
+
+	owners := map[string]int{"npc": 0, "player": 0, "none": 0}
+	resources := make(map[ResourceType]int)
+	for _, p := range g.Planets {
+		switch {
+		case p.Owner != nil:
+			owners["npc"]++
+		case p.Player != nil:
+			owners["player"]++
+		default:
+			owners["none"]++
+		}
+		for _, res := range resourceTypes {
+			resources[res] += p.Resources[res]
+		}
+	}
+	for owner, count := range owners {
+		ch <- prometheus.MustNewConstMetric(planetsDesc, prometheus.GaugeValue, float64(count), owner)
+	}
+	for _, res := range resourceTypes {
+		ch <- prometheus.MustNewConstMetric(resourcesDesc, prometheus.GaugeValue, float64(resources[res]), res.String())
+	}
+	ch <- prometheus.MustNewConstMetric(npcsDesc, prometheus.GaugeValue, float64(len(g.NPCs)))
+	ch <- prometheus.MustNewConstMetric(playersDesc, prometheus.GaugeValue, float64(len(g.Players)))
+	ch <- prometheus.MustNewConstMetric(activeEventsDesc, prometheus.GaugeValue, float64(len(g.ActiveEvents)))
+
+	for _, t := range []LedgerEntryType{PurchaseEntry, SaleEntry, ExchangeEntry} {
+		ch <- prometheus.MustNewConstMetric(tradesDesc, prometheus.CounterValue, float64(g.Ledger.Count(t)), "npc", strings.ToLower(t.String()))
+	}
+	for _, t := range []LedgerEntryType{PurchaseEntry, SaleEntry} {
+		ch <- prometheus.MustNewConstMetric(tradesDesc, prometheus.CounterValue, float64(g.activity.playerTrades[t]), "player", strings.ToLower(t.String()))
+	}
+	for _, colonist := range []string{"npc", "player"} {
+		ch <- prometheus.MustNewConstMetric(colonizationsDesc, prometheus.CounterValue, float64(g.activity.colonizations[colonist]), colonist)
+	}
+	for _, kind := range updateKinds {
+		ch <- prometheus.MustNewConstMetric(droppedUpdatesDesc, prometheus.CounterValue, float64(g.activity.droppedUpdates[kind]), kind)
+	}
+}
+
+// observeTick records the duration of a tick. Observing without metrics does nothing.
+func (m *Metrics) observeTick(duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.tickDuration.Observe(duration.Seconds())
+}
+
+// streamOpened counts an open stream until the returned function is called.
+func (m *Metrics) streamOpened() func() {
+	if m == nil {
+		return func() {}
+	}
+	m.subscribers.Inc()
+	return m.subscribers.Dec
+}
+
+// observeRequest records a handled gRPC request.
+func (m *Metrics) observeRequest(method string, start time.Time, err error) {
+	m.requests.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// MetricsServerOptions returns gRPC server options recording requests with passed metrics,
+// none without metrics. Requests are recorded before authentication, so rejected ones are counted too.
+func MetricsServerOptions(m *Metrics) []grpc.ServerOption {
+	if m == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			start := time.Now()
+			resp, err := handler(ctx, req)
+			m.observeRequest(info.FullMethod, start, err)
+			return resp, err
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+			err := handler(srv, ss)
+			m.observeRequest(info.FullMethod, start, err)
+			return err
+		}),
+	}
+}
+
+// streamOpened counts an open stream of the game until the returned function is called.
+func (g *Game) streamOpened() func() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.exporter.streamOpened()
+}
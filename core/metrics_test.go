@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+	pb "github.com/tommzn/utte-universe/core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+	game     *Game
+	metrics  *Metrics
+	registry *prometheus.Registry
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
+
+func (s *MetricsSuite) SetupTest() {
+	planets := []*Planet{
+		{Name: "Mars", Type: Desert, Resources: map[ResourceType]int{Iron: 1000, Food: 20}, Modifiers: map[ResourceType]float64{}},
+		{Name: "Venus", Type: Icy, Resources: map[ResourceType]int{Iron: 5}, Modifiers: map[ResourceType]float64{}},
+	}
+	s.game = NewGameService(DefaultConfig(), &mockRand{}, &nopLog{}, planets, []*NPC{})
+	s.metrics = NewMetrics(s.game)
+	s.registry = prometheus.NewRegistry()
+	s.Require().NoError(s.registry.Register(s.metrics))
+}
+
+// value returns the value of a gathered metric with passed labels, or -1 if there's no such metric.
+func (s *MetricsSuite) value(name string, labels map[string]string) float64 {
+	families, err := s.registry.Gather()
+	s.Require().NoError(err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			switch {
+			case metric.Counter != nil:
+				return metric.GetCounter().GetValue()
+			case metric.Gauge != nil:
+				return metric.GetGauge().GetValue()
+			case metric.Histogram != nil:
+				return float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return -1
+}
+
+func (s *MetricsSuite) TestGameState() {
+	player, err := s.game.JoinUniverse("Alice", "")
+	s.Require().NoError(err)
+	_, err = s.game.PlayerBuy(player.ID, "Mars", Iron, 10)
+	s.Require().NoError(err)
+	_, err = s.game.PlayerColonize(player.ID, "Venus", Mine)
+	s.Require().NoError(err)
+
+	s.Equal(995.0, s.value("utte_resources", map[string]string{"resource": "Iron"}))
+	s.Equal(20.0, s.value("utte_resources", map[string]string{"resource": "Food"}))
+	s.Equal(1.0, s.value("utte_planets", map[string]string{"owner": "player"}))
+	s.Equal(1.0, s.value("utte_planets", map[string]string{"owner": "none"}))
+	s.Equal(1.0, s.value("utte_players", nil))
+	s.Equal(0.0, s.value("utte_npcs", nil))
+	s.Equal(1.0, s.value("utte_trades_total", map[string]string{"trader": "player", "type": "purchase"}))
+	s.Equal(0.0, s.value("utte_trades_total", map[string]string{"trader": "npc", "type": "purchase"}))
+	s.Equal(1.0, s.value("utte_colonizations_total", map[string]string{"colonist": "player"}))
+}
+
+func (s *MetricsSuite) TestTicks() {
+	// update queues of streams keep 10 updates, later ones are dropped while the stream doesn't read them
+	_, unsubscribe := s.game.subscribeUpdates()
+	defer unsubscribe()
+	for range 11 {
+		s.game.tick(time.Second)
+	}
+	s.Equal(11.0, s.value("utte_ticks_total", nil))
+	s.Equal(11.0, s.value("utte_tick_duration_seconds", nil))
+	s.Equal(0.0, s.value("utte_ticks_behind", nil))
+	s.Equal(1.0, s.value("utte_dropped_updates_total", map[string]string{"kind": "state"}))
+	s.Equal(0.0, s.value("utte_dropped_updates_total", map[string]string{"kind": "config"}))
+}
+
+func (s *MetricsSuite) TestStreamSubscribers() {
+	closeFirst := s.game.streamOpened()
+	closeSecond := s.game.streamOpened()
+	s.Equal(2.0, s.value("utte_stream_subscribers", nil))
+	closeFirst()
+	s.Equal(1.0, s.value("utte_stream_subscribers", nil))
+	closeSecond()
+	s.Equal(0.0, s.value("utte_stream_subscribers", nil))
+
+	// games without metrics don't count streams
+	NewGameService(DefaultConfig(), &mockRand{}, &nopLog{}, []*Planet{}, []*NPC{}).streamOpened()()
+}
+
+func (s *MetricsSuite) TestGRPCRequests() {
+	server, lis, err := NewGRPCServer(s.game, "127.0.0.1:0", nil, &nopLog{}, MetricsServerOptions(s.metrics)...)
+	s.Require().NoError(err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	defer conn.Close()
+	client := pb.NewUniverseServiceClient(conn)
+	_, err = client.GetGameStatus(context.Background(), &pb.Empty{})
+	s.NoError(err)
+	_, err = client.GetPlayer(context.Background(), &pb.Empty{})
+	s.Error(err)
+
+	s.Equal(1.0, s.value("utte_grpc_requests_total", map[string]string{"method": pb.UniverseService_GetGameStatus_FullMethodName, "code": "OK"}))
+	s.Equal(1.0, s.value("utte_grpc_requests_total", map[string]string{"method": pb.UniverseService_GetPlayer_FullMethodName, "code": "Unauthenticated"}))
+	s.Equal(1.0, s.value("utte_grpc_request_duration_seconds", map[string]string{"method": pb.UniverseService_GetGameStatus_FullMethodName}))
+	s.Nil(MetricsServerOptions(nil))
+}
@@ -0,0 +1,412 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+)
+
+// MonteCarloConfig defines how many seeded simulations are run for a balance report.
+type MonteCarloConfig struct {
+	Runs        int     // number of simulations, each with its own seed
+	Ticks       int     // ticks per simulation
+	Seed        int64   // seed of the first run, following runs use Seed+1, Seed+2, ...
+	Workers     int     // number of simulations running in parallel
+	Samples     int     // number of checkpoints for colonization over time
+	GrowthLimit float64 // final/initial resource total ratio which is flagged as runaway growth
+}
+
+func DefaultMonteCarloConfig() MonteCarloConfig {
+	return MonteCarloConfig{
+		Runs:        100,
+		Ticks:       1000,
+		Seed:        1,
+		Workers:     4,
+		Samples:     10,
+		GrowthLimit: 100,
+	}
+}
+
+// Distribution summarizes a set of values.
+type Distribution struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+	P10  float64 `json:"p10"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+}
+
+// ColonizationSample is the share of colonized planets at a tick, averaged over all runs.
+type ColonizationSample struct {
+	Tick      int     `json:"tick"`
+	MeanShare float64 `json:"meanShare"`
+}
+
+// EventFrequency compares how often events have been triggered on planets of a type with
+// the chance defined by the event config.
+type EventFrequency struct {
+	Multiplier     float64 `json:"multiplier"`
+	Events         int     `json:"events"`
+	Selections     float64 `json:"selections"` // expected number of ticks a planet of this type was picked
+	ObservedChance float64 `json:"observedChance"`
+	ExpectedChance float64 `json:"expectedChance"`
+}
+
+// RunFlag marks a simulation run with suspicious results.
+type RunFlag struct {
+	Seed   int64  `json:"seed"`
+	Reason string `json:"reason"`
+}
+
+// BalanceReport aggregates results of many seeded simulations.
+type BalanceReport struct {
+	Runs            int                       `json:"runs"`
+	Ticks           int                       `json:"ticks"`
+	FinalResources  map[string]Distribution   `json:"finalResources"`
+	FinalNPCCredits Distribution              `json:"finalNpcCredits"`
+	NPCs            int                       `json:"npcs"`          // initial NPCs and arrivals
+	Lifecycle       map[string]int            `json:"lifecycle"`     // arrivals, retirements and bankruptcies
+	Battles         map[string]int            `json:"battles"`       // battles by outcome
+	PiracyLosses    int                       `json:"piracyLosses"`  // value of cargo lost to pirates
+	EscortFees      int                       `json:"escortFees"`    // credits paid for escorts
+	PricedOutRate   float64                   `json:"pricedOutRate"` // share of NPCs whose credits fell below their cheapest offer at least once
+	CargoFullRate   float64                   `json:"cargoFullRate"` // share of NPCs which filled their cargo at least once
+	Colonization    []ColonizationSample      `json:"colonization"`
+	EventFrequency  map[string]EventFrequency `json:"eventFrequency"`
+	Flags           []RunFlag                 `json:"flags"`
+}
+
+type runResult struct {
+	seed          int64
+	final         TickStats
+	npcs          int
+	pricedOutNPCs int
+	cargoFullNPCs int
+	lifecycle     map[LifecycleEventType]int
+	battles       map[BattleOutcome]int
+	piracyLosses  int
+	escortFees    int
+	colonization  []float64
+	eventsByType  map[PlanetType]int
+	selectByType  map[PlanetType]float64
+	flags         []RunFlag
+}
+
+// RunMonteCarlo runs the same config across many seeds in parallel and aggregates the results.
+// If config defines a scenario, each seed only changes its random fill and random decisions.
+// The passed log is shared by all simulations and has to be safe for concurrent use.
+func RunMonteCarlo(config Config, mc MonteCarloConfig, log Log) (BalanceReport, error) {
+
+	// fail early on invalid scenarios, instead of in every run
+	if _, err := NewSimulationFromConfig(config, mc.Seed, log); err != nil {
+		return BalanceReport{}, err
+	}
+
+	results := make([]runResult, mc.Runs)
+	errs := make([]error, mc.Runs)
+	runs := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < max(1, mc.Workers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range runs {
+				results[i], errs[i] = simulateRun(config, mc, mc.Seed+int64(i), log)
+			}
+		}()
+	}
+	for i := 0; i < mc.Runs; i++ {
+		runs <- i
+	}
+	close(runs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return BalanceReport{}, err
+		}
+	}
+	return aggregateRuns(mc, config.Events, results), nil
+}
+
+func simulateRun(config Config, mc MonteCarloConfig, seed int64, log Log) (runResult, error) {
+
+	simulation, err := NewSimulationFromConfig(config, seed, log)
+	if err != nil {
+		return runResult{}, fmt.Errorf("run with seed %d: %w", seed, err)
+	}
+	game := simulation.Game
+	initial := CollectStats(game)
+
+	result := runResult{
+		seed:         seed,
+		npcs:         len(game.NPCs),
+		lifecycle:    make(map[LifecycleEventType]int),
+		battles:      make(map[BattleOutcome]int),
+		colonization: make([]float64, 0, mc.Samples),
+		eventsByType: make(map[PlanetType]int),
+		selectByType: make(map[PlanetType]float64),
+	}
+	for _, p := range game.Planets {
+		// each tick MaybeTriggerEvent picks one planet uniformly at random
+		result.selectByType[p.Type] += float64(mc.Ticks) / float64(len(game.Planets))
+	}
+
+	pricedOut := make(map[*NPC]bool)
+	cargoFull := make(map[*NPC]bool)
+	overflow := false
+	sampleEvery := max(1, mc.Ticks/max(1, mc.Samples))
+
+	simulation.Run(mc.Ticks, func(stats TickStats) {
+		for _, e := range game.triggeredEvents {
+			if e.TargetPlanet != nil {
+				result.eventsByType[e.TargetPlanet.Type]++
+			}
+		}
+		for _, e := range game.lifecycleEvents {
+			result.lifecycle[e.Type]++
+			if e.Type == NPCArrival {
+				result.npcs++
+			}
+		}
+		for _, r := range game.battleReports {
+			result.battles[r.Outcome]++
+		}
+		for _, npc := range game.NPCs {
+			if npc.Credits < cheapestOffer(npc) {
+				pricedOut[npc] = true
+			}
+			if cargoLoad(npc) >= npc.MaxCargo {
+				cargoFull[npc] = true
+			}
+		}
+		if !overflow && hasOverflow(game) {
+			overflow = true
+			result.flags = append(result.flags, RunFlag{Seed: seed, Reason: fmt.Sprintf("int overflow at tick %d", stats.Tick)})
+		}
+		if int(stats.Tick)%sampleEvery == 0 && len(result.colonization) < mc.Samples {
+			result.colonization = append(result.colonization, ownedShare(stats))
+		}
+		result.final = stats
+	})
+
+	result.piracyLosses = game.Ledger.Credits(PiracyLossEntry)
+	result.escortFees = game.Ledger.Credits(EscortFeeEntry)
+	result.pricedOutNPCs = len(pricedOut)
+	result.cargoFullNPCs = len(cargoFull)
+	for _, res := range resourceTypes {
+		start := initial.Resources[res.String()]
+		end := result.final.Resources[res.String()]
+		if start > 0 && float64(end) > float64(start)*mc.GrowthLimit {
+			result.flags = append(result.flags, RunFlag{Seed: seed, Reason: fmt.Sprintf("runaway growth of %v: %d -> %d", res, start, end)})
+		}
+	}
+	return result, nil
+}
+
+func aggregateRuns(mc MonteCarloConfig, events EventConfig, results []runResult) BalanceReport {
+
+	report := BalanceReport{
+		Runs:           mc.Runs,
+		Ticks:          mc.Ticks,
+		FinalResources: make(map[string]Distribution),
+		Lifecycle:      make(map[string]int),
+		Battles:        make(map[string]int),
+		EventFrequency: make(map[string]EventFrequency),
+		Flags:          []RunFlag{},
+	}
+
+	resources := make(map[string][]float64)
+	credits := []float64{}
+	pricedOut, cargoFull := 0, 0
+	colonization := make([][]float64, mc.Samples)
+	triggered := make(map[PlanetType]int)
+	selections := make(map[PlanetType]float64)
+	for _, r := range results {
+		for _, res := range resourceTypes {
+			resources[res.String()] = append(resources[res.String()], float64(r.final.Resources[res.String()]))
+		}
+		credits = append(credits, float64(r.final.NPCCredits))
+		report.NPCs += r.npcs
+		pricedOut += r.pricedOutNPCs
+		cargoFull += r.cargoFullNPCs
+		for t, count := range r.lifecycle {
+			report.Lifecycle[t.String()] += count
+		}
+		for outcome, count := range r.battles {
+			report.Battles[outcome.String()] += count
+		}
+		report.PiracyLosses += r.piracyLosses
+		report.EscortFees += r.escortFees
+		for i, share := range r.colonization {
+			colonization[i] = append(colonization[i], share)
+		}
+		for pt, count := range r.eventsByType {
+			triggered[pt] += count
+		}
+		for pt, count := range r.selectByType {
+			selections[pt] += count
+		}
+		report.Flags = append(report.Flags, r.flags...)
+	}
+
+	for res, values := range resources {
+		report.FinalResources[res] = newDistribution(values)
+	}
+	report.FinalNPCCredits = newDistribution(credits)
+	if report.NPCs > 0 {
+		report.PricedOutRate = float64(pricedOut) / float64(report.NPCs)
+		report.CargoFullRate = float64(cargoFull) / float64(report.NPCs)
+	}
+
+	sampleEvery := max(1, mc.Ticks/max(1, mc.Samples))
+	for i, shares := range colonization {
+		if len(shares) == 0 {
+			continue
+		}
+		report.Colonization = append(report.Colonization, ColonizationSample{
+			Tick:      (i + 1) * sampleEvery,
+			MeanShare: newDistribution(shares).Mean,
+		})
+	}
+
+	for _, pt := range planetTypes {
+		if selections[pt] == 0 {
+			continue
+		}
+		report.EventFrequency[pt.String()] = EventFrequency{
+			Multiplier:     events.Multiplier(pt),
+			Events:         triggered[pt],
+			Selections:     selections[pt],
+			ObservedChance: float64(triggered[pt]) / selections[pt],
+			ExpectedChance: events.Chance(pt),
+		}
+	}
+	return report
+}
+
+// WriteBalanceReport writes a human readable summary of passed report.
+func WriteBalanceReport(w io.Writer, report BalanceReport) error {
+
+	lines := []string{
+		fmt.Sprintf("Balance report: %d runs, %d ticks each", report.Runs, report.Ticks),
+		"",
+		"Final resource totals:",
+	}
+	for _, res := range resourceTypes {
+		d := report.FinalResources[res.String()]
+		lines = append(lines, fmt.Sprintf("  %-5s min %.0f  p10 %.0f  p50 %.0f  p90 %.0f  max %.0f  mean %.1f", res, d.Min, d.P10, d.P50, d.P90, d.Max, d.Mean))
+	}
+	d := report.FinalNPCCredits
+	lines = append(lines,
+		fmt.Sprintf("Final NPC credits: min %.0f  p50 %.0f  max %.0f  mean %.1f", d.Min, d.P50, d.Max, d.Mean),
+		"",
+		fmt.Sprintf("NPCs: %d, priced out %.1f%%, cargo full %.1f%%", report.NPCs, report.PricedOutRate*100, report.CargoFullRate*100),
+		fmt.Sprintf("NPC lifecycle: %d arrivals, %d retirements, %d bankruptcies", report.Lifecycle[NPCArrival.String()],
+			report.Lifecycle[NPCRetirement.String()], report.Lifecycle[NPCBankruptcy.String()]),
+		fmt.Sprintf("Battles: %d captured, %d razed, %d repelled", report.Battles[BattleCaptured.String()],
+			report.Battles[BattleRazed.String()], report.Battles[BattleRepelled.String()]),
+		fmt.Sprintf("Piracy: %d credits of cargo lost, %d credits paid for escorts", report.PiracyLosses, report.EscortFees),
+		"",
+		"Colonized planets over time:",
+	)
+	for _, sample := range report.Colonization {
+		lines = append(lines, fmt.Sprintf("  tick %6d  %.1f%%", sample.Tick, sample.MeanShare*100))
+	}
+	lines = append(lines, "", "Event frequency per planet type:")
+	for _, pt := range planetTypes {
+		if f, ok := report.EventFrequency[pt.String()]; ok {
+			lines = append(lines, fmt.Sprintf("  %-10s x%.1f  events %6d  observed %.4f  expected %.4f", pt, f.Multiplier, f.Events, f.ObservedChance, f.ExpectedChance))
+		}
+	}
+	lines = append(lines, "", fmt.Sprintf("Flags: %d", len(report.Flags)))
+	for _, flag := range report.Flags {
+		lines = append(lines, fmt.Sprintf("  seed %d: %s", flag.Seed, flag.Reason))
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newDistribution(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	return Distribution{
+		Min:  sorted[0],
+		Max:  sorted[len(sorted)-1],
+		Mean: sum / float64(len(sorted)),
+		P10:  percentile(sorted, 0.1),
+		P50:  percentile(sorted, 0.5),
+		P90:  percentile(sorted, 0.9),
+	}
+}
+
+// percentile uses nearest rank on already sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(0, min(idx, len(sorted)-1))]
+}
+
+// hasOverflow detects values of single planets and NPCs which have wrapped around or don't fit into the
+// int32 fields they're streamed in. Resources, credits and treasuries can't become negative otherwise.
+func hasOverflow(g *Game) bool {
+	for _, p := range g.Planets {
+		if outOfInt32(p.Treasury) {
+			return true
+		}
+		for _, amount := range p.Resources {
+			if outOfInt32(amount) {
+				return true
+			}
+		}
+	}
+	for _, npc := range g.NPCs {
+		if outOfInt32(npc.Credits) {
+			return true
+		}
+		for _, amount := range npc.Cargo {
+			if outOfInt32(amount) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// outOfInt32 returns true if a value has wrapped around or doesn't fit into an int32 field of streamed updates.
+func outOfInt32(value int) bool {
+	return value < 0 || value > math.MaxInt32
+}
+
+func cheapestOffer(npc *NPC) int {
+	cheapest := math.MaxInt
+	for _, price := range npc.Offer {
+		cheapest = min(cheapest, price)
+	}
+	if cheapest == math.MaxInt {
+		return 0
+	}
+	return cheapest
+}
+
+func ownedShare(stats TickStats) float64 {
+	planets := stats.OwnedPlanets + stats.UnownedPlanets
+	if planets == 0 {
+		return 0
+	}
+	return float64(stats.OwnedPlanets) / float64(planets)
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MonteCarloSuite struct {
+	suite.Suite
+	config Config
+	mc     MonteCarloConfig
+}
+
+func TestMonteCarloSuite(t *testing.T) {
+	suite.Run(t, new(MonteCarloSuite))
+}
+
+func (s *MonteCarloSuite) SetupTest() {
+	s.config = DefaultConfig()
+	s.mc = MonteCarloConfig{
+		Runs:        8,
+		Ticks:       100,
+		Seed:        1,
+		Workers:     4,
+		Samples:     5,
+		GrowthLimit: 100,
+	}
+}
+
+func (s *MonteCarloSuite) TestRunMonteCarlo() {
+	report, err := RunMonteCarlo(s.config, s.mc, &nopLog{})
+	s.NoError(err)
+	s.Equal(8, report.Runs)
+	s.Equal(100, report.Ticks)
+	s.Len(report.FinalResources, len(resourceTypes))
+	for _, d := range report.FinalResources {
+		s.LessOrEqual(d.Min, d.P50)
+		s.LessOrEqual(d.P50, d.Max)
+	}
+	s.Greater(report.NPCs, 0)
+	s.Len(report.Colonization, 5)
+	s.Equal(20, report.Colonization[0].Tick)
+	s.Equal(100, report.Colonization[4].Tick)
+	for _, f := range report.EventFrequency {
+		s.Equal(BaseEventChance*f.Multiplier, f.ExpectedChance)
+	}
+}
+
+func (s *MonteCarloSuite) TestRunMonteCarloIsReproducible() {
+	report1, _ := RunMonteCarlo(s.config, s.mc, &nopLog{})
+	report2, _ := RunMonteCarlo(s.config, s.mc, &nopLog{})
+	s.Equal(report1, report2)
+}
+
+func (s *MonteCarloSuite) TestLifecycleIsReported() {
+	s.mc.Runs = 2
+	s.config.Lifecycle = LifecycleConfig{ArrivalChance: 1, MaxNPCs: 1000}
+	report, err := RunMonteCarlo(s.config, s.mc, &nopLog{})
+	s.NoError(err)
+	s.Equal(2*s.mc.Ticks, report.Lifecycle[NPCArrival.String()])
+	s.GreaterOrEqual(report.NPCs, 2*s.mc.Ticks)
+}
+
+func (s *MonteCarloSuite) TestPiracyIsReported() {
+	s.mc.Runs = 2
+	// every trip with cargo is intercepted, escorts are never worth their fee
+	s.config.Piracy.BaseRisk, s.config.Piracy.MaxRisk, s.config.Piracy.EscortFee = 1, 1, 1
+	report, err := RunMonteCarlo(s.config, s.mc, &nopLog{})
+	s.NoError(err)
+	s.Greater(report.PiracyLosses, 0)
+	s.Equal(0, report.EscortFees)
+}
+
+func (s *MonteCarloSuite) TestRunawayGrowthFlag() {
+	s.mc.Runs = 2
+	s.mc.GrowthLimit = 0.5
+	report, _ := RunMonteCarlo(s.config, s.mc, &nopLog{})
+	s.NotEmpty(report.Flags)
+	s.Contains(report.Flags[0].Reason, "runaway growth")
+}
+
+func (s *MonteCarloSuite) TestRunMonteCarloWithScenario() {
+	s.mc.Runs = 3
+	s.config.ScenarioFile = "fixtures/scenario.yml"
+	report, err := RunMonteCarlo(s.config, s.mc, &nopLog{})
+	s.NoError(err)
+	s.Equal(3, report.Runs)
+
+	s.config.ScenarioFile = "fixtures/missing.yml"
+	_, err = RunMonteCarlo(s.config, s.mc, &nopLog{})
+	s.Error(err)
+}
+
+func (s *MonteCarloSuite) TestSimulateRunReturnsError() {
+	s.config.ScenarioFile = "fixtures/missing.yml"
+	_, err := simulateRun(s.config, s.mc, 7, &nopLog{})
+	s.ErrorContains(err, "run with seed 7")
+}
+
+func (s *MonteCarloSuite) TestHasOverflow() {
+	planet := &Planet{Resources: map[ResourceType]int{Iron: 10}}
+	npc := &NPC{Cargo: map[ResourceType]int{Food: 5}}
+	game := &Game{Planets: []*Planet{planet}, NPCs: []*NPC{npc}}
+	s.False(hasOverflow(game))
+
+	// totals of large universes may exceed int32, single values may not
+	planet.Resources[Iron], npc.Credits = math.MaxInt32, math.MaxInt32
+	s.False(hasOverflow(game))
+
+	planet.Resources[Food] = math.MinInt
+	s.True(hasOverflow(game))
+	planet.Resources[Food] = 0
+	planet.Treasury = math.MaxInt32 + 1
+	s.True(hasOverflow(game))
+	planet.Treasury = 0
+	npc.Credits = -5
+	s.True(hasOverflow(game))
+	npc.Credits = 0
+	npc.Cargo[Food] = math.MaxInt32 + 1
+	s.True(hasOverflow(game))
+}
+
+func (s *MonteCarloSuite) TestNewDistribution() {
+	d := newDistribution([]float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10})
+	s.Equal(1.0, d.Min)
+	s.Equal(10.0, d.Max)
+	s.Equal(5.5, d.Mean)
+	s.Equal(1.0, d.P10)
+	s.Equal(5.0, d.P50)
+	s.Equal(9.0, d.P90)
+	s.Equal(Distribution{}, newDistribution([]float64{}))
+}
+
+func (s *MonteCarloSuite) TestCheapestOffer() {
+	s.Equal(3, cheapestOffer(&NPC{Offer: map[ResourceType]int{Iron: 5, Food: 3}}))
+	s.Equal(0, cheapestOffer(&NPC{}))
+}
+
+func (s *MonteCarloSuite) TestWriteBalanceReport() {
+	s.mc.Runs = 2
+	report, _ := RunMonteCarlo(s.config, s.mc, &nopLog{})
+	report.Flags = []RunFlag{{Seed: 3, Reason: "int overflow at tick 7"}}
+	buf := &bytes.Buffer{}
+	s.NoError(WriteBalanceReport(buf, report))
+	s.Contains(buf.String(), "Balance report: 2 runs, 100 ticks each")
+	s.Contains(buf.String(), "seed 3: int overflow at tick 7")
+}
@@ -27,17 +27,13 @@ func (n *NPC) UpdateTrade(planets []*Planet, rand Random, log Log) {
 
 func (n *NPC) tryBuy(p *Planet, resType ResourceType, rand Random, log Log) {
 
-	currentCargo := 0
-	for _, qty := range n.Cargo {
-		currentCargo += qty
-	}
-	if currentCargo >= n.MaxCargo {
-		log.Error("NPC %s: Cargo full, cannot buy.", n.Name)
+	if cargoLoad(n) >= n.MaxCargo {
+		log.Debug("NPC %s: Cargo full, cannot buy.", n.Name)
 		return
 	}
 
 	if p.Resources[resType] <= 5 {
-		log.Error("NPC %s: Not enough %v on planet %s to buy.", n.Name, resType, p.Name)
+		log.Debug("NPC %s: Not enough %v on planet %s to buy.", n.Name, resType, p.Name)
 		return
 	}
 
@@ -49,19 +45,19 @@ func (n *NPC) tryBuy(p *Planet, resType ResourceType, rand Random, log Log) {
 	price := n.Offer[resType] * amount
 
 	if n.Credits < price {
-		log.Error("NPC %s: Not enough credits to buy %d units of %v.", n.Name, amount, resType)
+		log.Debug("NPC %s: Not enough credits to buy %d units of %v.", n.Name, amount, resType)
 		return
 	}
 
 	p.Resources[resType] -= amount
 	n.Cargo[resType] += amount
 	n.Credits -= price
-	log.Info("NPC %s bought %d units of %v from planet %s.", n.Name, amount, resType, p.Name)
+	Emit(log, TradeExecuted{Trader: n.Name, Type: PurchaseEntry, Planet: p.Name, Resource: resType, Amount: amount, Price: n.Offer[resType]})
 }
 
 func (n *NPC) trySell(p *Planet, resType ResourceType, rand Random, log Log) {
 	if n.Cargo[resType] <= 0 {
-		log.Error("NPC %s: No %v to sell.", n.Name, resType)
+		log.Debug("NPC %s: No %v to sell.", n.Name, resType)
 		return
 	}
 
@@ -75,44 +71,124 @@ func (n *NPC) trySell(p *Planet, resType ResourceType, rand Random, log Log) {
 	p.Resources[resType] += amount
 	n.Cargo[resType] -= amount
 	n.Credits += price
-	log.Info("NPC %s sold %d units of %v to planet %s.", n.Name, amount, resType, p.Name)
+	Emit(log, TradeExecuted{Trader: n.Name, Type: SaleEntry, Planet: p.Name, Resource: resType, Amount: amount, Price: n.Offer[resType] + 2})
 }
 
-func RunNPCLogic(npc *NPC, planets []*Planet, rand Random, log Log) {
-	if time.Now().Before(npc.ColonizationCooldown) {
-		log.Debug("NPC %s: Colonization cooldown active.", npc.Name)
+// RunNPCLogic lets the strategy of an NPC decide on its actions for this tick and executes them.
+// NPCs without strategy use RandomStrategy.
+func RunNPCLogic(npc *NPC, planets []*Planet, config Config, ledger *TradeLedger, now time.Time, rand Random, log Log) {
+
+	strategy := npc.Strategy
+	if strategy == nil {
+		strategy = RandomStrategy{}
+	}
+	obs := Observation{NPC: npc, Planets: planets, Now: now, SeedConfig: config.SeedConfig, Conflict: config.Conflict}
+	actions := strategy.Decide(obs, rand)
+	if len(actions) == 0 {
+		log.Debug("NPC %s: No actions decided by %s strategy.", npc.Name, strategy.Name())
 		return
 	}
+	for _, action := range actions {
+		if travels(action) {
+			Travel(npc, action.Planet, config.Piracy, ledger, now, rand, log)
+		}
+		credits, cargo := npc.Credits, npc.Cargo[action.Resource]
+		if ExecuteNPCAction(npc, action, config.SeedConfig, config.Conflict, now, rand, log) {
+			recordTrade(ledger, npc, action, credits, cargo, now)
+		}
+	}
+}
 
-	candidatePlanets := []*Planet{}
-	for _, p := range planets {
-		if !IsPlanetColonized(p) {
-			candidatePlanets = append(candidatePlanets, p)
+// ExecuteNPCAction executes an action decided by an NPC strategy. Returns false if the action
+// couldn't be executed, e.g. because a planet has been colonized by another NPC in the meantime.
+func ExecuteNPCAction(npc *NPC, action NPCAction, seedConfig SeedConfig, conflict ConflictConfig, now time.Time, rand Random, log Log) bool {
+
+	p := action.Planet
+	if p == nil {
+		log.Error("NPC %s: %v action without planet.", npc.Name, action.Type)
+		return false
+	}
+	log.Debug("NPC %s: Executing %v action on planet %s.", npc.Name, action.Type, p.Name)
+
+	switch action.Type {
+	case BuyAction, SellAction, ExchangeAction:
+		if Embargoed(npc, p) {
+			log.Debug("NPC %s: Refuses to trade on planet %s of rival faction %s.", npc.Name, p.Name, p.Owner.Faction.Name)
+			return false
 		}
 	}
 
-	if len(candidatePlanets) > 0 && rand.Seek() < 0.05 {
-		planet := candidatePlanets[rand.Of(len(candidatePlanets))]
-		ColonizePlanet(npc, planet, rand, log)
-		npc.ColonizationCooldown = time.Now().Add(time.Duration(rand.Of(3600)+600) * time.Second)
-		log.Info("NPC %s colonized planet %s.", npc.Name, planet.Name)
-		return
+	if executed := executeNPCAction(npc, action, seedConfig, conflict, now, rand, log); executed {
+		updateRelations(npc, action, log)
+		return true
 	}
+	return false
+}
+
+// executeNPCAction executes an action without looking at faction relations.
+func executeNPCAction(npc *NPC, action NPCAction, seedConfig SeedConfig, conflict ConflictConfig, now time.Time, rand Random, log Log) bool {
 
-	if len(planets) > 0 && rand.Seek() < 0.3 {
-		p := planets[rand.Of(len(planets))]
-		log.Debug("NPC %s: Attempting trade with planet %s.", npc.Name, p.Name)
+	p := action.Planet
+	switch action.Type {
+	case BuyAction:
+		return BuyResources(npc, p, action.Resource, action.Amount, log) > 0
+	case SellAction:
+		return SellResources(npc, p, action.Resource, action.Amount, log) > 0
+	case CollectAction:
+		return CollectResources(npc, p, action.Resource, action.Amount, log) > 0
+	case ExchangeAction:
 		ExecuteTrade(npc, p, log)
+		return true
+	case ColonizeAction:
+		if IsPlanetColonized(p) {
+			log.Debug("NPC %s: Planet %s has already been colonized.", npc.Name, p.Name)
+			return false
+		}
+		colonize(npc, p, action.Building, log)
+		npc.ColonizationCooldown = now.Add(time.Duration(rand.Of(3600)+600) * time.Second)
+		return true
+	case BuildAction:
+		if p.Owner != npc || !BuildingAllowed(p.Type, action.Building) {
+			log.Debug("NPC %s: Can't build %v on planet %s.", npc.Name, action.Building, p.Name)
+			return false
+		}
+		building := NewBuilding(action.Building, seedConfig, rand)
+		log.Info("NPC %s decided to build %v on planet %s.", npc.Name, action.Building, p.Name)
+		return Invest(npc, p, building.BuildCost, log) && p.Build(building, log)
+	case UpgradeAction:
+		if p.Owner != npc || action.Target == nil {
+			log.Debug("NPC %s: Can't upgrade a building on planet %s.", npc.Name, p.Name)
+			return false
+		}
+		log.Info("NPC %s decided to upgrade %v on planet %s to level %d.", npc.Name, action.Target.Type, p.Name, action.Target.Level+1)
+		return Invest(npc, p, action.Target.UpgradeCost(), log) && action.Target.Upgrade(p, log)
+	case ArmAction:
+		return ArmFleet(npc, action.Amount, conflict, log) > 0
+	case AttackAction:
+		return DispatchFleet(npc, p, action.Amount, log)
+	default:
+		log.Error("NPC %s: Unknown action %v.", npc.Name, action.Type)
+		return false
 	}
 }
 
 func IsPlanetColonized(p *Planet) bool {
-	return p.Owner != nil
+	return p.Owner != nil || p.Player != nil
 }
+
 func ColonizePlanet(npc *NPC, p *Planet, rand Random, log Log) {
+	buildingType := Mine
+	if rand.Seek() < 0.7 {
+		buildingType = City
+	}
+	colonize(npc, p, buildingType, log)
+}
+
+// colonize takes over a planet and places a City or a Mine on it.
+func colonize(npc *NPC, p *Planet, buildingType BuildingType, log Log) {
 	p.Owner = npc
 
-	if rand.Seek() < 0.7 {
+	if buildingType == City {
 		city := &Building{
 			Type:       City,
 			Level:      1,
@@ -121,8 +197,8 @@ func ColonizePlanet(npc *NPC, p *Planet, rand Random, log Log) {
 			BuildCost:  map[ResourceType]int{Food: 10, Iron: 10, Fuel: 5},
 		}
 		p.Buildings = append(p.Buildings, city)
-		log.Info("NPC %s established a city on planet %s.", npc.Name, p.Name)
 	} else {
+		buildingType = Mine
 		mine := &Building{
 			Type:       Mine,
 			Level:      1,
@@ -131,9 +207,10 @@ func ColonizePlanet(npc *NPC, p *Planet, rand Random, log Log) {
 			BuildCost:  map[ResourceType]int{Food: 5, Iron: 15},
 		}
 		p.Buildings = append(p.Buildings, mine)
-		log.Info("NPC %s established a mine on planet %s.", npc.Name, p.Name)
 	}
+	Emit(log, PlanetColonized{Planet: p.Name, Colonist: npc.Name, Building: buildingType})
 }
+
 func ExecuteTrade(npc *NPC, p *Planet, log Log) {
 	if p.Owner == npc {
 		for res, offerAmount := range npc.Offer {
@@ -149,7 +226,9 @@ func ExecuteTrade(npc *NPC, p *Planet, log Log) {
 		return
 	}
 
-	for res, offerAmount := range npc.Offer {
+	// iterate in fixed order, so trades are reproducible if credits run out
+	for _, res := range resourceTypes {
+		offerAmount := npc.Offer[res]
 		planetAmount := p.Resources[res]
 		tradeAmount := min(planetAmount, offerAmount)
 		if tradeAmount <= 0 {
@@ -158,18 +237,28 @@ func ExecuteTrade(npc *NPC, p *Planet, log Log) {
 		p.Resources[res] -= tradeAmount
 		npc.Cargo[res] += tradeAmount
 		price := 1
-		totalCost := tradeAmount * price
+		tariff := duty(tradeAmount*price, Tariff(npc, p))
+		totalCost := tradeAmount*price + tariff
 
 		if npc.Credits >= totalCost {
 			npc.Credits -= totalCost
-			log.Info("NPC %s external trade: bought %d units of %v from planet %s.", npc.Name, tradeAmount, res, p.Name)
+			p.Treasury += tariff
+			Emit(log, TradeExecuted{Trader: npc.Name, Type: ExchangeEntry, Planet: p.Name, Resource: res, Amount: tradeAmount, Price: totalCost, Tariff: tariff})
 		} else {
-			log.Error("NPC %s: Not enough credits for external trade.", npc.Name)
+			log.Debug("NPC %s: Not enough credits for external trade.", npc.Name)
 		}
 	}
 }
 
 // ------------------- Utility -------------------
+func cargoLoad(npc *NPC) int {
+	load := 0
+	for _, qty := range npc.Cargo {
+		load += qty
+	}
+	return load
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
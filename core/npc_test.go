@@ -194,10 +194,23 @@ func (s *NPCSuite) TestExecuteTradeExternalAndInternal() {
 	s.Equal(0, p.Resources[Iron])
 }
 
+func (s *NPCSuite) TestExecuteTradePaysTariff() {
+	npc := &NPC{
+		Cargo:   map[ResourceType]int{Iron: 0},
+		Offer:   map[ResourceType]int{Iron: 10},
+		Credits: 100,
+	}
+	p := &Planet{Resources: map[ResourceType]int{Iron: 10}, Owner: &NPC{Name: "Owner", Tariff: 50}}
+	ExecuteTrade(npc, p, s.log)
+	s.Equal(10, npc.Cargo[Iron])
+	s.Equal(85, npc.Credits)
+	s.Equal(5, p.Treasury)
+}
+
 func (s *NPCSuite) TestRunNPCLogicCooldown() {
 	npc := &NPC{ColonizationCooldown: time.Now().Add(time.Hour)}
 	planets := []*Planet{{Buildings: []*Building{}}}
-	RunNPCLogic(npc, planets, &mockRand{seekVal: 0.5, ofVal: 1}, s.log)
+	RunNPCLogic(npc, planets, DefaultConfig(), nil, time.Now(), &mockRand{seekVal: 0.5, ofVal: 1}, s.log)
 	s.False(IsPlanetColonized(planets[0]))
 }
 
@@ -215,7 +228,7 @@ func (s *NPCSuite) TestRunNPCLogicTradeBranch() {
 			Buildings: []*Building{},
 		},
 	}
-	RunNPCLogic(npc, planets, &mockRand{seekVal: 0.2, ofVal: 0}, s.log)
+	RunNPCLogic(npc, planets, DefaultConfig(), nil, time.Now(), &mockRand{seekVal: 0.2, ofVal: 0}, s.log)
 }
 
 func (s *NPCSuite) TestRunNPCLogicColonizeBranch() {
@@ -232,7 +245,7 @@ func (s *NPCSuite) TestRunNPCLogicColonizeBranch() {
 			Buildings: []*Building{},
 		},
 	}
-	RunNPCLogic(npc, planets, &mockRand{seekVal: 0.01, ofVal: 0}, s.log)
+	RunNPCLogic(npc, planets, DefaultConfig(), nil, time.Now(), &mockRand{seekVal: 0.01, ofVal: 0}, s.log)
 	s.True(IsPlanetColonized(planets[0]))
 }
 
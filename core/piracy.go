@@ -0,0 +1,79 @@
+package core
+
+import (
+	"math"
+	"time"
+)
+
+// CargoValue returns the value of an NPC's cargo, at its own offers.
+func CargoValue(npc *NPC) int {
+	value := 0
+	for res, amount := range npc.Cargo {
+		value += amount * npc.Offer[res]
+	}
+	return value
+}
+
+// RouteRisk returns the chance of pirates intercepting a trader travelling between two planets with
+// cargo of passed value. The hazard of a route is the mean pirate activity around both planets, it's
+// the destination's alone for traders without location. The more valuable the cargo, the higher the risk.
+func RouteRisk(from, to *Planet, cargoValue int, config PiracyConfig) float64 {
+	hazard := config.Hazard(to.Type)
+	if from != nil {
+		hazard = (config.Hazard(from.Type) + hazard) / 2
+	}
+	risk := config.BaseRisk * hazard * (1 + float64(cargoValue)/float64(max(1, config.ValueScale)))
+	return math.Min(config.MaxRisk, risk)
+}
+
+// Travel moves an NPC to a planet. An NPC travelling with cargo from another planet may be intercepted
+// by pirates, who take a share of each resource. If the expected loss exceeds the fee, the NPC hires an
+// escort, which averts most of the risk. Fees and losses are recorded in the ledger.
+// Returns the resources lost to pirates.
+func Travel(npc *NPC, to *Planet, config PiracyConfig, ledger *TradeLedger, now time.Time, rand Random, log Log) map[ResourceType]int {
+
+	from := npc.Location
+	npc.Location = to
+	value := CargoValue(npc)
+	if from == to || value <= 0 {
+		return nil
+	}
+	risk := RouteRisk(from, to, value, config)
+	if risk <= 0 {
+		return nil
+	}
+
+	fee := int(math.Ceil(float64(value) * config.EscortFee))
+	if expectedLoss := risk * config.LossShare * float64(value); expectedLoss > float64(fee) && npc.Credits >= fee {
+		npc.Credits -= fee
+		risk *= 1 - config.EscortProtection
+		ledger.Record(LedgerEntry{
+			TradeAction: TradeAction{NPCName: npc.Name, PlanetName: to.Name, Resource: ResourceType(-1)},
+			Type:        EscortFeeEntry,
+			Credits:     fee,
+			Time:        now,
+		})
+		log.Debug("NPC %s hired an escort for %d credits to travel to planet %s.", npc.Name, fee, to.Name)
+	}
+	if rand.Seek() >= risk {
+		return nil
+	}
+
+	lost := make(map[ResourceType]int)
+	for _, res := range resourceTypes {
+		amount := int(float64(npc.Cargo[res]) * config.LossShare)
+		if amount <= 0 {
+			continue
+		}
+		npc.Cargo[res] -= amount
+		lost[res] = amount
+		ledger.Record(LedgerEntry{
+			TradeAction: TradeAction{NPCName: npc.Name, PlanetName: to.Name, Resource: res, Amount: amount},
+			Type:        PiracyLossEntry,
+			Credits:     amount * npc.Offer[res],
+			Time:        now,
+		})
+	}
+	log.Info("NPC %s was intercepted by pirates on the way to planet %s and lost %d units of cargo.", npc.Name, to.Name, sumAmounts(lost))
+	return lost
+}
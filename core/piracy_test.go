@@ -0,0 +1,94 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PiracySuite struct {
+	suite.Suite
+	npc    *NPC
+	desert *Planet
+	giant  *Planet
+	terra  *Planet
+	config PiracyConfig
+	ledger *TradeLedger
+	now    time.Time
+	log    *mockLog
+}
+
+func TestPiracySuite(t *testing.T) {
+	suite.Run(t, new(PiracySuite))
+}
+
+func (s *PiracySuite) SetupTest() {
+	s.log = &mockLog{}
+	s.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.config = DefaultPiracyConfig()
+	s.ledger = NewTradeLedger(10)
+	s.desert = &Planet{Name: "Dune", Type: Desert}
+	s.giant = &Planet{Name: "Jove", Type: GasGiant}
+	s.terra = &Planet{Name: "Eden", Type: TerraLike}
+	s.npc = &NPC{
+		Name:     "Trader",
+		Offer:    map[ResourceType]int{Iron: 10, Food: 5, Fuel: 5},
+		Credits:  100,
+		Cargo:    map[ResourceType]int{Iron: 100, Food: 0, Fuel: 0},
+		Location: s.desert,
+	}
+}
+
+func (s *PiracySuite) TestRouteRisk() {
+	s.Equal(1000, CargoValue(s.npc))
+	s.InDelta(0.01*1.75*1.2, RouteRisk(s.desert, s.giant, 1000, s.config), 1e-9)
+	s.InDelta(0.01*2.0, RouteRisk(nil, s.giant, 0, s.config), 1e-9)
+	s.Equal(s.config.MaxRisk, RouteRisk(s.desert, s.giant, 1000000, s.config))
+}
+
+func (s *PiracySuite) TestTravelWithoutRisk() {
+	s.Nil(Travel(s.npc, s.desert, s.config, s.ledger, s.now, &mockRand{}, s.log))
+
+	s.npc.Cargo[Iron] = 0
+	s.Nil(Travel(s.npc, s.giant, s.config, s.ledger, s.now, &mockRand{}, s.log))
+	s.Equal(s.giant, s.npc.Location)
+	s.Empty(s.ledger.Entries())
+}
+
+func (s *PiracySuite) TestInterception() {
+	s.npc.Location = s.terra
+	s.Nil(Travel(s.npc, s.terra, s.config, s.ledger, s.now, &mockRand{seekVal: 0.001}, s.log))
+
+	// a risk of 0.6% doesn't justify an escort fee of 10 credits
+	s.npc.Location = &Planet{Name: "Gaia", Type: TerraLike}
+	s.Nil(Travel(s.npc, s.terra, s.config, s.ledger, s.now, &mockRand{seekVal: 0.01}, s.log))
+	s.npc.Location = &Planet{Name: "Gaia", Type: TerraLike}
+	s.Equal(map[ResourceType]int{Iron: 50}, Travel(s.npc, s.terra, s.config, s.ledger, s.now, &mockRand{seekVal: 0.005}, s.log))
+	s.Equal(50, s.npc.Cargo[Iron])
+	s.Equal(100, s.npc.Credits)
+	s.Equal([]LedgerEntry{{
+		TradeAction: TradeAction{NPCName: "Trader", PlanetName: "Eden", Resource: Iron, Amount: 50},
+		Type:        PiracyLossEntry,
+		Credits:     500,
+		Time:        s.now,
+	}}, s.ledger.Entries())
+}
+
+func (s *PiracySuite) TestEscort() {
+	// a risk of 2.1% justifies an escort fee of 10 credits, it reduces the risk to 0.42%
+	s.Nil(Travel(s.npc, s.giant, s.config, s.ledger, s.now, &mockRand{seekVal: 0.005}, s.log))
+	s.Equal(90, s.npc.Credits)
+	s.Equal(100, s.npc.Cargo[Iron])
+	s.Equal(1, s.ledger.Count(EscortFeeEntry))
+	s.Equal(10, s.ledger.Credits(EscortFeeEntry))
+
+	s.npc.Location = s.desert
+	s.NotNil(Travel(s.npc, s.giant, s.config, s.ledger, s.now, &mockRand{seekVal: 0.004}, s.log))
+	s.Equal(50, s.npc.Cargo[Iron])
+
+	// without credits for the fee, there's no escort
+	s.npc.Credits, s.npc.Location = 0, s.desert
+	s.Nil(Travel(s.npc, s.giant, s.config, s.ledger, s.now, &mockRand{seekVal: 0.02}, s.log))
+	s.Equal(2, s.ledger.Count(EscortFeeEntry))
+}
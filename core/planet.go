@@ -2,33 +2,33 @@ package core
 
 func (p *Planet) CanBuild(b *Building, log Log) bool {
 	// Planet type restrictions
-	switch b.Type {
-	case Farm:
-		if p.Type != TerraLike && p.Type != Icy {
-			log.Error("Cannot build Farm on planet type %v (%s)", p.Type, p.Name)
-			return false
-		}
-	case Mine:
-		if p.Type == GasGiant {
-			log.Error("Cannot build Mine on GasGiant (%s)", p.Name)
-			return false
-		}
-	case Refinery:
-		// allowed everywhere
-	case City:
-		// allowed everywhere
+	if !BuildingAllowed(p.Type, b.Type) {
+		Emit(log, BuildFailed{Planet: p.Name, Building: b.Type, Level: 1, Reason: BuildingNotAllowed})
+		return false
 	}
 
 	// Check resource costs
 	for res, cost := range b.BuildCost {
 		if p.Resources[res] < cost {
-			log.Error("Insufficient %v to build %v on planet %s", res, b.Type, p.Name)
+			Emit(log, BuildFailed{Planet: p.Name, Building: b.Type, Level: 1, Reason: InsufficientResources, Resource: res})
 			return false
 		}
 	}
 	return true
 }
 
+// OwnerName returns the name of the NPC or player owning the planet, empty if it isn't owned.
+func (p *Planet) OwnerName() string {
+	switch {
+	case p.Owner != nil:
+		return p.Owner.Name
+	case p.Player != nil:
+		return p.Player.Name
+	default:
+		return ""
+	}
+}
+
 func (p *Planet) Build(b *Building, log Log) bool {
 	if !p.CanBuild(b, log) {
 		return false
@@ -38,6 +38,19 @@ func (p *Planet) Build(b *Building, log Log) bool {
 		log.Debug("Resource %v deducted by %d for building %v on planet %s", res, cost, b.Type, p.Name)
 	}
 	p.Buildings = append(p.Buildings, b)
-	log.Info("Built %v on planet %s", b.Type, p.Name)
+	Emit(log, BuildingBuilt{Planet: p.Name, Owner: p.OwnerName(), Building: b.Type, Level: b.Level})
 	return true
 }
+
+// BuildingAllowed returns true if buildings of given type can be built on planets of given type.
+// Farms need a TerraLike or Icy planet, mines can't be built on a GasGiant.
+func BuildingAllowed(pt PlanetType, bt BuildingType) bool {
+	switch bt {
+	case Farm:
+		return pt == TerraLike || pt == Icy
+	case Mine:
+		return pt != GasGiant
+	default:
+		return true
+	}
+}
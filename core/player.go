@@ -0,0 +1,330 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownPlayer       = errors.New("unknown player")
+	ErrInvalidPlayerName   = errors.New("player name can't be empty")
+	ErrPlayerNameTaken     = errors.New("player name is already taken")
+	ErrAlreadyJoined       = errors.New("account has already joined the universe")
+	ErrUniverseFull        = errors.New("maximum number of players reached")
+	ErrUnknownPlanet       = errors.New("unknown planet")
+	ErrUnknownResource     = errors.New("unknown resource")
+	ErrUnknownBuilding     = errors.New("unknown or unavailable building type")
+	ErrInvalidAmount       = errors.New("amount must be positive")
+	ErrNotOwner            = errors.New("planet isn't owned by the player")
+	ErrPlanetColonized     = errors.New("planet has already been colonized")
+	ErrBuildingNotAllowed  = errors.New("building isn't allowed on the planet")
+	ErrInsufficientCredits = errors.New("not enough credits")
+	ErrInsufficientStock   = errors.New("not enough resources")
+)
+
+// Planets returns all planets owned by a player.
+func (pl *Player) Planets(planets []*Planet) []*Planet {
+	owned := []*Planet{}
+	for _, p := range planets {
+		if p.Player == pl {
+			owned = append(owned, p)
+		}
+	}
+	return owned
+}
+
+// snapshot returns a copy of a player, safe to read while the game goes on.
+func (pl *Player) snapshot() Player {
+	player := *pl
+	player.Inventory = maps.Clone(pl.Inventory)
+	return player
+}
+
+// PlayerState is a copy of a player together with the names of all planets it owns.
+type PlayerState struct {
+	Player
+	Planets []string
+}
+
+// playerState returns a copy of passed player and its planets. It has to be called with the game locked.
+func (g *Game) playerState(player *Player) PlayerState {
+	state := PlayerState{Player: player.snapshot(), Planets: []string{}}
+	for _, p := range player.Planets(g.Planets) {
+		state.Planets = append(state.Planets, p.Name)
+	}
+	return state
+}
+
+// PlayerTariff returns the tariff in percent a player pays on trades with a planet, set by the NPC owning it.
+// Players don't charge tariffs on their own planets.
+func PlayerTariff(p *Planet) int {
+	if p.Owner == nil {
+		return 0
+	}
+	return p.Owner.Tariff
+}
+
+// JoinUniverse adds a new player with configured starting credits. Names are unique, ignoring case.
+// With authentication, each account joins once and the player is bound to it, otherwise the account
+// is empty. Returns the player, its ID is required for all further requests without authentication.
+func (g *Game) JoinUniverse(name, account string) (PlayerState, error) {
+	id, err := newPlayerID()
+	if err != nil {
+		return PlayerState{}, err
+	}
+	return g.joinUniverse(name, account, id)
+}
+
+// joinUniverse adds a new player with passed ID, replays reuse the ID recorded in the journal.
+func (g *Game) joinUniverse(name, account, id string) (PlayerState, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return PlayerState{}, ErrInvalidPlayerName
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.Players) >= g.config.Players.MaxPlayers {
+		return PlayerState{}, ErrUniverseFull
+	}
+	for _, pl := range g.Players {
+		if strings.EqualFold(pl.Name, name) {
+			return PlayerState{}, ErrPlayerNameTaken
+		}
+		if account != "" && pl.Account == account {
+			return PlayerState{}, ErrAlreadyJoined
+		}
+	}
+	player := &Player{
+		ID:        id,
+		Account:   account,
+		Name:      name,
+		Credits:   g.config.Players.StartingCredits,
+		Inventory: map[ResourceType]int{Iron: 0, Food: 0, Fuel: 0},
+		JoinedAt:  g.clock.Now(),
+	}
+	g.Players = append(g.Players, player)
+	g.journalAction(JournalEntry{Type: JoinEntry, Time: player.JoinedAt, Player: id, Name: name, Account: account})
+	g.log.Info("Player %s joined the universe with %d credits.", player.Name, player.Credits)
+	return g.playerState(player), nil
+}
+
+// FindPlayer returns the player with passed ID.
+func (g *Game) FindPlayer(id string) (PlayerState, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	player, err := g.player(id)
+	if err != nil {
+		return PlayerState{}, err
+	}
+	return g.playerState(player), nil
+}
+
+// FindPlayerByAccount returns the player bound to passed account.
+func (g *Game) FindPlayerByAccount(account string) (PlayerState, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, player := range g.Players {
+		if account != "" && player.Account == account {
+			return g.playerState(player), nil
+		}
+	}
+	return PlayerState{}, ErrUnknownPlayer
+}
+
+// PlayerBuy buys an amount of a resource from a planet at market price, plus the tariff of its owner,
+// which is paid into the planet's treasury. Players can't buy more than NPCs could at once.
+func (g *Game) PlayerBuy(id, planetName string, res ResourceType, amount int) (PlayerState, error) {
+	return g.playerAction(JournalEntry{Type: BuyEntry, Player: id, Planet: planetName, Resource: res, Amount: amount}, func(player *Player, p *Planet) error {
+		if err := validateTrade(res, amount); err != nil {
+			return err
+		}
+		if amount > buyableStock(p, res) {
+			return fmt.Errorf("%w: planet %s sells at most %d units of %v", ErrInsufficientStock, p.Name, buyableStock(p, res), res)
+		}
+		price := MarketPrice(p, res, g.config.Players.BasePrice)
+		tariff := duty(price, PlayerTariff(p))
+		if cost := (price + tariff) * amount; cost > player.Credits {
+			return fmt.Errorf("%w: %d units of %v cost %d credits", ErrInsufficientCredits, amount, res, cost)
+		}
+		p.Resources[res] -= amount
+		player.Inventory[res] += amount
+		player.Credits -= (price + tariff) * amount
+		p.Treasury += tariff * amount
+		g.activity.playerTrades[PurchaseEntry]++
+		Emit(g.log, TradeExecuted{Trader: player.Name, Player: true, Type: PurchaseEntry, Planet: p.Name, Resource: res, Amount: amount, Price: price + tariff, Tariff: tariff})
+		return nil
+	})
+}
+
+// PlayerSell sells an amount of a resource from a player's inventory to a planet at market price,
+// minus the tariff of its owner, which is paid into the planet's treasury.
+func (g *Game) PlayerSell(id, planetName string, res ResourceType, amount int) (PlayerState, error) {
+	return g.playerAction(JournalEntry{Type: SellEntry, Player: id, Planet: planetName, Resource: res, Amount: amount}, func(player *Player, p *Planet) error {
+		if err := validateTrade(res, amount); err != nil {
+			return err
+		}
+		if amount > player.Inventory[res] {
+			return fmt.Errorf("%w: inventory has %d units of %v", ErrInsufficientStock, player.Inventory[res], res)
+		}
+		price := MarketPrice(p, res, g.config.Players.BasePrice)
+		tariff := duty(price, PlayerTariff(p))
+		p.Resources[res] += amount
+		player.Inventory[res] -= amount
+		player.Credits += (price - tariff) * amount
+		p.Treasury += tariff * amount
+		g.activity.playerTrades[SaleEntry]++
+		Emit(g.log, TradeExecuted{Trader: player.Name, Player: true, Type: SaleEntry, Planet: p.Name, Resource: res, Amount: amount, Price: price - tariff, Tariff: tariff})
+		return nil
+	})
+}
+
+// PlayerCollect moves an amount of a resource from a planet owned by the player into its inventory, for free.
+func (g *Game) PlayerCollect(id, planetName string, res ResourceType, amount int) (PlayerState, error) {
+	return g.playerAction(JournalEntry{Type: CollectEntry, Player: id, Planet: planetName, Resource: res, Amount: amount}, func(player *Player, p *Planet) error {
+		if p.Player != player {
+			return ErrNotOwner
+		}
+		if err := validateTrade(res, amount); err != nil {
+			return err
+		}
+		if amount > p.Resources[res] {
+			return fmt.Errorf("%w: planet %s has %d units of %v", ErrInsufficientStock, p.Name, p.Resources[res], res)
+		}
+		p.Resources[res] -= amount
+		player.Inventory[res] += amount
+		g.log.Info("Player %s collected %d units of %v from planet %s.", player.Name, amount, res, p.Name)
+		return nil
+	})
+}
+
+// PlayerColonize takes over an unowned planet for configured colonization costs and places a building
+// of the player's choice on it, for free.
+func (g *Game) PlayerColonize(id, planetName string, buildingType BuildingType) (PlayerState, error) {
+	return g.playerAction(JournalEntry{Type: ColonizeEntry, Player: id, Planet: planetName, Building: buildingType}, func(player *Player, p *Planet) error {
+		if err := validateBuilding(p, buildingType); err != nil {
+			return err
+		}
+		if IsPlanetColonized(p) {
+			return ErrPlanetColonized
+		}
+		if cost := g.config.Players.ColonizationCost; cost > player.Credits {
+			return fmt.Errorf("%w: colonization costs %d credits", ErrInsufficientCredits, cost)
+		}
+		player.Credits -= g.config.Players.ColonizationCost
+		p.Player = player
+		g.activity.colonizations["player"]++
+		p.Buildings = append(p.Buildings, NewBuilding(buildingType, g.config.SeedConfig, g.random))
+		Emit(g.log, PlanetColonized{Planet: p.Name, Colonist: player.Name, Player: true, Building: buildingType})
+		return nil
+	})
+}
+
+// PlayerBuild places a building on a planet owned by the player. Resources the planet lacks to pay
+// its build costs are delivered from the player's inventory.
+func (g *Game) PlayerBuild(id, planetName string, buildingType BuildingType) (PlayerState, error) {
+	return g.playerAction(JournalEntry{Type: BuildEntry, Player: id, Planet: planetName, Building: buildingType}, func(player *Player, p *Planet) error {
+		if p.Player != player {
+			return ErrNotOwner
+		}
+		if err := validateBuilding(p, buildingType); err != nil {
+			return err
+		}
+		building := NewBuilding(buildingType, g.config.SeedConfig, g.random)
+		delivery := make(map[ResourceType]int)
+		for res, cost := range building.BuildCost {
+			missing := cost - p.Resources[res]
+			if missing <= 0 {
+				continue
+			}
+			if missing > player.Inventory[res] {
+				return fmt.Errorf("%w: %v requires %d more units of %v", ErrInsufficientStock, buildingType, missing-player.Inventory[res], res)
+			}
+			delivery[res] = missing
+		}
+		for res, amount := range delivery {
+			player.Inventory[res] -= amount
+			p.Resources[res] += amount
+		}
+		if !p.Build(building, g.log) {
+			return ErrInsufficientStock
+		}
+		g.log.Debug("Player %s built %v on planet %s, delivering %d units from inventory.", player.Name, buildingType, p.Name, sumAmounts(delivery))
+		return nil
+	})
+}
+
+// playerAction runs an action of a player on a planet between two ticks and returns the player afterwards.
+// Passed journal entry describes the action, it's recorded if the action succeeds.
+func (g *Game) playerAction(entry JournalEntry, action func(*Player, *Planet) error) (PlayerState, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	player, err := g.player(entry.Player)
+	if err != nil {
+		return PlayerState{}, err
+	}
+	p := g.planet(entry.Planet)
+	if p == nil {
+		return PlayerState{}, fmt.Errorf("%w: %s", ErrUnknownPlanet, entry.Planet)
+	}
+	if err := action(player, p); err != nil {
+		g.discardDraws()
+		g.log.Debug("Player %s: Action on planet %s failed: %v", player.Name, p.Name, err)
+		return PlayerState{}, err
+	}
+	g.journalAction(entry)
+	return g.playerState(player), nil
+}
+
+func (g *Game) player(id string) (*Player, error) {
+	for _, player := range g.Players {
+		if id != "" && player.ID == id {
+			return player, nil
+		}
+	}
+	return nil, ErrUnknownPlayer
+}
+
+func (g *Game) planet(name string) *Planet {
+	for _, p := range g.Planets {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func validateTrade(res ResourceType, amount int) error {
+	if !slices.Contains(resourceTypes, res) {
+		return ErrUnknownResource
+	}
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+func validateBuilding(p *Planet, buildingType BuildingType) error {
+	if !slices.Contains(developmentTypes, buildingType) {
+		return ErrUnknownBuilding
+	}
+	if !BuildingAllowed(p.Type, buildingType) {
+		return fmt.Errorf("%w: %v on %v planet %s", ErrBuildingNotAllowed, buildingType, p.Type, p.Name)
+	}
+	return nil
+}
+
+// newPlayerID returns a random, hard to guess player ID.
+func newPlayerID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("unable to generate player id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
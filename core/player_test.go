@@ -0,0 +1,156 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PlayerSuite struct {
+	suite.Suite
+	game   *Game
+	log    *mockLog
+	market *Planet
+	desert *Planet
+}
+
+func TestPlayerSuite(t *testing.T) {
+	suite.Run(t, new(PlayerSuite))
+}
+
+func (s *PlayerSuite) SetupTest() {
+	s.log = &mockLog{}
+	s.market = &Planet{
+		Name:      "Market",
+		Type:      TerraLike,
+		Resources: map[ResourceType]int{Iron: 1000, Food: 1000, Fuel: 1000},
+		Modifiers: map[ResourceType]float64{},
+	}
+	s.desert = &Planet{
+		Name:      "Desert",
+		Type:      Desert,
+		Resources: map[ResourceType]int{Iron: 0, Food: 0, Fuel: 0},
+		Modifiers: map[ResourceType]float64{},
+	}
+	config := DefaultConfig()
+	config.Players.MaxPlayers = 2
+	s.game = NewGameService(config, &mockRand{}, s.log, []*Planet{s.market, s.desert}, []*NPC{})
+}
+
+func (s *PlayerSuite) TestJoinUniverse() {
+	player, err := s.game.JoinUniverse(" Alice ", "")
+	s.NoError(err)
+	s.Equal("Alice", player.Name)
+	s.Len(player.ID, 32)
+	s.Equal(1000, player.Credits)
+	s.Empty(player.Planets)
+
+	_, err = s.game.JoinUniverse("alice", "")
+	s.ErrorIs(err, ErrPlayerNameTaken)
+	_, err = s.game.JoinUniverse("", "")
+	s.ErrorIs(err, ErrInvalidPlayerName)
+	_, err = s.game.JoinUniverse("Bob", "")
+	s.NoError(err)
+	_, err = s.game.JoinUniverse("Carol", "")
+	s.ErrorIs(err, ErrUniverseFull)
+
+	found, err := s.game.FindPlayer(player.ID)
+	s.NoError(err)
+	s.Equal(player, found)
+	_, err = s.game.FindPlayer("")
+	s.ErrorIs(err, ErrUnknownPlayer)
+}
+
+func (s *PlayerSuite) TestTrade() {
+	player, _ := s.game.JoinUniverse("Alice", "")
+
+	player, err := s.game.PlayerBuy(player.ID, "Market", Iron, 50)
+	s.NoError(err)
+	s.Equal(50, player.Inventory[Iron])
+	s.Equal(1000-50*10, player.Credits)
+	s.Equal(950, s.market.Resources[Iron])
+
+	_, err = s.game.PlayerBuy(player.ID, "Market", Iron, 200)
+	s.ErrorIs(err, ErrInsufficientStock)
+	_, err = s.game.PlayerBuy(player.ID, "Market", Food, 60)
+	s.ErrorIs(err, ErrInsufficientCredits)
+	_, err = s.game.PlayerBuy(player.ID, "Nowhere", Food, 1)
+	s.ErrorIs(err, ErrUnknownPlanet)
+	_, err = s.game.PlayerBuy(player.ID, "Market", ResourceType(-1), 1)
+	s.ErrorIs(err, ErrUnknownResource)
+	_, err = s.game.PlayerBuy("unknown", "Market", Food, 1)
+	s.ErrorIs(err, ErrUnknownPlayer)
+
+	// Iron is scarce on the desert planet, it pays twice the base price
+	player, err = s.game.PlayerSell(player.ID, "Desert", Iron, 50)
+	s.NoError(err)
+	s.Equal(0, player.Inventory[Iron])
+	s.Equal(500+50*20, player.Credits)
+	_, err = s.game.PlayerSell(player.ID, "Desert", Iron, 1)
+	s.ErrorIs(err, ErrInsufficientStock)
+}
+
+func (s *PlayerSuite) TestTariffsOfNPCPlanets() {
+	s.market.Owner = &NPC{Name: "Owner", Tariff: 10}
+	player, _ := s.game.JoinUniverse("Alice", "")
+
+	player, err := s.game.PlayerBuy(player.ID, "Market", Iron, 10)
+	s.NoError(err)
+	s.Equal(1000-10*11, player.Credits)
+	s.Equal(10, s.market.Treasury)
+}
+
+func (s *PlayerSuite) TestColonizeAndBuild() {
+	alice, _ := s.game.JoinUniverse("Alice", "")
+	bob, _ := s.game.JoinUniverse("Bob", "")
+
+	_, err := s.game.PlayerColonize(alice.ID, "Desert", Farm)
+	s.ErrorIs(err, ErrBuildingNotAllowed)
+	_, err = s.game.PlayerColonize(alice.ID, "Desert", City)
+	s.ErrorIs(err, ErrUnknownBuilding)
+
+	alice, err = s.game.PlayerColonize(alice.ID, "Desert", Mine)
+	s.NoError(err)
+	s.Equal(500, alice.Credits)
+	s.Equal([]string{"Desert"}, alice.Planets)
+	s.Equal(s.game.Players[0], s.desert.Player)
+	s.Len(s.desert.Buildings, 1)
+	s.Equal([]*Planet{s.desert}, s.game.Players[0].Planets(s.game.Planets))
+	s.True(IsPlanetColonized(s.desert))
+
+	_, err = s.game.PlayerColonize(bob.ID, "Desert", Mine)
+	s.ErrorIs(err, ErrPlanetColonized)
+
+	// only the owner may build on or collect from a planet
+	_, err = s.game.PlayerBuild(bob.ID, "Desert", Refinery)
+	s.ErrorIs(err, ErrNotOwner)
+	_, err = s.game.PlayerCollect(bob.ID, "Desert", Iron, 1)
+	s.ErrorIs(err, ErrNotOwner)
+
+	// a refinery costs 70 Iron and 30 Fuel, the planet has none of it
+	_, err = s.game.PlayerBuild(alice.ID, "Desert", Refinery)
+	s.ErrorIs(err, ErrInsufficientStock)
+	s.game.Players[0].Inventory = map[ResourceType]int{Iron: 80, Fuel: 30}
+	alice, err = s.game.PlayerBuild(alice.ID, "Desert", Refinery)
+	s.NoError(err)
+	s.Equal(10, alice.Inventory[Iron])
+	s.Equal(0, alice.Inventory[Fuel])
+	s.Len(s.desert.Buildings, 2)
+
+	s.desert.Resources[Iron] = 30
+	alice, err = s.game.PlayerCollect(alice.ID, "Desert", Iron, 30)
+	s.NoError(err)
+	s.Equal(40, alice.Inventory[Iron])
+	_, err = s.game.PlayerCollect(alice.ID, "Desert", Iron, 1)
+	s.ErrorIs(err, ErrInsufficientStock)
+}
+
+func (s *PlayerSuite) TestTreasuriesArePaidToPlayers() {
+	s.game.JoinUniverse("Alice", "")
+	s.desert.Player = s.game.Players[0]
+	s.desert.Treasury = 100
+
+	PayOutTreasuries(s.game.Planets, TaxConfig{PayoutRate: 0.5}, s.log)
+	s.Equal(1050, s.game.Players[0].Credits)
+	s.Equal(50, s.desert.Treasury)
+}
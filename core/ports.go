@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 type Random interface {
 	Seek() float64
 	Of(n int) int
@@ -13,3 +15,7 @@ type Log interface {
 	Debug(message string, v ...interface{})
 	Flush()
 }
+
+type Clock interface {
+	Now() time.Time
+}
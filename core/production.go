@@ -1,28 +1,50 @@
 package core
 
-func ProduceResources(planets []*Planet, log Log) {
+// ProduceResources adds the production of all buildings to their planets.
+// Returns the number of units produced per planet.
+func ProduceResources(planets []*Planet, log Log) map[*Planet]int {
+	produced := make(map[*Planet]int)
 	for _, p := range planets {
 		for _, b := range p.Buildings {
-			for resType, base := range b.Production {
-				planetBoost := p.Modifiers[resType] * BaseProductionModifier(p.Type, resType)
-				buildingBoost := b.Modifiers[resType]
-				if planetBoost == 0 {
-					planetBoost = 1.0
-				}
-				if buildingBoost == 0 {
-					buildingBoost = 1.0
-				}
-				totalBoost := planetBoost * buildingBoost
-				produced := int(float64(base*b.Level) * totalBoost)
-				p.Resources[resType] += produced
-				if produced > 0 {
-					log.Info("Produced %d units of %v on planet %s (building %v, level %d)", produced, resType, p.Name, b.Type, b.Level)
+			for resType := range b.Production {
+				units := buildingOutput(p, b, resType)
+				p.Resources[resType] += units
+				if units > 0 {
+					produced[p] += units
+					log.Info("Produced %d units of %v on planet %s (building %v, level %d)", units, resType, p.Name, b.Type, b.Level)
 				} else {
 					log.Debug("No production for %v on planet %s (building %v, level %d)", resType, p.Name, b.Type, b.Level)
 				}
 			}
 		}
 	}
+	return produced
+}
+
+// ProductionOf returns the units of each resource the buildings of a planet produce per tick.
+func ProductionOf(p *Planet) map[ResourceType]int {
+	production := make(map[ResourceType]int)
+	for _, b := range p.Buildings {
+		for resType := range b.Production {
+			production[resType] += buildingOutput(p, b, resType)
+		}
+	}
+	return production
+}
+
+// buildingOutput returns the units of a resource a building produces per tick, boosted by modifiers
+// of the building, its planet and the planet's type.
+func buildingOutput(p *Planet, b *Building, resType ResourceType) int {
+	planetBoost := p.Modifiers[resType] * BaseProductionModifier(p.Type, resType)
+	buildingBoost := b.Modifiers[resType]
+	if planetBoost == 0 {
+		planetBoost = 1.0
+	}
+	if buildingBoost == 0 {
+		buildingBoost = 1.0
+	}
+	totalBoost := planetBoost * buildingBoost
+	return int(float64(b.Production[resType]*b.Level) * totalBoost)
 }
 
 func BaseProductionModifier(pt PlanetType, res ResourceType) float64 {
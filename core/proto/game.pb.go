@@ -28,6 +28,7 @@ const (
 	ClientCommand_PAUSE       ClientCommand_CommandType = 1
 	ClientCommand_RESUME      ClientCommand_CommandType = 2
 	ClientCommand_UNSUBSCRIBE ClientCommand_CommandType = 3
+	ClientCommand_FILTER      ClientCommand_CommandType = 4 // replace the filter of the stream, the filter can also be passed with SUBSCRIBE
 )
 
 // Enum value maps for ClientCommand_CommandType.
@@ -37,12 +38,14 @@ var (
 		1: "PAUSE",
 		2: "RESUME",
 		3: "UNSUBSCRIBE",
+		4: "FILTER",
 	}
 	ClientCommand_CommandType_value = map[string]int32{
 		"SUBSCRIBE":   0,
 		"PAUSE":       1,
 		"RESUME":      2,
 		"UNSUBSCRIBE": 3,
+		"FILTER":      4,
 	}
 )
 
@@ -70,7 +73,62 @@ func (x ClientCommand_CommandType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ClientCommand_CommandType.Descriptor instead.
 func (ClientCommand_CommandType) EnumDescriptor() ([]byte, []int) {
-	return file_core_proto_game_proto_rawDescGZIP(), []int{8, 0}
+	return file_core_proto_game_proto_rawDescGZIP(), []int{19, 0}
+}
+
+type GameControl_Action int32
+
+const (
+	GameControl_ACTION_UNSPECIFIED GameControl_Action = 0
+	GameControl_PAUSE              GameControl_Action = 1
+	GameControl_RESUME             GameControl_Action = 2
+	GameControl_STEP               GameControl_Action = 3
+	GameControl_SET_SPEED          GameControl_Action = 4
+)
+
+// Enum value maps for GameControl_Action.
+var (
+	GameControl_Action_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "PAUSE",
+		2: "RESUME",
+		3: "STEP",
+		4: "SET_SPEED",
+	}
+	GameControl_Action_value = map[string]int32{
+		"ACTION_UNSPECIFIED": 0,
+		"PAUSE":              1,
+		"RESUME":             2,
+		"STEP":               3,
+		"SET_SPEED":          4,
+	}
+)
+
+func (x GameControl_Action) Enum() *GameControl_Action {
+	p := new(GameControl_Action)
+	*p = x
+	return p
+}
+
+func (x GameControl_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameControl_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_proto_game_proto_enumTypes[1].Descriptor()
+}
+
+func (GameControl_Action) Type() protoreflect.EnumType {
+	return &file_core_proto_game_proto_enumTypes[1]
+}
+
+func (x GameControl_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameControl_Action.Descriptor instead.
+func (GameControl_Action) EnumDescriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{21, 0}
 }
 
 type Empty struct {
@@ -205,6 +263,10 @@ type Planet struct {
 	Modifiers     map[string]float32     `protobuf:"bytes,4,rep,name=modifiers,proto3" json:"modifiers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
 	Buildings     []*Building            `protobuf:"bytes,5,rep,name=buildings,proto3" json:"buildings,omitempty"`
 	Owner         *NPC                   `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`
+	Treasury      int32                  `protobuf:"varint,7,opt,name=treasury,proto3" json:"treasury,omitempty"`
+	Tariff        int32                  `protobuf:"varint,8,opt,name=tariff,proto3" json:"tariff,omitempty"`
+	Garrison      int32                  `protobuf:"varint,9,opt,name=garrison,proto3" json:"garrison,omitempty"`
+	Player        string                 `protobuf:"bytes,10,opt,name=player,proto3" json:"player,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -281,6 +343,34 @@ func (x *Planet) GetOwner() *NPC {
 	return nil
 }
 
+func (x *Planet) GetTreasury() int32 {
+	if x != nil {
+		return x.Treasury
+	}
+	return 0
+}
+
+func (x *Planet) GetTariff() int32 {
+	if x != nil {
+		return x.Tariff
+	}
+	return 0
+}
+
+func (x *Planet) GetGarrison() int32 {
+	if x != nil {
+		return x.Garrison
+	}
+	return 0
+}
+
+func (x *Planet) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
 type Building struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -365,6 +455,12 @@ type NPC struct {
 	Cargo                map[string]int32       `protobuf:"bytes,4,rep,name=cargo,proto3" json:"cargo,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	MaxCargo             int32                  `protobuf:"varint,5,opt,name=maxCargo,proto3" json:"maxCargo,omitempty"`
 	ColonizationCooldown string                 `protobuf:"bytes,6,opt,name=colonizationCooldown,proto3" json:"colonizationCooldown,omitempty"`
+	Strategy             string                 `protobuf:"bytes,7,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Tariff               int32                  `protobuf:"varint,8,opt,name=tariff,proto3" json:"tariff,omitempty"`
+	Revenue              int32                  `protobuf:"varint,9,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Faction              string                 `protobuf:"bytes,10,opt,name=faction,proto3" json:"faction,omitempty"`
+	Ships                int32                  `protobuf:"varint,11,opt,name=ships,proto3" json:"ships,omitempty"`
+	Location             string                 `protobuf:"bytes,12,opt,name=location,proto3" json:"location,omitempty"`
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -441,29 +537,74 @@ func (x *NPC) GetColonizationCooldown() string {
 	return ""
 }
 
-type UniverseState struct {
+func (x *NPC) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *NPC) GetTariff() int32 {
+	if x != nil {
+		return x.Tariff
+	}
+	return 0
+}
+
+func (x *NPC) GetRevenue() int32 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *NPC) GetFaction() string {
+	if x != nil {
+		return x.Faction
+	}
+	return ""
+}
+
+func (x *NPC) GetShips() int32 {
+	if x != nil {
+		return x.Ships
+	}
+	return 0
+}
+
+func (x *NPC) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type Player struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Planets       *PlanetList            `protobuf:"bytes,1,opt,name=planets,proto3" json:"planets,omitempty"`
-	Npcs          *NPCList               `protobuf:"bytes,2,opt,name=npcs,proto3" json:"npcs,omitempty"`
-	Events        []*Event               `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Credits       int32                  `protobuf:"varint,3,opt,name=credits,proto3" json:"credits,omitempty"`
+	Inventory     map[string]int32       `protobuf:"bytes,4,rep,name=inventory,proto3" json:"inventory,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Planets       []string               `protobuf:"bytes,5,rep,name=planets,proto3" json:"planets,omitempty"`
+	JoinedAt      string                 `protobuf:"bytes,6,opt,name=joinedAt,proto3" json:"joinedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UniverseState) Reset() {
-	*x = UniverseState{}
+func (x *Player) Reset() {
+	*x = Player{}
 	mi := &file_core_proto_game_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UniverseState) String() string {
+func (x *Player) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UniverseState) ProtoMessage() {}
+func (*Player) ProtoMessage() {}
 
-func (x *UniverseState) ProtoReflect() protoreflect.Message {
+func (x *Player) ProtoReflect() protoreflect.Message {
 	mi := &file_core_proto_game_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -475,59 +616,74 @@ func (x *UniverseState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UniverseState.ProtoReflect.Descriptor instead.
-func (*UniverseState) Descriptor() ([]byte, []int) {
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
 	return file_core_proto_game_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *UniverseState) GetPlanets() *PlanetList {
+func (x *Player) GetId() string {
 	if x != nil {
-		return x.Planets
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *UniverseState) GetNpcs() *NPCList {
+func (x *Player) GetName() string {
 	if x != nil {
-		return x.Npcs
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Player) GetCredits() int32 {
+	if x != nil {
+		return x.Credits
+	}
+	return 0
+}
+
+func (x *Player) GetInventory() map[string]int32 {
+	if x != nil {
+		return x.Inventory
 	}
 	return nil
 }
 
-func (x *UniverseState) GetEvents() []*Event {
+func (x *Player) GetPlanets() []string {
 	if x != nil {
-		return x.Events
+		return x.Planets
 	}
 	return nil
 }
 
-type Event struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Target         int32                  `protobuf:"varint,2,opt,name=target,proto3" json:"target,omitempty"`
-	TargetPlanet   string                 `protobuf:"bytes,3,opt,name=targetPlanet,proto3" json:"targetPlanet,omitempty"`
-	TargetBuilding string                 `protobuf:"bytes,4,opt,name=targetBuilding,proto3" json:"targetBuilding,omitempty"`
-	ResourceBoost  map[string]float32     `protobuf:"bytes,5,rep,name=resourceBoost,proto3" json:"resourceBoost,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
-	Duration       int32                  `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
-	RemainingTicks int32                  `protobuf:"varint,7,opt,name=remainingTicks,proto3" json:"remainingTicks,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *Player) GetJoinedAt() string {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return ""
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
+type JoinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
 	mi := &file_core_proto_game_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Event) String() string {
+func (x *JoinRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*JoinRequest) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_core_proto_game_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -539,83 +695,154 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
 	return file_core_proto_game_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Event) GetName() string {
+func (x *JoinRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Event) GetTarget() int32 {
+type TradeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Planet        string                 `protobuf:"bytes,1,opt,name=planet,proto3" json:"planet,omitempty"`
+	Resource      string                 `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Amount        int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeRequest) Reset() {
+	*x = TradeRequest{}
+	mi := &file_core_proto_game_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeRequest) ProtoMessage() {}
+
+func (x *TradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[8]
 	if x != nil {
-		return x.Target
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Event) GetTargetPlanet() string {
+// Deprecated: Use TradeRequest.ProtoReflect.Descriptor instead.
+func (*TradeRequest) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TradeRequest) GetPlanet() string {
 	if x != nil {
-		return x.TargetPlanet
+		return x.Planet
 	}
 	return ""
 }
 
-func (x *Event) GetTargetBuilding() string {
+func (x *TradeRequest) GetResource() string {
 	if x != nil {
-		return x.TargetBuilding
+		return x.Resource
 	}
 	return ""
 }
 
-func (x *Event) GetResourceBoost() map[string]float32 {
+func (x *TradeRequest) GetAmount() int32 {
 	if x != nil {
-		return x.ResourceBoost
+		return x.Amount
 	}
-	return nil
+	return 0
 }
 
-func (x *Event) GetDuration() int32 {
+type BuildRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Planet        string                 `protobuf:"bytes,1,opt,name=planet,proto3" json:"planet,omitempty"`
+	Building      string                 `protobuf:"bytes,2,opt,name=building,proto3" json:"building,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildRequest) Reset() {
+	*x = BuildRequest{}
+	mi := &file_core_proto_game_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildRequest) ProtoMessage() {}
+
+func (x *BuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[9]
 	if x != nil {
-		return x.Duration
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Event) GetRemainingTicks() int32 {
+// Deprecated: Use BuildRequest.ProtoReflect.Descriptor instead.
+func (*BuildRequest) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BuildRequest) GetPlanet() string {
 	if x != nil {
-		return x.RemainingTicks
+		return x.Planet
 	}
-	return 0
+	return ""
 }
 
-type ClientCommand struct {
-	state         protoimpl.MessageState    `protogen:"open.v1"`
-	Type          ClientCommand_CommandType `protobuf:"varint,1,opt,name=type,proto3,enum=proto.ClientCommand_CommandType" json:"type,omitempty"`
-	Payload       string                    `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+func (x *BuildRequest) GetBuilding() string {
+	if x != nil {
+		return x.Building
+	}
+	return ""
+}
+
+type TradeLedger struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Counts        map[string]int32       `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Credits       map[string]int64       `protobuf:"bytes,3,rep,name=credits,proto3" json:"credits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClientCommand) Reset() {
-	*x = ClientCommand{}
-	mi := &file_core_proto_game_proto_msgTypes[8]
+func (x *TradeLedger) Reset() {
+	*x = TradeLedger{}
+	mi := &file_core_proto_game_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClientCommand) String() string {
+func (x *TradeLedger) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientCommand) ProtoMessage() {}
+func (*TradeLedger) ProtoMessage() {}
 
-func (x *ClientCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_core_proto_game_proto_msgTypes[8]
+func (x *TradeLedger) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -626,55 +853,1853 @@ func (x *ClientCommand) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientCommand.ProtoReflect.Descriptor instead.
-func (*ClientCommand) Descriptor() ([]byte, []int) {
-	return file_core_proto_game_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use TradeLedger.ProtoReflect.Descriptor instead.
+func (*TradeLedger) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ClientCommand) GetType() ClientCommand_CommandType {
+func (x *TradeLedger) GetEntries() []*LedgerEntry {
 	if x != nil {
-		return x.Type
+		return x.Entries
 	}
-	return ClientCommand_SUBSCRIBE
+	return nil
 }
 
-func (x *ClientCommand) GetPayload() string {
+func (x *TradeLedger) GetCounts() map[string]int32 {
 	if x != nil {
-		return x.Payload
+		return x.Counts
 	}
-	return ""
+	return nil
 }
 
-var File_core_proto_game_proto protoreflect.FileDescriptor
+func (x *TradeLedger) GetCredits() map[string]int64 {
+	if x != nil {
+		return x.Credits
+	}
+	return nil
+}
 
-const file_core_proto_game_proto_rawDesc = "" +
-	"\n" +
-	"\x15core/proto/game.proto\x12\x05proto\"\a\n" +
-	"\x05Empty\"5\n" +
-	"\n" +
-	"PlanetList\x12'\n" +
-	"\aplanets\x18\x01 \x03(\v2\r.proto.PlanetR\aplanets\")\n" +
-	"\aNPCList\x12\x1e\n" +
-	"\x04npcs\x18\x01 \x03(\v2\n" +
-	".proto.NPCR\x04npcs\"\xf5\x02\n" +
-	"\x06Planet\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04type\x18\x02 \x01(\tR\x04type\x12:\n" +
-	"\tresources\x18\x03 \x03(\v2\x1c.proto.Planet.ResourcesEntryR\tresources\x12:\n" +
-	"\tmodifiers\x18\x04 \x03(\v2\x1c.proto.Planet.ModifiersEntryR\tmodifiers\x12-\n" +
-	"\tbuildings\x18\x05 \x03(\v2\x0f.proto.BuildingR\tbuildings\x12 \n" +
-	"\x05owner\x18\x06 \x01(\v2\n" +
-	".proto.NPCR\x05owner\x1a<\n" +
-	"\x0eResourcesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a<\n" +
-	"\x0eModifiersEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"\xac\x03\n" +
-	"\bBuilding\x12\x12\n" +
-	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
-	"\x05level\x18\x02 \x01(\x05R\x05level\x12?\n" +
-	"\n" +
+type LedgerEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Npc           string                 `protobuf:"bytes,2,opt,name=npc,proto3" json:"npc,omitempty"`
+	Planet        string                 `protobuf:"bytes,3,opt,name=planet,proto3" json:"planet,omitempty"`
+	Resource      string                 `protobuf:"bytes,4,opt,name=resource,proto3" json:"resource,omitempty"`
+	Amount        int32                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Credits       int32                  `protobuf:"varint,6,opt,name=credits,proto3" json:"credits,omitempty"`
+	Time          string                 `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_core_proto_game_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LedgerEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetNpc() string {
+	if x != nil {
+		return x.Npc
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetPlanet() string {
+	if x != nil {
+		return x.Planet
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetCredits() int32 {
+	if x != nil {
+		return x.Credits
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+type FactionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Factions      []*Faction             `protobuf:"bytes,1,rep,name=factions,proto3" json:"factions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FactionList) Reset() {
+	*x = FactionList{}
+	mi := &file_core_proto_game_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FactionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactionList) ProtoMessage() {}
+
+func (x *FactionList) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactionList.ProtoReflect.Descriptor instead.
+func (*FactionList) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FactionList) GetFactions() []*Faction {
+	if x != nil {
+		return x.Factions
+	}
+	return nil
+}
+
+type Faction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Territory     []string               `protobuf:"bytes,3,rep,name=territory,proto3" json:"territory,omitempty"`
+	Relations     map[string]int32       `protobuf:"bytes,4,rep,name=relations,proto3" json:"relations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Allies        []string               `protobuf:"bytes,5,rep,name=allies,proto3" json:"allies,omitempty"`
+	Rivals        []string               `protobuf:"bytes,6,rep,name=rivals,proto3" json:"rivals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Faction) Reset() {
+	*x = Faction{}
+	mi := &file_core_proto_game_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Faction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Faction) ProtoMessage() {}
+
+func (x *Faction) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Faction.ProtoReflect.Descriptor instead.
+func (*Faction) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Faction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Faction) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Faction) GetTerritory() []string {
+	if x != nil {
+		return x.Territory
+	}
+	return nil
+}
+
+func (x *Faction) GetRelations() map[string]int32 {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *Faction) GetAllies() []string {
+	if x != nil {
+		return x.Allies
+	}
+	return nil
+}
+
+func (x *Faction) GetRivals() []string {
+	if x != nil {
+		return x.Rivals
+	}
+	return nil
+}
+
+type UniverseState struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Planets         *PlanetList            `protobuf:"bytes,1,opt,name=planets,proto3" json:"planets,omitempty"`
+	Npcs            *NPCList               `protobuf:"bytes,2,opt,name=npcs,proto3" json:"npcs,omitempty"`
+	Events          []*Event               `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	ConfigChange    *ConfigChange          `protobuf:"bytes,4,opt,name=configChange,proto3" json:"configChange,omitempty"`
+	LifecycleEvents []*LifecycleEvent      `protobuf:"bytes,5,rep,name=lifecycleEvents,proto3" json:"lifecycleEvents,omitempty"`
+	BattleReports   []*BattleReport        `protobuf:"bytes,6,rep,name=battleReports,proto3" json:"battleReports,omitempty"`
+	Player          *Player                `protobuf:"bytes,7,opt,name=player,proto3" json:"player,omitempty"`
+	Tick            uint64                 `protobuf:"varint,8,opt,name=tick,proto3" json:"tick,omitempty"`          // tick the update was made after
+	Sequence        uint64                 `protobuf:"varint,9,opt,name=sequence,proto3" json:"sequence,omitempty"`  // increases with each update, pass it as resumeFrom to resume a stream
+	Keyframe        bool                   `protobuf:"varint,10,opt,name=keyframe,proto3" json:"keyframe,omitempty"` // full state, sent on resume if missed updates aren't buffered anymore
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UniverseState) Reset() {
+	*x = UniverseState{}
+	mi := &file_core_proto_game_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UniverseState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UniverseState) ProtoMessage() {}
+
+func (x *UniverseState) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UniverseState.ProtoReflect.Descriptor instead.
+func (*UniverseState) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UniverseState) GetPlanets() *PlanetList {
+	if x != nil {
+		return x.Planets
+	}
+	return nil
+}
+
+func (x *UniverseState) GetNpcs() *NPCList {
+	if x != nil {
+		return x.Npcs
+	}
+	return nil
+}
+
+func (x *UniverseState) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *UniverseState) GetConfigChange() *ConfigChange {
+	if x != nil {
+		return x.ConfigChange
+	}
+	return nil
+}
+
+func (x *UniverseState) GetLifecycleEvents() []*LifecycleEvent {
+	if x != nil {
+		return x.LifecycleEvents
+	}
+	return nil
+}
+
+func (x *UniverseState) GetBattleReports() []*BattleReport {
+	if x != nil {
+		return x.BattleReports
+	}
+	return nil
+}
+
+func (x *UniverseState) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *UniverseState) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *UniverseState) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *UniverseState) GetKeyframe() bool {
+	if x != nil {
+		return x.Keyframe
+	}
+	return false
+}
+
+type BattleReport struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Planet          string                 `protobuf:"bytes,1,opt,name=planet,proto3" json:"planet,omitempty"`
+	Attacker        string                 `protobuf:"bytes,2,opt,name=attacker,proto3" json:"attacker,omitempty"`
+	Defender        string                 `protobuf:"bytes,3,opt,name=defender,proto3" json:"defender,omitempty"`
+	Ships           int32                  `protobuf:"varint,4,opt,name=ships,proto3" json:"ships,omitempty"`
+	AttackStrength  int32                  `protobuf:"varint,5,opt,name=attackStrength,proto3" json:"attackStrength,omitempty"`
+	DefenceStrength int32                  `protobuf:"varint,6,opt,name=defenceStrength,proto3" json:"defenceStrength,omitempty"`
+	AttackerLosses  int32                  `protobuf:"varint,7,opt,name=attackerLosses,proto3" json:"attackerLosses,omitempty"`
+	DefenderLosses  int32                  `protobuf:"varint,8,opt,name=defenderLosses,proto3" json:"defenderLosses,omitempty"`
+	Outcome         string                 `protobuf:"bytes,9,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BattleReport) Reset() {
+	*x = BattleReport{}
+	mi := &file_core_proto_game_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleReport) ProtoMessage() {}
+
+func (x *BattleReport) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleReport.ProtoReflect.Descriptor instead.
+func (*BattleReport) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BattleReport) GetPlanet() string {
+	if x != nil {
+		return x.Planet
+	}
+	return ""
+}
+
+func (x *BattleReport) GetAttacker() string {
+	if x != nil {
+		return x.Attacker
+	}
+	return ""
+}
+
+func (x *BattleReport) GetDefender() string {
+	if x != nil {
+		return x.Defender
+	}
+	return ""
+}
+
+func (x *BattleReport) GetShips() int32 {
+	if x != nil {
+		return x.Ships
+	}
+	return 0
+}
+
+func (x *BattleReport) GetAttackStrength() int32 {
+	if x != nil {
+		return x.AttackStrength
+	}
+	return 0
+}
+
+func (x *BattleReport) GetDefenceStrength() int32 {
+	if x != nil {
+		return x.DefenceStrength
+	}
+	return 0
+}
+
+func (x *BattleReport) GetAttackerLosses() int32 {
+	if x != nil {
+		return x.AttackerLosses
+	}
+	return 0
+}
+
+func (x *BattleReport) GetDefenderLosses() int32 {
+	if x != nil {
+		return x.DefenderLosses
+	}
+	return 0
+}
+
+func (x *BattleReport) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+type LifecycleEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Npc             *NPC                   `protobuf:"bytes,2,opt,name=npc,proto3" json:"npc,omitempty"`
+	ReleasedPlanets []string               `protobuf:"bytes,3,rep,name=releasedPlanets,proto3" json:"releasedPlanets,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LifecycleEvent) Reset() {
+	*x = LifecycleEvent{}
+	mi := &file_core_proto_game_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifecycleEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifecycleEvent) ProtoMessage() {}
+
+func (x *LifecycleEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LifecycleEvent.ProtoReflect.Descriptor instead.
+func (*LifecycleEvent) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LifecycleEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LifecycleEvent) GetNpc() *NPC {
+	if x != nil {
+		return x.Npc
+	}
+	return nil
+}
+
+func (x *LifecycleEvent) GetReleasedPlanets() []string {
+	if x != nil {
+		return x.ReleasedPlanets
+	}
+	return nil
+}
+
+type ConfigChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applied       []string               `protobuf:"bytes,1,rep,name=applied,proto3" json:"applied,omitempty"`
+	Rejected      []string               `protobuf:"bytes,2,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigChange) Reset() {
+	*x = ConfigChange{}
+	mi := &file_core_proto_game_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChange) ProtoMessage() {}
+
+func (x *ConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChange.ProtoReflect.Descriptor instead.
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConfigChange) GetApplied() []string {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+func (x *ConfigChange) GetRejected() []string {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+type Event struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Target         int32                  `protobuf:"varint,2,opt,name=target,proto3" json:"target,omitempty"`
+	TargetPlanet   string                 `protobuf:"bytes,3,opt,name=targetPlanet,proto3" json:"targetPlanet,omitempty"`
+	TargetBuilding string                 `protobuf:"bytes,4,opt,name=targetBuilding,proto3" json:"targetBuilding,omitempty"`
+	ResourceBoost  map[string]float32     `protobuf:"bytes,5,rep,name=resourceBoost,proto3" json:"resourceBoost,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
+	Duration       int32                  `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	RemainingTicks int32                  `protobuf:"varint,7,opt,name=remainingTicks,proto3" json:"remainingTicks,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_core_proto_game_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Event) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Event) GetTarget() int32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *Event) GetTargetPlanet() string {
+	if x != nil {
+		return x.TargetPlanet
+	}
+	return ""
+}
+
+func (x *Event) GetTargetBuilding() string {
+	if x != nil {
+		return x.TargetBuilding
+	}
+	return ""
+}
+
+func (x *Event) GetResourceBoost() map[string]float32 {
+	if x != nil {
+		return x.ResourceBoost
+	}
+	return nil
+}
+
+func (x *Event) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Event) GetRemainingTicks() int32 {
+	if x != nil {
+		return x.RemainingTicks
+	}
+	return 0
+}
+
+type ClientCommand struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Type          ClientCommand_CommandType `protobuf:"varint,1,opt,name=type,proto3,enum=proto.ClientCommand_CommandType" json:"type,omitempty"`
+	Payload       string                    `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"` // filter as JSON, used if filter isn't set
+	Filter        *StateFilter              `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	ResumeFrom    uint64                    `protobuf:"varint,4,opt,name=resumeFrom,proto3" json:"resumeFrom,omitempty"` // sequence of the last received update, to receive missed updates with SUBSCRIBE
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientCommand) Reset() {
+	*x = ClientCommand{}
+	mi := &file_core_proto_game_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientCommand) ProtoMessage() {}
+
+func (x *ClientCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientCommand.ProtoReflect.Descriptor instead.
+func (*ClientCommand) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ClientCommand) GetType() ClientCommand_CommandType {
+	if x != nil {
+		return x.Type
+	}
+	return ClientCommand_SUBSCRIBE
+}
+
+func (x *ClientCommand) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *ClientCommand) GetFilter() *StateFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ClientCommand) GetResumeFrom() uint64 {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return 0
+}
+
+// StateFilter selects the part of the universe a stream receives, all of it if empty.
+// Planets are selected by name or owner, events by name and the planet they target.
+type StateFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Planets       []string               `protobuf:"bytes,1,rep,name=planets,proto3" json:"planets,omitempty"`
+	Owners        []string               `protobuf:"bytes,2,rep,name=owners,proto3" json:"owners,omitempty"`      // names of NPCs or players
+	Events        []string               `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`      // e.g. Solar Flare
+	OnlyNpcs      bool                   `protobuf:"varint,4,opt,name=onlyNpcs,proto3" json:"onlyNpcs,omitempty"` // NPCs only, without planets, events and battles
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateFilter) Reset() {
+	*x = StateFilter{}
+	mi := &file_core_proto_game_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateFilter) ProtoMessage() {}
+
+func (x *StateFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateFilter.ProtoReflect.Descriptor instead.
+func (*StateFilter) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StateFilter) GetPlanets() []string {
+	if x != nil {
+		return x.Planets
+	}
+	return nil
+}
+
+func (x *StateFilter) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *StateFilter) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *StateFilter) GetOnlyNpcs() bool {
+	if x != nil {
+		return x.OnlyNpcs
+	}
+	return false
+}
+
+type GameControl struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        GameControl_Action     `protobuf:"varint,1,opt,name=action,proto3,enum=proto.GameControl_Action" json:"action,omitempty"`
+	Steps         int32                  `protobuf:"varint,2,opt,name=steps,proto3" json:"steps,omitempty"`
+	Speed         float64                `protobuf:"fixed64,3,opt,name=speed,proto3" json:"speed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameControl) Reset() {
+	*x = GameControl{}
+	mi := &file_core_proto_game_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameControl) ProtoMessage() {}
+
+func (x *GameControl) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameControl.ProtoReflect.Descriptor instead.
+func (*GameControl) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GameControl) GetAction() GameControl_Action {
+	if x != nil {
+		return x.Action
+	}
+	return GameControl_ACTION_UNSPECIFIED
+}
+
+func (x *GameControl) GetSteps() int32 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+func (x *GameControl) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type GameStatus struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Paused                 bool                   `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	Speed                  float64                `protobuf:"fixed64,2,opt,name=speed,proto3" json:"speed,omitempty"`
+	TickInterval           string                 `protobuf:"bytes,3,opt,name=tickInterval,proto3" json:"tickInterval,omitempty"`
+	Ticks                  uint64                 `protobuf:"varint,4,opt,name=ticks,proto3" json:"ticks,omitempty"`
+	LastTickDurationMicros int64                  `protobuf:"varint,5,opt,name=lastTickDurationMicros,proto3" json:"lastTickDurationMicros,omitempty"`
+	MaxTickDurationMicros  int64                  `protobuf:"varint,6,opt,name=maxTickDurationMicros,proto3" json:"maxTickDurationMicros,omitempty"`
+	AvgTickDurationMicros  int64                  `protobuf:"varint,7,opt,name=avgTickDurationMicros,proto3" json:"avgTickDurationMicros,omitempty"`
+	Overruns               uint64                 `protobuf:"varint,8,opt,name=overruns,proto3" json:"overruns,omitempty"`
+	SkippedTicks           uint64                 `protobuf:"varint,9,opt,name=skippedTicks,proto3" json:"skippedTicks,omitempty"`
+	TicksBehind            int32                  `protobuf:"varint,10,opt,name=ticksBehind,proto3" json:"ticksBehind,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GameStatus) Reset() {
+	*x = GameStatus{}
+	mi := &file_core_proto_game_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameStatus) ProtoMessage() {}
+
+func (x *GameStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameStatus.ProtoReflect.Descriptor instead.
+func (*GameStatus) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GameStatus) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *GameStatus) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *GameStatus) GetTickInterval() string {
+	if x != nil {
+		return x.TickInterval
+	}
+	return ""
+}
+
+func (x *GameStatus) GetTicks() uint64 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+func (x *GameStatus) GetLastTickDurationMicros() int64 {
+	if x != nil {
+		return x.LastTickDurationMicros
+	}
+	return 0
+}
+
+func (x *GameStatus) GetMaxTickDurationMicros() int64 {
+	if x != nil {
+		return x.MaxTickDurationMicros
+	}
+	return 0
+}
+
+func (x *GameStatus) GetAvgTickDurationMicros() int64 {
+	if x != nil {
+		return x.AvgTickDurationMicros
+	}
+	return 0
+}
+
+func (x *GameStatus) GetOverruns() uint64 {
+	if x != nil {
+		return x.Overruns
+	}
+	return 0
+}
+
+func (x *GameStatus) GetSkippedTicks() uint64 {
+	if x != nil {
+		return x.SkippedTicks
+	}
+	return 0
+}
+
+func (x *GameStatus) GetTicksBehind() int32 {
+	if x != nil {
+		return x.TicksBehind
+	}
+	return 0
+}
+
+type LogEventFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kinds         []string               `protobuf:"bytes,1,rep,name=kinds,proto3" json:"kinds,omitempty"` // kinds of events to receive, e.g. TradeExecuted, all if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEventFilter) Reset() {
+	*x = LogEventFilter{}
+	mi := &file_core_proto_game_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEventFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEventFilter) ProtoMessage() {}
+
+func (x *LogEventFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEventFilter.ProtoReflect.Descriptor instead.
+func (*LogEventFilter) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LogEventFilter) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+type LogEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Time          string                 `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEvent) Reset() {
+	*x = LogEvent{}
+	mi := &file_core_proto_game_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEvent) ProtoMessage() {}
+
+func (x *LogEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEvent.ProtoReflect.Descriptor instead.
+func (*LogEvent) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LogEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *LogEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LogEvent) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *LogEvent) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+type HistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`         // planet or npc
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`             // name of the planet or NPC
+	Metric        string                 `protobuf:"bytes,3,opt,name=metric,proto3" json:"metric,omitempty"`         // e.g. resources, resources.Iron or owner
+	From          string                 `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`             // RFC 3339 time of the first sample, oldest sample if empty
+	To            string                 `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`                 // RFC 3339 time of the last sample, latest sample if empty
+	Resolution    string                 `protobuf:"bytes,6,opt,name=resolution,proto3" json:"resolution,omitempty"` // minimum duration between two points, e.g. 1m, all samples if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_core_proto_game_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *HistoryRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+type History struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Metric        string                 `protobuf:"bytes,3,opt,name=metric,proto3" json:"metric,omitempty"`
+	Points        []*HistoryPoint        `protobuf:"bytes,4,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *History) Reset() {
+	*x = History{}
+	mi := &file_core_proto_game_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *History) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*History) ProtoMessage() {}
+
+func (x *History) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use History.ProtoReflect.Descriptor instead.
+func (*History) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *History) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *History) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *History) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *History) GetPoints() []*HistoryPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type HistoryPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tick          uint64                 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Time          string                 `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"` // value of text metrics, e.g. owner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryPoint) Reset() {
+	*x = HistoryPoint{}
+	mi := &file_core_proto_game_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryPoint) ProtoMessage() {}
+
+func (x *HistoryPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryPoint.ProtoReflect.Descriptor instead.
+func (*HistoryPoint) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *HistoryPoint) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *HistoryPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type EntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityRequest) Reset() {
+	*x = EntityRequest{}
+	mi := &file_core_proto_game_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityRequest) ProtoMessage() {}
+
+func (x *EntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityRequest.ProtoReflect.Descriptor instead.
+func (*EntityRequest) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EntityRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PlanetQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`                                                                                          // planet has one of these types, e.g. Desert
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`                                                                                          // name of the NPC or player owning the planet
+	Unowned       bool                   `protobuf:"varint,3,opt,name=unowned,proto3" json:"unowned,omitempty"`                                                                                     // planet hasn't been colonized
+	Buildings     []string               `protobuf:"bytes,4,rep,name=buildings,proto3" json:"buildings,omitempty"`                                                                                  // planet has buildings of all these types, e.g. Mine
+	MinResources  map[string]int32       `protobuf:"bytes,5,rep,name=minResources,proto3" json:"minResources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // planet has at least these amounts, e.g. Iron: 100
+	MaxResources  map[string]int32       `protobuf:"bytes,6,rep,name=maxResources,proto3" json:"maxResources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // planet has at most these amounts
+	Sort          string                 `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`                                                                                            // name, resources, resources.Iron, garrison or buildings
+	Descending    bool                   `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize      int32                  `protobuf:"varint,9,opt,name=pageSize,proto3" json:"pageSize,omitempty"`   // 50 by default, 500 at most
+	PageToken     string                 `protobuf:"bytes,10,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken of the previous page, empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanetQuery) Reset() {
+	*x = PlanetQuery{}
+	mi := &file_core_proto_game_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanetQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanetQuery) ProtoMessage() {}
+
+func (x *PlanetQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanetQuery.ProtoReflect.Descriptor instead.
+func (*PlanetQuery) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PlanetQuery) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *PlanetQuery) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *PlanetQuery) GetUnowned() bool {
+	if x != nil {
+		return x.Unowned
+	}
+	return false
+}
+
+func (x *PlanetQuery) GetBuildings() []string {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+func (x *PlanetQuery) GetMinResources() map[string]int32 {
+	if x != nil {
+		return x.MinResources
+	}
+	return nil
+}
+
+func (x *PlanetQuery) GetMaxResources() map[string]int32 {
+	if x != nil {
+		return x.MaxResources
+	}
+	return nil
+}
+
+func (x *PlanetQuery) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *PlanetQuery) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *PlanetQuery) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PlanetQuery) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type PlanetPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Planets       []*Planet              `protobuf:"bytes,1,rep,name=planets,proto3" json:"planets,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // empty on the last page
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`                // planets matching the query
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanetPage) Reset() {
+	*x = PlanetPage{}
+	mi := &file_core_proto_game_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanetPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanetPage) ProtoMessage() {}
+
+func (x *PlanetPage) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanetPage.ProtoReflect.Descriptor instead.
+func (*PlanetPage) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PlanetPage) GetPlanets() []*Planet {
+	if x != nil {
+		return x.Planets
+	}
+	return nil
+}
+
+func (x *PlanetPage) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *PlanetPage) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type NPCQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Faction       string                 `protobuf:"bytes,1,opt,name=faction,proto3" json:"faction,omitempty"`
+	Strategy      string                 `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	MinCredits    int32                  `protobuf:"varint,3,opt,name=minCredits,proto3" json:"minCredits,omitempty"`
+	MinCargo      map[string]int32       `protobuf:"bytes,4,rep,name=minCargo,proto3" json:"minCargo,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // NPC carries at least these amounts
+	Sort          string                 `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`                                                                                    // name, credits, cargo, cargo.Iron, ships or revenue
+	Descending    bool                   `protobuf:"varint,6,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize      int32                  `protobuf:"varint,7,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken     string                 `protobuf:"bytes,8,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NPCQuery) Reset() {
+	*x = NPCQuery{}
+	mi := &file_core_proto_game_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NPCQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NPCQuery) ProtoMessage() {}
+
+func (x *NPCQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NPCQuery.ProtoReflect.Descriptor instead.
+func (*NPCQuery) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *NPCQuery) GetFaction() string {
+	if x != nil {
+		return x.Faction
+	}
+	return ""
+}
+
+func (x *NPCQuery) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *NPCQuery) GetMinCredits() int32 {
+	if x != nil {
+		return x.MinCredits
+	}
+	return 0
+}
+
+func (x *NPCQuery) GetMinCargo() map[string]int32 {
+	if x != nil {
+		return x.MinCargo
+	}
+	return nil
+}
+
+func (x *NPCQuery) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *NPCQuery) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *NPCQuery) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *NPCQuery) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type NPCPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Npcs          []*NPC                 `protobuf:"bytes,1,rep,name=npcs,proto3" json:"npcs,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NPCPage) Reset() {
+	*x = NPCPage{}
+	mi := &file_core_proto_game_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NPCPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NPCPage) ProtoMessage() {}
+
+func (x *NPCPage) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NPCPage.ProtoReflect.Descriptor instead.
+func (*NPCPage) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *NPCPage) GetNpcs() []*NPC {
+	if x != nil {
+		return x.Npcs
+	}
+	return nil
+}
+
+func (x *NPCPage) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *NPCPage) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type EventList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventList) Reset() {
+	*x = EventList{}
+	mi := &file_core_proto_game_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventList) ProtoMessage() {}
+
+func (x *EventList) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventList.ProtoReflect.Descriptor instead.
+func (*EventList) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *EventList) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type GameInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticks         uint64                 `protobuf:"varint,1,opt,name=ticks,proto3" json:"ticks,omitempty"`
+	TickDuration  string                 `protobuf:"bytes,2,opt,name=tickDuration,proto3" json:"tickDuration,omitempty"`
+	Seed          int64                  `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameInfo) Reset() {
+	*x = GameInfo{}
+	mi := &file_core_proto_game_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameInfo) ProtoMessage() {}
+
+func (x *GameInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_core_proto_game_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameInfo.ProtoReflect.Descriptor instead.
+func (*GameInfo) Descriptor() ([]byte, []int) {
+	return file_core_proto_game_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GameInfo) GetTicks() uint64 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+func (x *GameInfo) GetTickDuration() string {
+	if x != nil {
+		return x.TickDuration
+	}
+	return ""
+}
+
+func (x *GameInfo) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *GameInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+var File_core_proto_game_proto protoreflect.FileDescriptor
+
+const file_core_proto_game_proto_rawDesc = "" +
+	"\n" +
+	"\x15core/proto/game.proto\x12\x05proto\"\a\n" +
+	"\x05Empty\"5\n" +
+	"\n" +
+	"PlanetList\x12'\n" +
+	"\aplanets\x18\x01 \x03(\v2\r.proto.PlanetR\aplanets\")\n" +
+	"\aNPCList\x12\x1e\n" +
+	"\x04npcs\x18\x01 \x03(\v2\n" +
+	".proto.NPCR\x04npcs\"\xdd\x03\n" +
+	"\x06Planet\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12:\n" +
+	"\tresources\x18\x03 \x03(\v2\x1c.proto.Planet.ResourcesEntryR\tresources\x12:\n" +
+	"\tmodifiers\x18\x04 \x03(\v2\x1c.proto.Planet.ModifiersEntryR\tmodifiers\x12-\n" +
+	"\tbuildings\x18\x05 \x03(\v2\x0f.proto.BuildingR\tbuildings\x12 \n" +
+	"\x05owner\x18\x06 \x01(\v2\n" +
+	".proto.NPCR\x05owner\x12\x1a\n" +
+	"\btreasury\x18\a \x01(\x05R\btreasury\x12\x16\n" +
+	"\x06tariff\x18\b \x01(\x05R\x06tariff\x12\x1a\n" +
+	"\bgarrison\x18\t \x01(\x05R\bgarrison\x12\x16\n" +
+	"\x06player\x18\n" +
+	" \x01(\tR\x06player\x1a<\n" +
+	"\x0eResourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a<\n" +
+	"\x0eModifiersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"\xac\x03\n" +
+	"\bBuilding\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x05R\x05level\x12?\n" +
+	"\n" +
 	"production\x18\x03 \x03(\v2\x1f.proto.Building.ProductionEntryR\n" +
 	"production\x12<\n" +
 	"\tmodifiers\x18\x04 \x03(\v2\x1e.proto.Building.ModifiersEntryR\tmodifiers\x12<\n" +
@@ -687,14 +2712,21 @@ const file_core_proto_game_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\x1a<\n" +
 	"\x0eBuildCostEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xd1\x02\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xeb\x03\n" +
 	"\x03NPC\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
 	"\x05offer\x18\x02 \x03(\v2\x15.proto.NPC.OfferEntryR\x05offer\x12\x18\n" +
 	"\acredits\x18\x03 \x01(\x05R\acredits\x12+\n" +
 	"\x05cargo\x18\x04 \x03(\v2\x15.proto.NPC.CargoEntryR\x05cargo\x12\x1a\n" +
 	"\bmaxCargo\x18\x05 \x01(\x05R\bmaxCargo\x122\n" +
-	"\x14colonizationCooldown\x18\x06 \x01(\tR\x14colonizationCooldown\x1a8\n" +
+	"\x14colonizationCooldown\x18\x06 \x01(\tR\x14colonizationCooldown\x12\x1a\n" +
+	"\bstrategy\x18\a \x01(\tR\bstrategy\x12\x16\n" +
+	"\x06tariff\x18\b \x01(\x05R\x06tariff\x12\x18\n" +
+	"\arevenue\x18\t \x01(\x05R\arevenue\x12\x18\n" +
+	"\afaction\x18\n" +
+	" \x01(\tR\afaction\x12\x14\n" +
+	"\x05ships\x18\v \x01(\x05R\x05ships\x12\x1a\n" +
+	"\blocation\x18\f \x01(\tR\blocation\x1a8\n" +
 	"\n" +
 	"OfferEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
@@ -702,11 +2734,86 @@ const file_core_proto_game_proto_rawDesc = "" +
 	"\n" +
 	"CargoEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x86\x01\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xf6\x01\n" +
+	"\x06Player\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acredits\x18\x03 \x01(\x05R\acredits\x12:\n" +
+	"\tinventory\x18\x04 \x03(\v2\x1c.proto.Player.InventoryEntryR\tinventory\x12\x18\n" +
+	"\aplanets\x18\x05 \x03(\tR\aplanets\x12\x1a\n" +
+	"\bjoinedAt\x18\x06 \x01(\tR\bjoinedAt\x1a<\n" +
+	"\x0eInventoryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"!\n" +
+	"\vJoinRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Z\n" +
+	"\fTradeRequest\x12\x16\n" +
+	"\x06planet\x18\x01 \x01(\tR\x06planet\x12\x1a\n" +
+	"\bresource\x18\x02 \x01(\tR\bresource\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\"B\n" +
+	"\fBuildRequest\x12\x16\n" +
+	"\x06planet\x18\x01 \x01(\tR\x06planet\x12\x1a\n" +
+	"\bbuilding\x18\x02 \x01(\tR\bbuilding\"\xa5\x02\n" +
+	"\vTradeLedger\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.proto.LedgerEntryR\aentries\x126\n" +
+	"\x06counts\x18\x02 \x03(\v2\x1e.proto.TradeLedger.CountsEntryR\x06counts\x129\n" +
+	"\acredits\x18\x03 \x03(\v2\x1f.proto.TradeLedger.CreditsEntryR\acredits\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a:\n" +
+	"\fCreditsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xad\x01\n" +
+	"\vLedgerEntry\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
+	"\x03npc\x18\x02 \x01(\tR\x03npc\x12\x16\n" +
+	"\x06planet\x18\x03 \x01(\tR\x06planet\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x05R\x06amount\x12\x18\n" +
+	"\acredits\x18\x06 \x01(\x05R\acredits\x12\x12\n" +
+	"\x04time\x18\a \x01(\tR\x04time\"9\n" +
+	"\vFactionList\x12*\n" +
+	"\bfactions\x18\x01 \x03(\v2\x0e.proto.FactionR\bfactions\"\x80\x02\n" +
+	"\aFaction\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\x12\x1c\n" +
+	"\tterritory\x18\x03 \x03(\tR\tterritory\x12;\n" +
+	"\trelations\x18\x04 \x03(\v2\x1d.proto.Faction.RelationsEntryR\trelations\x12\x16\n" +
+	"\x06allies\x18\x05 \x03(\tR\x06allies\x12\x16\n" +
+	"\x06rivals\x18\x06 \x03(\tR\x06rivals\x1a<\n" +
+	"\x0eRelationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xae\x03\n" +
 	"\rUniverseState\x12+\n" +
 	"\aplanets\x18\x01 \x01(\v2\x11.proto.PlanetListR\aplanets\x12\"\n" +
 	"\x04npcs\x18\x02 \x01(\v2\x0e.proto.NPCListR\x04npcs\x12$\n" +
-	"\x06events\x18\x03 \x03(\v2\f.proto.EventR\x06events\"\xcc\x02\n" +
+	"\x06events\x18\x03 \x03(\v2\f.proto.EventR\x06events\x127\n" +
+	"\fconfigChange\x18\x04 \x01(\v2\x13.proto.ConfigChangeR\fconfigChange\x12?\n" +
+	"\x0flifecycleEvents\x18\x05 \x03(\v2\x15.proto.LifecycleEventR\x0flifecycleEvents\x129\n" +
+	"\rbattleReports\x18\x06 \x03(\v2\x13.proto.BattleReportR\rbattleReports\x12%\n" +
+	"\x06player\x18\a \x01(\v2\r.proto.PlayerR\x06player\x12\x12\n" +
+	"\x04tick\x18\b \x01(\x04R\x04tick\x12\x1a\n" +
+	"\bsequence\x18\t \x01(\x04R\bsequence\x12\x1a\n" +
+	"\bkeyframe\x18\n" +
+	" \x01(\bR\bkeyframe\"\xb0\x02\n" +
+	"\fBattleReport\x12\x16\n" +
+	"\x06planet\x18\x01 \x01(\tR\x06planet\x12\x1a\n" +
+	"\battacker\x18\x02 \x01(\tR\battacker\x12\x1a\n" +
+	"\bdefender\x18\x03 \x01(\tR\bdefender\x12\x14\n" +
+	"\x05ships\x18\x04 \x01(\x05R\x05ships\x12&\n" +
+	"\x0eattackStrength\x18\x05 \x01(\x05R\x0eattackStrength\x12(\n" +
+	"\x0fdefenceStrength\x18\x06 \x01(\x05R\x0fdefenceStrength\x12&\n" +
+	"\x0eattackerLosses\x18\a \x01(\x05R\x0eattackerLosses\x12&\n" +
+	"\x0edefenderLosses\x18\b \x01(\x05R\x0edefenderLosses\x12\x18\n" +
+	"\aoutcome\x18\t \x01(\tR\aoutcome\"l\n" +
+	"\x0eLifecycleEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1c\n" +
+	"\x03npc\x18\x02 \x01(\v2\n" +
+	".proto.NPCR\x03npc\x12(\n" +
+	"\x0freleasedPlanets\x18\x03 \x03(\tR\x0freleasedPlanets\"D\n" +
+	"\fConfigChange\x12\x18\n" +
+	"\aapplied\x18\x01 \x03(\tR\aapplied\x12\x1a\n" +
+	"\brejected\x18\x02 \x03(\tR\brejected\"\xcc\x02\n" +
 	"\x05Event\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\x05R\x06target\x12\"\n" +
@@ -717,21 +2824,163 @@ const file_core_proto_game_proto_rawDesc = "" +
 	"\x0eremainingTicks\x18\a \x01(\x05R\x0eremainingTicks\x1a@\n" +
 	"\x12ResourceBoostEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"\xa5\x01\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"\xfd\x01\n" +
 	"\rClientCommand\x124\n" +
 	"\x04type\x18\x01 \x01(\x0e2 .proto.ClientCommand.CommandTypeR\x04type\x12\x18\n" +
-	"\apayload\x18\x02 \x01(\tR\apayload\"D\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\x12*\n" +
+	"\x06filter\x18\x03 \x01(\v2\x12.proto.StateFilterR\x06filter\x12\x1e\n" +
+	"\n" +
+	"resumeFrom\x18\x04 \x01(\x04R\n" +
+	"resumeFrom\"P\n" +
 	"\vCommandType\x12\r\n" +
 	"\tSUBSCRIBE\x10\x00\x12\t\n" +
 	"\x05PAUSE\x10\x01\x12\n" +
 	"\n" +
 	"\x06RESUME\x10\x02\x12\x0f\n" +
-	"\vUNSUBSCRIBE\x10\x032\xb0\x01\n" +
+	"\vUNSUBSCRIBE\x10\x03\x12\n" +
+	"\n" +
+	"\x06FILTER\x10\x04\"s\n" +
+	"\vStateFilter\x12\x18\n" +
+	"\aplanets\x18\x01 \x03(\tR\aplanets\x12\x16\n" +
+	"\x06owners\x18\x02 \x03(\tR\x06owners\x12\x16\n" +
+	"\x06events\x18\x03 \x03(\tR\x06events\x12\x1a\n" +
+	"\bonlyNpcs\x18\x04 \x01(\bR\bonlyNpcs\"\xbe\x01\n" +
+	"\vGameControl\x121\n" +
+	"\x06action\x18\x01 \x01(\x0e2\x19.proto.GameControl.ActionR\x06action\x12\x14\n" +
+	"\x05steps\x18\x02 \x01(\x05R\x05steps\x12\x14\n" +
+	"\x05speed\x18\x03 \x01(\x01R\x05speed\"P\n" +
+	"\x06Action\x12\x16\n" +
+	"\x12ACTION_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05PAUSE\x10\x01\x12\n" +
+	"\n" +
+	"\x06RESUME\x10\x02\x12\b\n" +
+	"\x04STEP\x10\x03\x12\r\n" +
+	"\tSET_SPEED\x10\x04\"\xfa\x02\n" +
+	"\n" +
+	"GameStatus\x12\x16\n" +
+	"\x06paused\x18\x01 \x01(\bR\x06paused\x12\x14\n" +
+	"\x05speed\x18\x02 \x01(\x01R\x05speed\x12\"\n" +
+	"\ftickInterval\x18\x03 \x01(\tR\ftickInterval\x12\x14\n" +
+	"\x05ticks\x18\x04 \x01(\x04R\x05ticks\x126\n" +
+	"\x16lastTickDurationMicros\x18\x05 \x01(\x03R\x16lastTickDurationMicros\x124\n" +
+	"\x15maxTickDurationMicros\x18\x06 \x01(\x03R\x15maxTickDurationMicros\x124\n" +
+	"\x15avgTickDurationMicros\x18\a \x01(\x03R\x15avgTickDurationMicros\x12\x1a\n" +
+	"\boverruns\x18\b \x01(\x04R\boverruns\x12\"\n" +
+	"\fskippedTicks\x18\t \x01(\x04R\fskippedTicks\x12 \n" +
+	"\vticksBehind\x18\n" +
+	" \x01(\x05R\vticksBehind\"&\n" +
+	"\x0eLogEventFilter\x12\x14\n" +
+	"\x05kinds\x18\x01 \x03(\tR\x05kinds\"\xcc\x01\n" +
+	"\bLogEvent\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12?\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2\x1f.proto.LogEvent.AttributesEntryR\n" +
+	"attributes\x12\x12\n" +
+	"\x04time\x18\x04 \x01(\tR\x04time\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x98\x01\n" +
+	"\x0eHistoryRequest\x12\x16\n" +
+	"\x06entity\x18\x01 \x01(\tR\x06entity\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06metric\x18\x03 \x01(\tR\x06metric\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x06 \x01(\tR\n" +
+	"resolution\"z\n" +
+	"\aHistory\x12\x16\n" +
+	"\x06entity\x18\x01 \x01(\tR\x06entity\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06metric\x18\x03 \x01(\tR\x06metric\x12+\n" +
+	"\x06points\x18\x04 \x03(\v2\x13.proto.HistoryPointR\x06points\"`\n" +
+	"\fHistoryPoint\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\x04R\x04tick\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\tR\x04time\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\"#\n" +
+	"\rEntityRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xf5\x03\n" +
+	"\vPlanetQuery\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x18\n" +
+	"\aunowned\x18\x03 \x01(\bR\aunowned\x12\x1c\n" +
+	"\tbuildings\x18\x04 \x03(\tR\tbuildings\x12H\n" +
+	"\fminResources\x18\x05 \x03(\v2$.proto.PlanetQuery.MinResourcesEntryR\fminResources\x12H\n" +
+	"\fmaxResources\x18\x06 \x03(\v2$.proto.PlanetQuery.MaxResourcesEntryR\fmaxResources\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\b \x01(\bR\n" +
+	"descending\x12\x1a\n" +
+	"\bpageSize\x18\t \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\n" +
+	" \x01(\tR\tpageToken\x1a?\n" +
+	"\x11MinResourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a?\n" +
+	"\x11MaxResourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"q\n" +
+	"\n" +
+	"PlanetPage\x12'\n" +
+	"\aplanets\x18\x01 \x03(\v2\r.proto.PlanetR\aplanets\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"\xc6\x02\n" +
+	"\bNPCQuery\x12\x18\n" +
+	"\afaction\x18\x01 \x01(\tR\afaction\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12\x1e\n" +
+	"\n" +
+	"minCredits\x18\x03 \x01(\x05R\n" +
+	"minCredits\x129\n" +
+	"\bminCargo\x18\x04 \x03(\v2\x1d.proto.NPCQuery.MinCargoEntryR\bminCargo\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x06 \x01(\bR\n" +
+	"descending\x12\x1a\n" +
+	"\bpageSize\x18\a \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\b \x01(\tR\tpageToken\x1a;\n" +
+	"\rMinCargoEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"e\n" +
+	"\aNPCPage\x12\x1e\n" +
+	"\x04npcs\x18\x01 \x03(\v2\n" +
+	".proto.NPCR\x04npcs\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"1\n" +
+	"\tEventList\x12$\n" +
+	"\x06events\x18\x01 \x03(\v2\f.proto.EventR\x06events\"r\n" +
+	"\bGameInfo\x12\x14\n" +
+	"\x05ticks\x18\x01 \x01(\x04R\x05ticks\x12\"\n" +
+	"\ftickDuration\x18\x02 \x01(\tR\ftickDuration\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\x03R\x04seed\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion2\xfa\b\n" +
 	"\x0fUniverseService\x12-\n" +
 	"\n" +
 	"GetPlanets\x12\f.proto.Empty\x1a\x11.proto.PlanetList\x12'\n" +
-	"\aGetNPCs\x12\f.proto.Empty\x1a\x0e.proto.NPCList\x12E\n" +
-	"\x13StreamUniverseState\x12\x14.proto.ClientCommand\x1a\x14.proto.UniverseState(\x010\x01B\x12Z\x10core/proto;protob\x06proto3"
+	"\aGetNPCs\x12\f.proto.Empty\x1a\x0e.proto.NPCList\x12/\n" +
+	"\vGetFactions\x12\f.proto.Empty\x1a\x12.proto.FactionList\x122\n" +
+	"\x0eGetTradeLedger\x12\f.proto.Empty\x1a\x12.proto.TradeLedger\x12E\n" +
+	"\x13StreamUniverseState\x12\x14.proto.ClientCommand\x1a\x14.proto.UniverseState(\x010\x01\x124\n" +
+	"\vControlGame\x12\x12.proto.GameControl\x1a\x11.proto.GameStatus\x120\n" +
+	"\rGetGameStatus\x12\f.proto.Empty\x1a\x11.proto.GameStatus\x121\n" +
+	"\fJoinUniverse\x12\x12.proto.JoinRequest\x1a\r.proto.Player\x12(\n" +
+	"\tGetPlayer\x12\f.proto.Empty\x1a\r.proto.Player\x122\n" +
+	"\fBuyResources\x12\x13.proto.TradeRequest\x1a\r.proto.Player\x123\n" +
+	"\rSellResources\x12\x13.proto.TradeRequest\x1a\r.proto.Player\x126\n" +
+	"\x10CollectResources\x12\x13.proto.TradeRequest\x1a\r.proto.Player\x124\n" +
+	"\x0eColonizePlanet\x12\x13.proto.BuildRequest\x1a\r.proto.Player\x123\n" +
+	"\rBuildOnPlanet\x12\x13.proto.BuildRequest\x1a\r.proto.Player\x12;\n" +
+	"\x0fStreamLogEvents\x12\x15.proto.LogEventFilter\x1a\x0f.proto.LogEvent0\x01\x123\n" +
+	"\n" +
+	"GetHistory\x12\x15.proto.HistoryRequest\x1a\x0e.proto.History\x120\n" +
+	"\tGetPlanet\x12\x14.proto.EntityRequest\x1a\r.proto.Planet\x12*\n" +
+	"\x06GetNPC\x12\x14.proto.EntityRequest\x1a\n" +
+	".proto.NPC\x124\n" +
+	"\vListPlanets\x12\x12.proto.PlanetQuery\x1a\x11.proto.PlanetPage\x12+\n" +
+	"\bListNPCs\x12\x0f.proto.NPCQuery\x1a\x0e.proto.NPCPage\x121\n" +
+	"\x0fGetActiveEvents\x12\f.proto.Empty\x1a\x10.proto.EventList\x12,\n" +
+	"\vGetGameInfo\x12\f.proto.Empty\x1a\x0f.proto.GameInfoB\x12Z\x10core/proto;protob\x06proto3"
 
 var (
 	file_core_proto_game_proto_rawDescOnce sync.Once
@@ -745,56 +2994,150 @@ func file_core_proto_game_proto_rawDescGZIP() []byte {
 	return file_core_proto_game_proto_rawDescData
 }
 
-var file_core_proto_game_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_core_proto_game_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_core_proto_game_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_core_proto_game_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_core_proto_game_proto_goTypes = []any{
 	(ClientCommand_CommandType)(0), // 0: proto.ClientCommand.CommandType
-	(*Empty)(nil),                  // 1: proto.Empty
-	(*PlanetList)(nil),             // 2: proto.PlanetList
-	(*NPCList)(nil),                // 3: proto.NPCList
-	(*Planet)(nil),                 // 4: proto.Planet
-	(*Building)(nil),               // 5: proto.Building
-	(*NPC)(nil),                    // 6: proto.NPC
-	(*UniverseState)(nil),          // 7: proto.UniverseState
-	(*Event)(nil),                  // 8: proto.Event
-	(*ClientCommand)(nil),          // 9: proto.ClientCommand
-	nil,                            // 10: proto.Planet.ResourcesEntry
-	nil,                            // 11: proto.Planet.ModifiersEntry
-	nil,                            // 12: proto.Building.ProductionEntry
-	nil,                            // 13: proto.Building.ModifiersEntry
-	nil,                            // 14: proto.Building.BuildCostEntry
-	nil,                            // 15: proto.NPC.OfferEntry
-	nil,                            // 16: proto.NPC.CargoEntry
-	nil,                            // 17: proto.Event.ResourceBoostEntry
+	(GameControl_Action)(0),        // 1: proto.GameControl.Action
+	(*Empty)(nil),                  // 2: proto.Empty
+	(*PlanetList)(nil),             // 3: proto.PlanetList
+	(*NPCList)(nil),                // 4: proto.NPCList
+	(*Planet)(nil),                 // 5: proto.Planet
+	(*Building)(nil),               // 6: proto.Building
+	(*NPC)(nil),                    // 7: proto.NPC
+	(*Player)(nil),                 // 8: proto.Player
+	(*JoinRequest)(nil),            // 9: proto.JoinRequest
+	(*TradeRequest)(nil),           // 10: proto.TradeRequest
+	(*BuildRequest)(nil),           // 11: proto.BuildRequest
+	(*TradeLedger)(nil),            // 12: proto.TradeLedger
+	(*LedgerEntry)(nil),            // 13: proto.LedgerEntry
+	(*FactionList)(nil),            // 14: proto.FactionList
+	(*Faction)(nil),                // 15: proto.Faction
+	(*UniverseState)(nil),          // 16: proto.UniverseState
+	(*BattleReport)(nil),           // 17: proto.BattleReport
+	(*LifecycleEvent)(nil),         // 18: proto.LifecycleEvent
+	(*ConfigChange)(nil),           // 19: proto.ConfigChange
+	(*Event)(nil),                  // 20: proto.Event
+	(*ClientCommand)(nil),          // 21: proto.ClientCommand
+	(*StateFilter)(nil),            // 22: proto.StateFilter
+	(*GameControl)(nil),            // 23: proto.GameControl
+	(*GameStatus)(nil),             // 24: proto.GameStatus
+	(*LogEventFilter)(nil),         // 25: proto.LogEventFilter
+	(*LogEvent)(nil),               // 26: proto.LogEvent
+	(*HistoryRequest)(nil),         // 27: proto.HistoryRequest
+	(*History)(nil),                // 28: proto.History
+	(*HistoryPoint)(nil),           // 29: proto.HistoryPoint
+	(*EntityRequest)(nil),          // 30: proto.EntityRequest
+	(*PlanetQuery)(nil),            // 31: proto.PlanetQuery
+	(*PlanetPage)(nil),             // 32: proto.PlanetPage
+	(*NPCQuery)(nil),               // 33: proto.NPCQuery
+	(*NPCPage)(nil),                // 34: proto.NPCPage
+	(*EventList)(nil),              // 35: proto.EventList
+	(*GameInfo)(nil),               // 36: proto.GameInfo
+	nil,                            // 37: proto.Planet.ResourcesEntry
+	nil,                            // 38: proto.Planet.ModifiersEntry
+	nil,                            // 39: proto.Building.ProductionEntry
+	nil,                            // 40: proto.Building.ModifiersEntry
+	nil,                            // 41: proto.Building.BuildCostEntry
+	nil,                            // 42: proto.NPC.OfferEntry
+	nil,                            // 43: proto.NPC.CargoEntry
+	nil,                            // 44: proto.Player.InventoryEntry
+	nil,                            // 45: proto.TradeLedger.CountsEntry
+	nil,                            // 46: proto.TradeLedger.CreditsEntry
+	nil,                            // 47: proto.Faction.RelationsEntry
+	nil,                            // 48: proto.Event.ResourceBoostEntry
+	nil,                            // 49: proto.LogEvent.AttributesEntry
+	nil,                            // 50: proto.PlanetQuery.MinResourcesEntry
+	nil,                            // 51: proto.PlanetQuery.MaxResourcesEntry
+	nil,                            // 52: proto.NPCQuery.MinCargoEntry
 }
 var file_core_proto_game_proto_depIdxs = []int32{
-	4,  // 0: proto.PlanetList.planets:type_name -> proto.Planet
-	6,  // 1: proto.NPCList.npcs:type_name -> proto.NPC
-	10, // 2: proto.Planet.resources:type_name -> proto.Planet.ResourcesEntry
-	11, // 3: proto.Planet.modifiers:type_name -> proto.Planet.ModifiersEntry
-	5,  // 4: proto.Planet.buildings:type_name -> proto.Building
-	6,  // 5: proto.Planet.owner:type_name -> proto.NPC
-	12, // 6: proto.Building.production:type_name -> proto.Building.ProductionEntry
-	13, // 7: proto.Building.modifiers:type_name -> proto.Building.ModifiersEntry
-	14, // 8: proto.Building.buildCost:type_name -> proto.Building.BuildCostEntry
-	15, // 9: proto.NPC.offer:type_name -> proto.NPC.OfferEntry
-	16, // 10: proto.NPC.cargo:type_name -> proto.NPC.CargoEntry
-	2,  // 11: proto.UniverseState.planets:type_name -> proto.PlanetList
-	3,  // 12: proto.UniverseState.npcs:type_name -> proto.NPCList
-	8,  // 13: proto.UniverseState.events:type_name -> proto.Event
-	17, // 14: proto.Event.resourceBoost:type_name -> proto.Event.ResourceBoostEntry
-	0,  // 15: proto.ClientCommand.type:type_name -> proto.ClientCommand.CommandType
-	1,  // 16: proto.UniverseService.GetPlanets:input_type -> proto.Empty
-	1,  // 17: proto.UniverseService.GetNPCs:input_type -> proto.Empty
-	9,  // 18: proto.UniverseService.StreamUniverseState:input_type -> proto.ClientCommand
-	2,  // 19: proto.UniverseService.GetPlanets:output_type -> proto.PlanetList
-	3,  // 20: proto.UniverseService.GetNPCs:output_type -> proto.NPCList
-	7,  // 21: proto.UniverseService.StreamUniverseState:output_type -> proto.UniverseState
-	19, // [19:22] is the sub-list for method output_type
-	16, // [16:19] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	5,  // 0: proto.PlanetList.planets:type_name -> proto.Planet
+	7,  // 1: proto.NPCList.npcs:type_name -> proto.NPC
+	37, // 2: proto.Planet.resources:type_name -> proto.Planet.ResourcesEntry
+	38, // 3: proto.Planet.modifiers:type_name -> proto.Planet.ModifiersEntry
+	6,  // 4: proto.Planet.buildings:type_name -> proto.Building
+	7,  // 5: proto.Planet.owner:type_name -> proto.NPC
+	39, // 6: proto.Building.production:type_name -> proto.Building.ProductionEntry
+	40, // 7: proto.Building.modifiers:type_name -> proto.Building.ModifiersEntry
+	41, // 8: proto.Building.buildCost:type_name -> proto.Building.BuildCostEntry
+	42, // 9: proto.NPC.offer:type_name -> proto.NPC.OfferEntry
+	43, // 10: proto.NPC.cargo:type_name -> proto.NPC.CargoEntry
+	44, // 11: proto.Player.inventory:type_name -> proto.Player.InventoryEntry
+	13, // 12: proto.TradeLedger.entries:type_name -> proto.LedgerEntry
+	45, // 13: proto.TradeLedger.counts:type_name -> proto.TradeLedger.CountsEntry
+	46, // 14: proto.TradeLedger.credits:type_name -> proto.TradeLedger.CreditsEntry
+	15, // 15: proto.FactionList.factions:type_name -> proto.Faction
+	47, // 16: proto.Faction.relations:type_name -> proto.Faction.RelationsEntry
+	3,  // 17: proto.UniverseState.planets:type_name -> proto.PlanetList
+	4,  // 18: proto.UniverseState.npcs:type_name -> proto.NPCList
+	20, // 19: proto.UniverseState.events:type_name -> proto.Event
+	19, // 20: proto.UniverseState.configChange:type_name -> proto.ConfigChange
+	18, // 21: proto.UniverseState.lifecycleEvents:type_name -> proto.LifecycleEvent
+	17, // 22: proto.UniverseState.battleReports:type_name -> proto.BattleReport
+	8,  // 23: proto.UniverseState.player:type_name -> proto.Player
+	7,  // 24: proto.LifecycleEvent.npc:type_name -> proto.NPC
+	48, // 25: proto.Event.resourceBoost:type_name -> proto.Event.ResourceBoostEntry
+	0,  // 26: proto.ClientCommand.type:type_name -> proto.ClientCommand.CommandType
+	22, // 27: proto.ClientCommand.filter:type_name -> proto.StateFilter
+	1,  // 28: proto.GameControl.action:type_name -> proto.GameControl.Action
+	49, // 29: proto.LogEvent.attributes:type_name -> proto.LogEvent.AttributesEntry
+	29, // 30: proto.History.points:type_name -> proto.HistoryPoint
+	50, // 31: proto.PlanetQuery.minResources:type_name -> proto.PlanetQuery.MinResourcesEntry
+	51, // 32: proto.PlanetQuery.maxResources:type_name -> proto.PlanetQuery.MaxResourcesEntry
+	5,  // 33: proto.PlanetPage.planets:type_name -> proto.Planet
+	52, // 34: proto.NPCQuery.minCargo:type_name -> proto.NPCQuery.MinCargoEntry
+	7,  // 35: proto.NPCPage.npcs:type_name -> proto.NPC
+	20, // 36: proto.EventList.events:type_name -> proto.Event
+	2,  // 37: proto.UniverseService.GetPlanets:input_type -> proto.Empty
+	2,  // 38: proto.UniverseService.GetNPCs:input_type -> proto.Empty
+	2,  // 39: proto.UniverseService.GetFactions:input_type -> proto.Empty
+	2,  // 40: proto.UniverseService.GetTradeLedger:input_type -> proto.Empty
+	21, // 41: proto.UniverseService.StreamUniverseState:input_type -> proto.ClientCommand
+	23, // 42: proto.UniverseService.ControlGame:input_type -> proto.GameControl
+	2,  // 43: proto.UniverseService.GetGameStatus:input_type -> proto.Empty
+	9,  // 44: proto.UniverseService.JoinUniverse:input_type -> proto.JoinRequest
+	2,  // 45: proto.UniverseService.GetPlayer:input_type -> proto.Empty
+	10, // 46: proto.UniverseService.BuyResources:input_type -> proto.TradeRequest
+	10, // 47: proto.UniverseService.SellResources:input_type -> proto.TradeRequest
+	10, // 48: proto.UniverseService.CollectResources:input_type -> proto.TradeRequest
+	11, // 49: proto.UniverseService.ColonizePlanet:input_type -> proto.BuildRequest
+	11, // 50: proto.UniverseService.BuildOnPlanet:input_type -> proto.BuildRequest
+	25, // 51: proto.UniverseService.StreamLogEvents:input_type -> proto.LogEventFilter
+	27, // 52: proto.UniverseService.GetHistory:input_type -> proto.HistoryRequest
+	30, // 53: proto.UniverseService.GetPlanet:input_type -> proto.EntityRequest
+	30, // 54: proto.UniverseService.GetNPC:input_type -> proto.EntityRequest
+	31, // 55: proto.UniverseService.ListPlanets:input_type -> proto.PlanetQuery
+	33, // 56: proto.UniverseService.ListNPCs:input_type -> proto.NPCQuery
+	2,  // 57: proto.UniverseService.GetActiveEvents:input_type -> proto.Empty
+	2,  // 58: proto.UniverseService.GetGameInfo:input_type -> proto.Empty
+	3,  // 59: proto.UniverseService.GetPlanets:output_type -> proto.PlanetList
+	4,  // 60: proto.UniverseService.GetNPCs:output_type -> proto.NPCList
+	14, // 61: proto.UniverseService.GetFactions:output_type -> proto.FactionList
+	12, // 62: proto.UniverseService.GetTradeLedger:output_type -> proto.TradeLedger
+	16, // 63: proto.UniverseService.StreamUniverseState:output_type -> proto.UniverseState
+	24, // 64: proto.UniverseService.ControlGame:output_type -> proto.GameStatus
+	24, // 65: proto.UniverseService.GetGameStatus:output_type -> proto.GameStatus
+	8,  // 66: proto.UniverseService.JoinUniverse:output_type -> proto.Player
+	8,  // 67: proto.UniverseService.GetPlayer:output_type -> proto.Player
+	8,  // 68: proto.UniverseService.BuyResources:output_type -> proto.Player
+	8,  // 69: proto.UniverseService.SellResources:output_type -> proto.Player
+	8,  // 70: proto.UniverseService.CollectResources:output_type -> proto.Player
+	8,  // 71: proto.UniverseService.ColonizePlanet:output_type -> proto.Player
+	8,  // 72: proto.UniverseService.BuildOnPlanet:output_type -> proto.Player
+	26, // 73: proto.UniverseService.StreamLogEvents:output_type -> proto.LogEvent
+	28, // 74: proto.UniverseService.GetHistory:output_type -> proto.History
+	5,  // 75: proto.UniverseService.GetPlanet:output_type -> proto.Planet
+	7,  // 76: proto.UniverseService.GetNPC:output_type -> proto.NPC
+	32, // 77: proto.UniverseService.ListPlanets:output_type -> proto.PlanetPage
+	34, // 78: proto.UniverseService.ListNPCs:output_type -> proto.NPCPage
+	35, // 79: proto.UniverseService.GetActiveEvents:output_type -> proto.EventList
+	36, // 80: proto.UniverseService.GetGameInfo:output_type -> proto.GameInfo
+	59, // [59:81] is the sub-list for method output_type
+	37, // [37:59] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_core_proto_game_proto_init() }
@@ -807,8 +3150,8 @@ func file_core_proto_game_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_proto_game_proto_rawDesc), len(file_core_proto_game_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   17,
+			NumEnums:      2,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
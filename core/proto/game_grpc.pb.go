@@ -21,7 +21,26 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	UniverseService_GetPlanets_FullMethodName          = "/proto.UniverseService/GetPlanets"
 	UniverseService_GetNPCs_FullMethodName             = "/proto.UniverseService/GetNPCs"
+	UniverseService_GetFactions_FullMethodName         = "/proto.UniverseService/GetFactions"
+	UniverseService_GetTradeLedger_FullMethodName      = "/proto.UniverseService/GetTradeLedger"
 	UniverseService_StreamUniverseState_FullMethodName = "/proto.UniverseService/StreamUniverseState"
+	UniverseService_ControlGame_FullMethodName         = "/proto.UniverseService/ControlGame"
+	UniverseService_GetGameStatus_FullMethodName       = "/proto.UniverseService/GetGameStatus"
+	UniverseService_JoinUniverse_FullMethodName        = "/proto.UniverseService/JoinUniverse"
+	UniverseService_GetPlayer_FullMethodName           = "/proto.UniverseService/GetPlayer"
+	UniverseService_BuyResources_FullMethodName        = "/proto.UniverseService/BuyResources"
+	UniverseService_SellResources_FullMethodName       = "/proto.UniverseService/SellResources"
+	UniverseService_CollectResources_FullMethodName    = "/proto.UniverseService/CollectResources"
+	UniverseService_ColonizePlanet_FullMethodName      = "/proto.UniverseService/ColonizePlanet"
+	UniverseService_BuildOnPlanet_FullMethodName       = "/proto.UniverseService/BuildOnPlanet"
+	UniverseService_StreamLogEvents_FullMethodName     = "/proto.UniverseService/StreamLogEvents"
+	UniverseService_GetHistory_FullMethodName          = "/proto.UniverseService/GetHistory"
+	UniverseService_GetPlanet_FullMethodName           = "/proto.UniverseService/GetPlanet"
+	UniverseService_GetNPC_FullMethodName              = "/proto.UniverseService/GetNPC"
+	UniverseService_ListPlanets_FullMethodName         = "/proto.UniverseService/ListPlanets"
+	UniverseService_ListNPCs_FullMethodName            = "/proto.UniverseService/ListNPCs"
+	UniverseService_GetActiveEvents_FullMethodName     = "/proto.UniverseService/GetActiveEvents"
+	UniverseService_GetGameInfo_FullMethodName         = "/proto.UniverseService/GetGameInfo"
 )
 
 // UniverseServiceClient is the client API for UniverseService service.
@@ -30,7 +49,26 @@ const (
 type UniverseServiceClient interface {
 	GetPlanets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PlanetList, error)
 	GetNPCs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NPCList, error)
+	GetFactions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FactionList, error)
+	GetTradeLedger(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TradeLedger, error)
 	StreamUniverseState(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ClientCommand, UniverseState], error)
+	ControlGame(ctx context.Context, in *GameControl, opts ...grpc.CallOption) (*GameStatus, error)
+	GetGameStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GameStatus, error)
+	JoinUniverse(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*Player, error)
+	GetPlayer(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Player, error)
+	BuyResources(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*Player, error)
+	SellResources(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*Player, error)
+	CollectResources(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*Player, error)
+	ColonizePlanet(ctx context.Context, in *BuildRequest, opts ...grpc.CallOption) (*Player, error)
+	BuildOnPlanet(ctx context.Context, in *BuildRequest, opts ...grpc.CallOption) (*Player, error)
+	StreamLogEvents(ctx context.Context, in *LogEventFilter, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEvent], error)
+	GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*History, error)
+	GetPlanet(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*Planet, error)
+	GetNPC(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*NPC, error)
+	ListPlanets(ctx context.Context, in *PlanetQuery, opts ...grpc.CallOption) (*PlanetPage, error)
+	ListNPCs(ctx context.Context, in *NPCQuery, opts ...grpc.CallOption) (*NPCPage, error)
+	GetActiveEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EventList, error)
+	GetGameInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GameInfo, error)
 }
 
 type universeServiceClient struct {
@@ -61,6 +99,26 @@ func (c *universeServiceClient) GetNPCs(ctx context.Context, in *Empty, opts ...
 	return out, nil
 }
 
+func (c *universeServiceClient) GetFactions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FactionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FactionList)
+	err := c.cc.Invoke(ctx, UniverseService_GetFactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) GetTradeLedger(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TradeLedger, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TradeLedger)
+	err := c.cc.Invoke(ctx, UniverseService_GetTradeLedger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *universeServiceClient) StreamUniverseState(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ClientCommand, UniverseState], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &UniverseService_ServiceDesc.Streams[0], UniverseService_StreamUniverseState_FullMethodName, cOpts...)
@@ -74,13 +132,211 @@ func (c *universeServiceClient) StreamUniverseState(ctx context.Context, opts ..
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type UniverseService_StreamUniverseStateClient = grpc.BidiStreamingClient[ClientCommand, UniverseState]
 
+func (c *universeServiceClient) ControlGame(ctx context.Context, in *GameControl, opts ...grpc.CallOption) (*GameStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameStatus)
+	err := c.cc.Invoke(ctx, UniverseService_ControlGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) GetGameStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GameStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameStatus)
+	err := c.cc.Invoke(ctx, UniverseService_GetGameStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) JoinUniverse(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, UniverseService_JoinUniverse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) GetPlayer(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, UniverseService_GetPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) BuyResources(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, UniverseService_BuyResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) SellResources(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, UniverseService_SellResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) CollectResources(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, UniverseService_CollectResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) ColonizePlanet(ctx context.Context, in *BuildRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, UniverseService_ColonizePlanet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) BuildOnPlanet(ctx context.Context, in *BuildRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, UniverseService_BuildOnPlanet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) StreamLogEvents(ctx context.Context, in *LogEventFilter, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UniverseService_ServiceDesc.Streams[1], UniverseService_StreamLogEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogEventFilter, LogEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UniverseService_StreamLogEventsClient = grpc.ServerStreamingClient[LogEvent]
+
+func (c *universeServiceClient) GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*History, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(History)
+	err := c.cc.Invoke(ctx, UniverseService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) GetPlanet(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*Planet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Planet)
+	err := c.cc.Invoke(ctx, UniverseService_GetPlanet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) GetNPC(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*NPC, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NPC)
+	err := c.cc.Invoke(ctx, UniverseService_GetNPC_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) ListPlanets(ctx context.Context, in *PlanetQuery, opts ...grpc.CallOption) (*PlanetPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanetPage)
+	err := c.cc.Invoke(ctx, UniverseService_ListPlanets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) ListNPCs(ctx context.Context, in *NPCQuery, opts ...grpc.CallOption) (*NPCPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NPCPage)
+	err := c.cc.Invoke(ctx, UniverseService_ListNPCs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) GetActiveEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EventList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventList)
+	err := c.cc.Invoke(ctx, UniverseService_GetActiveEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeServiceClient) GetGameInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GameInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameInfo)
+	err := c.cc.Invoke(ctx, UniverseService_GetGameInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UniverseServiceServer is the server API for UniverseService service.
 // All implementations must embed UnimplementedUniverseServiceServer
 // for forward compatibility.
 type UniverseServiceServer interface {
 	GetPlanets(context.Context, *Empty) (*PlanetList, error)
 	GetNPCs(context.Context, *Empty) (*NPCList, error)
+	GetFactions(context.Context, *Empty) (*FactionList, error)
+	GetTradeLedger(context.Context, *Empty) (*TradeLedger, error)
 	StreamUniverseState(grpc.BidiStreamingServer[ClientCommand, UniverseState]) error
+	ControlGame(context.Context, *GameControl) (*GameStatus, error)
+	GetGameStatus(context.Context, *Empty) (*GameStatus, error)
+	JoinUniverse(context.Context, *JoinRequest) (*Player, error)
+	GetPlayer(context.Context, *Empty) (*Player, error)
+	BuyResources(context.Context, *TradeRequest) (*Player, error)
+	SellResources(context.Context, *TradeRequest) (*Player, error)
+	CollectResources(context.Context, *TradeRequest) (*Player, error)
+	ColonizePlanet(context.Context, *BuildRequest) (*Player, error)
+	BuildOnPlanet(context.Context, *BuildRequest) (*Player, error)
+	StreamLogEvents(*LogEventFilter, grpc.ServerStreamingServer[LogEvent]) error
+	GetHistory(context.Context, *HistoryRequest) (*History, error)
+	GetPlanet(context.Context, *EntityRequest) (*Planet, error)
+	GetNPC(context.Context, *EntityRequest) (*NPC, error)
+	ListPlanets(context.Context, *PlanetQuery) (*PlanetPage, error)
+	ListNPCs(context.Context, *NPCQuery) (*NPCPage, error)
+	GetActiveEvents(context.Context, *Empty) (*EventList, error)
+	GetGameInfo(context.Context, *Empty) (*GameInfo, error)
 	mustEmbedUnimplementedUniverseServiceServer()
 }
 
@@ -97,9 +353,66 @@ func (UnimplementedUniverseServiceServer) GetPlanets(context.Context, *Empty) (*
 func (UnimplementedUniverseServiceServer) GetNPCs(context.Context, *Empty) (*NPCList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNPCs not implemented")
 }
+func (UnimplementedUniverseServiceServer) GetFactions(context.Context, *Empty) (*FactionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFactions not implemented")
+}
+func (UnimplementedUniverseServiceServer) GetTradeLedger(context.Context, *Empty) (*TradeLedger, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTradeLedger not implemented")
+}
 func (UnimplementedUniverseServiceServer) StreamUniverseState(grpc.BidiStreamingServer[ClientCommand, UniverseState]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamUniverseState not implemented")
 }
+func (UnimplementedUniverseServiceServer) ControlGame(context.Context, *GameControl) (*GameStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ControlGame not implemented")
+}
+func (UnimplementedUniverseServiceServer) GetGameStatus(context.Context, *Empty) (*GameStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGameStatus not implemented")
+}
+func (UnimplementedUniverseServiceServer) JoinUniverse(context.Context, *JoinRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinUniverse not implemented")
+}
+func (UnimplementedUniverseServiceServer) GetPlayer(context.Context, *Empty) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayer not implemented")
+}
+func (UnimplementedUniverseServiceServer) BuyResources(context.Context, *TradeRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuyResources not implemented")
+}
+func (UnimplementedUniverseServiceServer) SellResources(context.Context, *TradeRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SellResources not implemented")
+}
+func (UnimplementedUniverseServiceServer) CollectResources(context.Context, *TradeRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectResources not implemented")
+}
+func (UnimplementedUniverseServiceServer) ColonizePlanet(context.Context, *BuildRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ColonizePlanet not implemented")
+}
+func (UnimplementedUniverseServiceServer) BuildOnPlanet(context.Context, *BuildRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildOnPlanet not implemented")
+}
+func (UnimplementedUniverseServiceServer) StreamLogEvents(*LogEventFilter, grpc.ServerStreamingServer[LogEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogEvents not implemented")
+}
+func (UnimplementedUniverseServiceServer) GetHistory(context.Context, *HistoryRequest) (*History, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedUniverseServiceServer) GetPlanet(context.Context, *EntityRequest) (*Planet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlanet not implemented")
+}
+func (UnimplementedUniverseServiceServer) GetNPC(context.Context, *EntityRequest) (*NPC, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNPC not implemented")
+}
+func (UnimplementedUniverseServiceServer) ListPlanets(context.Context, *PlanetQuery) (*PlanetPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlanets not implemented")
+}
+func (UnimplementedUniverseServiceServer) ListNPCs(context.Context, *NPCQuery) (*NPCPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNPCs not implemented")
+}
+func (UnimplementedUniverseServiceServer) GetActiveEvents(context.Context, *Empty) (*EventList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveEvents not implemented")
+}
+func (UnimplementedUniverseServiceServer) GetGameInfo(context.Context, *Empty) (*GameInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGameInfo not implemented")
+}
 func (UnimplementedUniverseServiceServer) mustEmbedUnimplementedUniverseServiceServer() {}
 func (UnimplementedUniverseServiceServer) testEmbeddedByValue()                         {}
 
@@ -157,6 +470,42 @@ func _UniverseService_GetNPCs_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UniverseService_GetFactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetFactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetFactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetFactions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_GetTradeLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetTradeLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetTradeLedger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetTradeLedger(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UniverseService_StreamUniverseState_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(UniverseServiceServer).StreamUniverseState(&grpc.GenericServerStream[ClientCommand, UniverseState]{ServerStream: stream})
 }
@@ -164,6 +513,305 @@ func _UniverseService_StreamUniverseState_Handler(srv interface{}, stream grpc.S
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type UniverseService_StreamUniverseStateServer = grpc.BidiStreamingServer[ClientCommand, UniverseState]
 
+func _UniverseService_ControlGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameControl)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).ControlGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_ControlGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).ControlGame(ctx, req.(*GameControl))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_GetGameStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetGameStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetGameStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetGameStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_JoinUniverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).JoinUniverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_JoinUniverse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).JoinUniverse(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_GetPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetPlayer(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_BuyResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).BuyResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_BuyResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).BuyResources(ctx, req.(*TradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_SellResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).SellResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_SellResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).SellResources(ctx, req.(*TradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_CollectResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).CollectResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_CollectResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).CollectResources(ctx, req.(*TradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_ColonizePlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).ColonizePlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_ColonizePlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).ColonizePlanet(ctx, req.(*BuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_BuildOnPlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).BuildOnPlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_BuildOnPlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).BuildOnPlanet(ctx, req.(*BuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_StreamLogEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogEventFilter)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UniverseServiceServer).StreamLogEvents(m, &grpc.GenericServerStream[LogEventFilter, LogEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UniverseService_StreamLogEventsServer = grpc.ServerStreamingServer[LogEvent]
+
+func _UniverseService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_GetPlanet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetPlanet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetPlanet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetPlanet(ctx, req.(*EntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_GetNPC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetNPC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetNPC_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetNPC(ctx, req.(*EntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_ListPlanets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanetQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).ListPlanets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_ListPlanets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).ListPlanets(ctx, req.(*PlanetQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_ListNPCs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NPCQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).ListNPCs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_ListNPCs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).ListNPCs(ctx, req.(*NPCQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_GetActiveEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetActiveEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetActiveEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetActiveEvents(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UniverseService_GetGameInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServiceServer).GetGameInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UniverseService_GetGameInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServiceServer).GetGameInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UniverseService_ServiceDesc is the grpc.ServiceDesc for UniverseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -179,6 +827,78 @@ var UniverseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNPCs",
 			Handler:    _UniverseService_GetNPCs_Handler,
 		},
+		{
+			MethodName: "GetFactions",
+			Handler:    _UniverseService_GetFactions_Handler,
+		},
+		{
+			MethodName: "GetTradeLedger",
+			Handler:    _UniverseService_GetTradeLedger_Handler,
+		},
+		{
+			MethodName: "ControlGame",
+			Handler:    _UniverseService_ControlGame_Handler,
+		},
+		{
+			MethodName: "GetGameStatus",
+			Handler:    _UniverseService_GetGameStatus_Handler,
+		},
+		{
+			MethodName: "JoinUniverse",
+			Handler:    _UniverseService_JoinUniverse_Handler,
+		},
+		{
+			MethodName: "GetPlayer",
+			Handler:    _UniverseService_GetPlayer_Handler,
+		},
+		{
+			MethodName: "BuyResources",
+			Handler:    _UniverseService_BuyResources_Handler,
+		},
+		{
+			MethodName: "SellResources",
+			Handler:    _UniverseService_SellResources_Handler,
+		},
+		{
+			MethodName: "CollectResources",
+			Handler:    _UniverseService_CollectResources_Handler,
+		},
+		{
+			MethodName: "ColonizePlanet",
+			Handler:    _UniverseService_ColonizePlanet_Handler,
+		},
+		{
+			MethodName: "BuildOnPlanet",
+			Handler:    _UniverseService_BuildOnPlanet_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _UniverseService_GetHistory_Handler,
+		},
+		{
+			MethodName: "GetPlanet",
+			Handler:    _UniverseService_GetPlanet_Handler,
+		},
+		{
+			MethodName: "GetNPC",
+			Handler:    _UniverseService_GetNPC_Handler,
+		},
+		{
+			MethodName: "ListPlanets",
+			Handler:    _UniverseService_ListPlanets_Handler,
+		},
+		{
+			MethodName: "ListNPCs",
+			Handler:    _UniverseService_ListNPCs_Handler,
+		},
+		{
+			MethodName: "GetActiveEvents",
+			Handler:    _UniverseService_GetActiveEvents_Handler,
+		},
+		{
+			MethodName: "GetGameInfo",
+			Handler:    _UniverseService_GetGameInfo_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -187,6 +907,11 @@ var UniverseService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamLogEvents",
+			Handler:       _UniverseService_StreamLogEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "core/proto/game.proto",
 }
@@ -0,0 +1,369 @@
+package core
+
+import (
+	"cmp"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownNPC       = errors.New("unknown NPC")
+	ErrUnknownSortKey   = errors.New("unknown sort key")
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+// Page sizes of queries, if none or a larger one is requested.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// Sort keys of queries, resources and cargo are totals or, e.g. resources.Iron, amounts of a single resource.
+const (
+	NameSortKey      = "name"
+	ResourcesSortKey = "resources"
+	GarrisonSortKey  = "garrison"
+	BuildingsSortKey = "buildings" // number of buildings
+	CreditsSortKey   = "credits"
+	CargoSortKey     = "cargo"
+	ShipsSortKey     = "ships"
+	RevenueSortKey   = "revenue"
+)
+
+// PlanetQuery selects, sorts and pages planets. Empty filters match all planets.
+type PlanetQuery struct {
+	Types        []PlanetType         // planet has one of these types
+	Owner        string               // name of the NPC or player owning the planet
+	Unowned      bool                 // planet hasn't been colonized
+	Buildings    []BuildingType       // planet has buildings of all these types
+	MinResources map[ResourceType]int // planet has at least these amounts
+	MaxResources map[ResourceType]int // planet has at most these amounts
+	Page
+}
+
+// NPCQuery selects, sorts and pages NPCs. Empty filters match all NPCs.
+type NPCQuery struct {
+	Faction    string
+	Strategy   string
+	MinCredits int
+	MinCargo   map[ResourceType]int // NPC carries at least these amounts
+	Page
+}
+
+// Page selects a sorted page of query results. Results are sorted by name, unless another key is
+// passed, and by name if they're equal by that key. The token of the first page is empty.
+type Page struct {
+	Sort       string
+	Descending bool
+	Size       int
+	Token      string
+}
+
+// PlanetPage is a page of planets matching a query.
+type PlanetPage struct {
+	Planets       []*Planet
+	NextPageToken string // empty on the last page
+	Total         int    // planets matching the query
+}
+
+// NPCPage is a page of NPCs matching a query.
+type NPCPage struct {
+	NPCs          []*NPC
+	NextPageToken string
+	Total         int
+}
+
+// FindPlanet returns a copy of the planet with passed name.
+func (g *Game) FindPlanet(name string) (*Planet, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if p := g.planet(name); p != nil {
+		return p.clone(), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownPlanet, name)
+}
+
+// FindNPC returns a copy of the NPC with passed name.
+func (g *Game) FindNPC(name string) (*NPC, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, n := range g.NPCs {
+		if n.Name == name {
+			return n.clone(), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownNPC, name)
+}
+
+// QueryPlanets returns a page of copies of all planets matching passed query.
+func (g *Game) QueryPlanets(query PlanetQuery) (PlanetPage, error) {
+	key, err := planetSortKey(query.Sort)
+	if err != nil {
+		return PlanetPage{}, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	planets := []*Planet{}
+	for _, p := range g.Planets {
+		if query.matches(p) {
+			planets = append(planets, p)
+		}
+	}
+	sortByKey(planets, func(p *Planet) string { return p.Name }, key, query.Descending)
+
+	start, end, next, err := query.Page.bounds(len(planets))
+	if err != nil {
+		return PlanetPage{}, err
+	}
+	page := PlanetPage{Planets: make([]*Planet, 0, end-start), NextPageToken: next, Total: len(planets)}
+	for _, p := range planets[start:end] {
+		page.Planets = append(page.Planets, p.clone())
+	}
+	return page, nil
+}
+
+// QueryNPCs returns a page of copies of all NPCs matching passed query.
+func (g *Game) QueryNPCs(query NPCQuery) (NPCPage, error) {
+	key, err := npcSortKey(query.Sort)
+	if err != nil {
+		return NPCPage{}, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	npcs := []*NPC{}
+	for _, n := range g.NPCs {
+		if query.matches(n) {
+			npcs = append(npcs, n)
+		}
+	}
+	sortByKey(npcs, func(n *NPC) string { return n.Name }, key, query.Descending)
+
+	start, end, next, err := query.Page.bounds(len(npcs))
+	if err != nil {
+		return NPCPage{}, err
+	}
+	page := NPCPage{NPCs: make([]*NPC, 0, end-start), NextPageToken: next, Total: len(npcs)}
+	for _, n := range npcs[start:end] {
+		page.NPCs = append(page.NPCs, n.clone())
+	}
+	return page, nil
+}
+
+// FindActiveEvents returns copies of all active events.
+func (g *Game) FindActiveEvents() []*Event {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	events := make([]*Event, 0, len(g.ActiveEvents))
+	for _, e := range g.ActiveEvents {
+		events = append(events, e.clone())
+	}
+	return events
+}
+
+func (q PlanetQuery) matches(p *Planet) bool {
+	if len(q.Types) > 0 && !slices.Contains(q.Types, p.Type) {
+		return false
+	}
+	if q.Owner != "" && p.OwnerName() != q.Owner {
+		return false
+	}
+	if q.Unowned && IsPlanetColonized(p) {
+		return false
+	}
+	for _, buildingType := range q.Buildings {
+		if !slices.ContainsFunc(p.Buildings, func(b *Building) bool { return b.Type == buildingType }) {
+			return false
+		}
+	}
+	for res, amount := range q.MinResources {
+		if p.Resources[res] < amount {
+			return false
+		}
+	}
+	for res, amount := range q.MaxResources {
+		if p.Resources[res] > amount {
+			return false
+		}
+	}
+	return true
+}
+
+func (q NPCQuery) matches(n *NPC) bool {
+	if q.Faction != "" && (n.Faction == nil || n.Faction.Name != q.Faction) {
+		return false
+	}
+	if q.Strategy != "" && strategyName(n) != strings.ToLower(q.Strategy) {
+		return false
+	}
+	if n.Credits < q.MinCredits {
+		return false
+	}
+	for res, amount := range q.MinCargo {
+		if n.Cargo[res] < amount {
+			return false
+		}
+	}
+	return true
+}
+
+// strategyName returns the name of the strategy of an NPC, NPCs without strategy act randomly.
+func strategyName(n *NPC) string {
+	if n.Strategy == nil {
+		return RandomStrategyName
+	}
+	return n.Strategy.Name()
+}
+
+// planetSortKey returns the value planets are sorted by for passed sort key, nil to sort by name only.
+func planetSortKey(key string) (func(*Planet) int, error) {
+	switch key {
+	case "", NameSortKey:
+		return nil, nil
+	case ResourcesSortKey:
+		return func(p *Planet) int { return sumAmounts(p.Resources) }, nil
+	case GarrisonSortKey:
+		return func(p *Planet) int { return p.Garrison }, nil
+	case BuildingsSortKey:
+		return func(p *Planet) int { return len(p.Buildings) }, nil
+	}
+	if res, ok := resourceSortKey(key, ResourcesSortKey); ok {
+		return func(p *Planet) int { return p.Resources[res] }, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSortKey, key)
+}
+
+// npcSortKey returns the value NPCs are sorted by for passed sort key, nil to sort by name only.
+func npcSortKey(key string) (func(*NPC) int, error) {
+	switch key {
+	case "", NameSortKey:
+		return nil, nil
+	case CreditsSortKey:
+		return func(n *NPC) int { return n.Credits }, nil
+	case CargoSortKey:
+		return func(n *NPC) int { return sumAmounts(n.Cargo) }, nil
+	case ShipsSortKey:
+		return func(n *NPC) int { return n.Ships }, nil
+	case RevenueSortKey:
+		return func(n *NPC) int { return n.Revenue }, nil
+	}
+	if res, ok := resourceSortKey(key, CargoSortKey); ok {
+		return func(n *NPC) int { return n.Cargo[res] }, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSortKey, key)
+}
+
+// resourceSortKey returns the resource of a sort key for a single resource, e.g. Iron of resources.Iron.
+func resourceSortKey(key, prefix string) (ResourceType, bool) {
+	name, ok := strings.CutPrefix(key, prefix+".")
+	if !ok {
+		return 0, false
+	}
+	res := ResourceTypeFromString(name)
+	return res, slices.Contains(resourceTypes, res)
+}
+
+// sortByKey sorts items by passed key, if any, and by name.
+func sortByKey[T any](items []T, name func(T) string, key func(T) int, descending bool) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		c := 0
+		if key != nil {
+			c = cmp.Compare(key(a), key(b))
+		}
+		if c == 0 {
+			c = strings.Compare(name(a), name(b))
+		}
+		if descending {
+			return -c
+		}
+		return c
+	})
+}
+
+// bounds returns the range of the requested page within passed number of results, and the token of the next page.
+func (p Page) bounds(total int) (int, int, string, error) {
+	size := p.Size
+	switch {
+	case size <= 0:
+		size = DefaultPageSize
+	case size > MaxPageSize:
+		size = MaxPageSize
+	}
+	start := 0
+	if p.Token != "" {
+		offset, err := decodePageToken(p.Token)
+		if err != nil {
+			return 0, 0, "", err
+		}
+		start = min(offset, total)
+	}
+	end := min(start+size, total)
+	next := ""
+	if end < total {
+		next = encodePageToken(end)
+	}
+	return start, end, next, nil
+}
+
+// Page tokens are opaque to clients, they encode the offset of the page.
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	value, ok := strings.CutPrefix(string(data), "offset:")
+	if !ok {
+		return 0, ErrInvalidPageToken
+	}
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidPageToken
+	}
+	return offset, nil
+}
+
+// Queries return copies, which can be read while the game goes on. Copies share factions, strategies,
+// locations and targets with the game, whose names don't change.
+
+func (p *Planet) clone() *Planet {
+	c := *p
+	c.Resources = maps.Clone(p.Resources)
+	c.Modifiers = maps.Clone(p.Modifiers)
+	c.Buildings = make([]*Building, 0, len(p.Buildings))
+	for _, b := range p.Buildings {
+		building := *b
+		building.Production = maps.Clone(b.Production)
+		building.Modifiers = maps.Clone(b.Modifiers)
+		building.BuildCost = maps.Clone(b.BuildCost)
+		c.Buildings = append(c.Buildings, &building)
+	}
+	if p.Owner != nil {
+		c.Owner = p.Owner.clone()
+	}
+	if p.Player != nil {
+		player := p.Player.snapshot()
+		c.Player = &player
+	}
+	return &c
+}
+
+func (n *NPC) clone() *NPC {
+	c := *n
+	c.Offer = maps.Clone(n.Offer)
+	c.Cargo = maps.Clone(n.Cargo)
+	return &c
+}
+
+func (e *Event) clone() *Event {
+	c := *e
+	c.ResourceBoost = maps.Clone(e.ResourceBoost)
+	return &c
+}
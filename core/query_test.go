@@ -0,0 +1,169 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type QuerySuite struct {
+	suite.Suite
+	game *Game
+}
+
+func TestQuerySuite(t *testing.T) {
+	suite.Run(t, new(QuerySuite))
+}
+
+func (s *QuerySuite) SetupTest() {
+	trader := &NPC{Name: "Trader", Credits: 300, Cargo: map[ResourceType]int{Iron: 5}, Strategy: TraderStrategy{}, Ships: 2}
+	hoarder := &NPC{Name: "Hoarder", Credits: 100, Cargo: map[ResourceType]int{Food: 20}, Faction: &Faction{Name: "Guild"}}
+	planets := []*Planet{
+		{Name: "Vega-B", Type: Desert, Resources: map[ResourceType]int{Iron: 100, Food: 10}, Buildings: []*Building{{Type: Mine}}, Owner: trader},
+		{Name: "Aurora-A", Type: TerraLike, Resources: map[ResourceType]int{Iron: 50, Food: 80}, Buildings: []*Building{{Type: Farm}, {Type: Mine}}, Owner: hoarder},
+		{Name: "Luna-D", Type: Icy, Resources: map[ResourceType]int{Iron: 20}, Buildings: []*Building{}},
+		{Name: "Ceres-F", Type: Desert, Resources: map[ResourceType]int{Iron: 70}, Buildings: []*Building{}},
+	}
+	s.game = NewGameService(Config{TickDuration: time.Second}, &mockRand{}, &nopLog{}, planets, []*NPC{trader, hoarder})
+}
+
+func planetNames(planets []*Planet) []string {
+	names := []string{}
+	for _, p := range planets {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func (s *QuerySuite) TestFilterPlanets() {
+	page, err := s.game.QueryPlanets(PlanetQuery{Types: []PlanetType{Desert}})
+	s.Require().NoError(err)
+	s.Equal([]string{"Ceres-F", "Vega-B"}, planetNames(page.Planets))
+
+	page, err = s.game.QueryPlanets(PlanetQuery{Owner: "Hoarder"})
+	s.Require().NoError(err)
+	s.Equal([]string{"Aurora-A"}, planetNames(page.Planets))
+
+	page, err = s.game.QueryPlanets(PlanetQuery{Unowned: true, MinResources: map[ResourceType]int{Iron: 30}})
+	s.Require().NoError(err)
+	s.Equal([]string{"Ceres-F"}, planetNames(page.Planets))
+
+	page, err = s.game.QueryPlanets(PlanetQuery{Buildings: []BuildingType{Mine}, MaxResources: map[ResourceType]int{Food: 50}})
+	s.Require().NoError(err)
+	s.Equal([]string{"Vega-B"}, planetNames(page.Planets))
+}
+
+func (s *QuerySuite) TestSortPlanets() {
+	page, err := s.game.QueryPlanets(PlanetQuery{Page: Page{Sort: "resources.Iron", Descending: true}})
+	s.Require().NoError(err)
+	s.Equal([]string{"Vega-B", "Ceres-F", "Aurora-A", "Luna-D"}, planetNames(page.Planets))
+
+	page, err = s.game.QueryPlanets(PlanetQuery{Page: Page{Sort: BuildingsSortKey}})
+	s.Require().NoError(err)
+	s.Equal([]string{"Ceres-F", "Luna-D", "Vega-B", "Aurora-A"}, planetNames(page.Planets))
+
+	_, err = s.game.QueryPlanets(PlanetQuery{Page: Page{Sort: "treasury"}})
+	s.ErrorIs(err, ErrUnknownSortKey)
+	_, err = s.game.QueryPlanets(PlanetQuery{Page: Page{Sort: "resources.Gold"}})
+	s.ErrorIs(err, ErrUnknownSortKey)
+}
+
+func (s *QuerySuite) TestPagination() {
+	query := PlanetQuery{Page: Page{Size: 3}}
+	page, err := s.game.QueryPlanets(query)
+	s.Require().NoError(err)
+	s.Equal([]string{"Aurora-A", "Ceres-F", "Luna-D"}, planetNames(page.Planets))
+	s.Equal(4, page.Total)
+	s.Require().NotEmpty(page.NextPageToken)
+
+	query.Token = page.NextPageToken
+	page, err = s.game.QueryPlanets(query)
+	s.Require().NoError(err)
+	s.Equal([]string{"Vega-B"}, planetNames(page.Planets))
+	s.Empty(page.NextPageToken)
+
+	query.Token = "not-a-token"
+	_, err = s.game.QueryPlanets(query)
+	s.ErrorIs(err, ErrInvalidPageToken)
+}
+
+func (s *QuerySuite) TestPageSize() {
+	start, end, next, err := Page{}.bounds(120)
+	s.Require().NoError(err)
+	s.Equal([]int{0, DefaultPageSize}, []int{start, end})
+	s.NotEmpty(next)
+
+	_, end, next, err = Page{Size: 10000}.bounds(1000)
+	s.Require().NoError(err)
+	s.Equal(MaxPageSize, end)
+	s.Equal(encodePageToken(MaxPageSize), next)
+}
+
+func (s *QuerySuite) TestQueryNPCs() {
+	page, err := s.game.QueryNPCs(NPCQuery{Page: Page{Sort: CreditsSortKey, Descending: true}})
+	s.Require().NoError(err)
+	s.Equal("Trader", page.NPCs[0].Name)
+
+	page, err = s.game.QueryNPCs(NPCQuery{Faction: "Guild"})
+	s.Require().NoError(err)
+	s.Require().Len(page.NPCs, 1)
+	s.Equal("Hoarder", page.NPCs[0].Name)
+
+	page, err = s.game.QueryNPCs(NPCQuery{Strategy: "Random", MinCargo: map[ResourceType]int{Food: 10}})
+	s.Require().NoError(err)
+	s.Len(page.NPCs, 1)
+
+	page, err = s.game.QueryNPCs(NPCQuery{MinCredits: 500})
+	s.Require().NoError(err)
+	s.Empty(page.NPCs)
+	s.Equal(0, page.Total)
+}
+
+func (s *QuerySuite) TestFindEntities() {
+	p, err := s.game.FindPlanet("Luna-D")
+	s.Require().NoError(err)
+	s.Equal(Icy, p.Type)
+	_, err = s.game.FindPlanet("Rigel")
+	s.ErrorIs(err, ErrUnknownPlanet)
+
+	n, err := s.game.FindNPC("Trader")
+	s.Require().NoError(err)
+	s.Equal(300, n.Credits)
+	_, err = s.game.FindNPC("Nobody")
+	s.ErrorIs(err, ErrUnknownNPC)
+}
+
+func (s *QuerySuite) TestInfo() {
+	s.game.SetSeed(42)
+	s.game.tick(0)
+	info := s.game.Info()
+	s.Equal(GameInfo{Ticks: 1, TickDuration: time.Second, Seed: 42, Version: Version}, info)
+}
+
+func (s *QuerySuite) TestQueriesReturnCopies() {
+	planet, err := s.game.FindPlanet("Vega-B")
+	s.Require().NoError(err)
+	planet.Resources[Iron] = 0
+	planet.Buildings[0].Level = 5
+	planet.Owner.Credits = 0
+
+	page, err := s.game.QueryPlanets(PlanetQuery{})
+	s.Require().NoError(err)
+	page.Planets[0].Garrison = 10
+
+	npc, err := s.game.FindNPC("Trader")
+	s.Require().NoError(err)
+	npc.Cargo[Iron] = 0
+
+	s.game.ActiveEvents = []*Event{{Name: "Solar Flare", ResourceBoost: map[ResourceType]float64{Iron: 2}}}
+	s.game.FindActiveEvents()[0].ResourceBoost[Iron] = 1
+
+	vega := s.game.Planets[0]
+	s.Equal(100, vega.Resources[Iron])
+	s.Equal(0, vega.Buildings[0].Level)
+	s.Equal(300, vega.Owner.Credits)
+	s.Equal(5, vega.Owner.Cargo[Iron])
+	s.Equal(0, s.game.Planets[1].Garrison)
+	s.Equal(2.0, s.game.ActiveEvents[0].ResourceBoost[Iron])
+}
@@ -17,9 +17,40 @@ func NewBuiltInRand() Random {
 }
 
 func (r *BuiltInRand) OfRange(min, max int) int {
+	if max <= min {
+		return min
+	}
 	return rand.Intn(max-min) + min
 }
 
 func (r *BuiltInRand) OfIntRange(rng intRange) int {
-	return rand.Intn(rng.Max-rng.Min) + rng.Min
+	return r.OfRange(rng.Min, rng.Max)
+}
+
+// SeededRand is a Random backed by its own source, so a given seed always produces the same sequence.
+type SeededRand struct {
+	rand *rand.Rand
+}
+
+func NewSeededRand(seed int64) Random {
+	return &SeededRand{rand: rand.New(rand.NewSource(seed))}
+}
+
+func (r *SeededRand) Seek() float64 {
+	return r.rand.Float64()
+}
+
+func (r *SeededRand) Of(n int) int {
+	return r.rand.Intn(n)
+}
+
+func (r *SeededRand) OfRange(min, max int) int {
+	if max <= min {
+		return min
+	}
+	return r.rand.Intn(max-min) + min
+}
+
+func (r *SeededRand) OfIntRange(rng intRange) int {
+	return r.OfRange(rng.Min, rng.Max)
 }
@@ -40,9 +40,42 @@ func (s *BuiltInRandSuite) TestOfIntRange() {
 	s.True(val >= 10 && val < 15)
 }
 
+func (s *BuiltInRandSuite) TestEmptyRange() {
+	s.Equal(4, s.r.OfRange(4, 4))
+	s.Equal(7, s.r.OfIntRange(intRange{Min: 7, Max: 7}))
+	s.Equal(0, NewSeededRand(1).OfIntRange(intRange{Min: 0, Max: 0}))
+}
+
 func (s *BuiltInRandSuite) TestNewBuiltInRand() {
 	r := NewBuiltInRand()
 	s.NotNil(r)
 	_, ok := r.(*BuiltInRand)
 	s.True(ok)
 }
+
+type SeededRandSuite struct {
+	suite.Suite
+}
+
+func TestSeededRandSuite(t *testing.T) {
+	suite.Run(t, new(SeededRandSuite))
+}
+
+func (s *SeededRandSuite) TestSameSeedSameSequence() {
+	r1 := NewSeededRand(42)
+	r2 := NewSeededRand(42)
+	for i := 0; i < 10; i++ {
+		s.Equal(r1.Seek(), r2.Seek())
+		s.Equal(r1.Of(100), r2.Of(100))
+		s.Equal(r1.OfRange(5, 10), r2.OfRange(5, 10))
+		s.Equal(r1.OfIntRange(intRange{Min: 1, Max: 3}), r2.OfIntRange(intRange{Min: 1, Max: 3}))
+	}
+}
+
+func (s *SeededRandSuite) TestRanges() {
+	r := NewSeededRand(1)
+	val := r.OfRange(2, 6)
+	s.True(val >= 2 && val < 6)
+	val = r.OfIntRange(intRange{Min: 10, Max: 15})
+	s.True(val >= 10 && val < 15)
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"time"
+)
+
+// ConfigReport lists all changes of a config reload.
+type ConfigReport struct {
+	Applied  []string // changes in effect from the next tick on
+	Rejected []string // changes which only take effect in a new universe and have been ignored
+}
+
+// HasChanges returns true if a reload contained any applied or rejected change.
+func (r ConfigReport) HasChanges() bool {
+	return len(r.Applied) > 0 || len(r.Rejected) > 0
+}
+
+// ReloadConfig validates passed config and applies all balance values which are safe to change
+// in a running universe: tick duration, catch-up policy, build costs, building chances,
+// production ranges for new buildings, the event config, taxes, NPC lifecycle rules, conflict,
+// piracy and player rules.
+// Build costs of existing buildings are updated as well. Changes of values which only define the initial universe are rejected.
+// The reload happens between two ticks. Each reload with changes is sent to stream clients.
+func (g *Game) ReloadConfig(next Config) (ConfigReport, error) {
+
+	if err := next.Validate(); err != nil {
+		return ConfigReport{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	g.mu.Lock()
+	report := diffConfig(g.config, next)
+	tickDurationChanged := g.config.TickDuration != next.TickDuration
+	buildCostsChanged := !reflect.DeepEqual(g.config.SeedConfig.BuildCosts, next.SeedConfig.BuildCosts)
+
+	g.config.TickDuration = next.TickDuration
+	g.config.CatchUpPolicy = next.CatchUpPolicy
+	g.config.MaxCatchUpTicks = next.MaxCatchUpTicks
+	g.config.SeedConfig.BuildCosts = next.SeedConfig.BuildCosts
+	g.config.SeedConfig.BuildingChance = next.SeedConfig.BuildingChance
+	g.config.SeedConfig.Production = next.SeedConfig.Production
+	g.config.Events = next.Events
+	g.config.Taxes = next.Taxes
+	g.config.Lifecycle = next.Lifecycle
+	g.config.Conflict = next.Conflict
+	g.config.Piracy = next.Piracy
+	g.config.Players = next.Players
+	if buildCostsChanged {
+		g.updateBuildCosts()
+	}
+	if len(report.Applied) > 0 {
+		// the journal records the config in effect, without rejected changes
+		applied := g.config
+		g.journalAction(JournalEntry{Type: ConfigEntry, Config: &applied})
+	}
+	if report.HasChanges() {
+		g.publish(stateUpdate{tick: g.metrics.Ticks, config: configReportToProto(report)})
+	}
+	g.mu.Unlock()
+
+	if tickDurationChanged {
+		g.notifyControlChange()
+	}
+	return report, nil
+}
+
+// WatchConfig loads config in passed interval and reloads it if it differs from the last one,
+// until passed context is canceled. Rejected changes are reported once, not on each load.
+func (g *Game) WatchConfig(ctx context.Context, interval time.Duration, load func() (Config, error)) {
+
+	g.mu.Lock()
+	last := g.config
+	g.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			g.log.Info("Config watch canceled via context.")
+			return
+		case <-ticker.C:
+			next, err := load()
+			if err != nil {
+				g.log.Error("Unable to load config: %v", err)
+				continue
+			}
+			if reflect.DeepEqual(last, next) {
+				continue
+			}
+			last = next
+			report, err := g.ReloadConfig(next)
+			if err != nil {
+				g.log.Error("Config reload rejected: %v", err)
+				continue
+			}
+			for _, change := range report.Applied {
+				g.log.Info("Config change applied: %s", change)
+			}
+			for _, change := range report.Rejected {
+				g.log.Error("Config change rejected: %s", change)
+			}
+		}
+	}
+}
+
+func (g *Game) updateBuildCosts() {
+	for _, p := range g.Planets {
+		for _, b := range p.Buildings {
+			if costs, ok := g.config.SeedConfig.BuildCosts[b.Type]; ok {
+				b.BuildCost = maps.Clone(costs)
+			}
+		}
+	}
+}
+
+// diffConfig lists all changed values, with values used for new buildings or in each tick as applied
+// and values used to create a universe as rejected.
+func diffConfig(current, next Config) ConfigReport {
+
+	report := ConfigReport{}
+	compare := func(key string, from, to any, live bool) {
+		if reflect.DeepEqual(from, to) {
+			return
+		}
+		if live {
+			report.Applied = append(report.Applied, fmt.Sprintf("%s: %v -> %v", key, from, to))
+		} else {
+			report.Rejected = append(report.Rejected, fmt.Sprintf("%s: %v -> %v requires a restart", key, from, to))
+		}
+	}
+
+	compare("tick_duration", current.TickDuration, next.TickDuration, true)
+	compare("catch_up_policy", current.CatchUpPolicy, next.CatchUpPolicy, true)
+	compare("max_catch_up_ticks", current.MaxCatchUpTicks, next.MaxCatchUpTicks, true)
+	compare("scenario_file", current.ScenarioFile, next.ScenarioFile, false)
+	compare("ledger_size", current.LedgerSize, next.LedgerSize, false)
+	compare("resume_buffer", current.ResumeBuffer, next.ResumeBuffer, false)
+
+	seed, nextSeed := current.SeedConfig, next.SeedConfig
+	compare("universe_seed.number_of_planets", seed.NumberOfPlanets, nextSeed.NumberOfPlanets, false)
+	compare("universe_seed.resources", seed.Resources, nextSeed.Resources, false)
+	compare("universe_seed.building_chance", seed.BuildingChance, nextSeed.BuildingChance, true)
+	compare("universe_seed.build_costs", seed.BuildCosts, nextSeed.BuildCosts, true)
+	compare("universe_seed.production", seed.Production, nextSeed.Production, true)
+	compare("universe_seed.npc", seed.MPCConfig, nextSeed.MPCConfig, false)
+
+	compare("events.base_chance", current.Events.BaseChance, next.Events.BaseChance, true)
+	compare("events.duration", current.Events.Duration, next.Events.Duration, true)
+	compare("events.chance_multipliers", current.Events.ChanceMultipliers, next.Events.ChanceMultipliers, true)
+
+	compare("taxes.production_tax", current.Taxes.ProductionTax, next.Taxes.ProductionTax, true)
+	compare("taxes.payout_rate", current.Taxes.PayoutRate, next.Taxes.PayoutRate, true)
+	compare("lifecycle", current.Lifecycle, next.Lifecycle, true)
+	compare("conflict", current.Conflict, next.Conflict, true)
+	compare("piracy", current.Piracy, next.Piracy, true)
+	compare("players", current.Players, next.Players, true)
+
+	return report
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReloadSuite struct {
+	suite.Suite
+	game    *Game
+	mine    *Building
+	log     *mockLog
+	updates <-chan stateUpdate
+}
+
+func TestReloadSuite(t *testing.T) {
+	suite.Run(t, new(ReloadSuite))
+}
+
+func (s *ReloadSuite) SetupTest() {
+	s.log = &mockLog{}
+	s.mine = &Building{
+		Type:       Mine,
+		Level:      1,
+		Production: map[ResourceType]int{Iron: 2},
+		Modifiers:  map[ResourceType]float64{Iron: 1.0},
+		BuildCost:  DefaultBuildCost()[Mine],
+	}
+	planets := []*Planet{
+		{
+			Name:      "TestPlanet",
+			Type:      TerraLike,
+			Resources: map[ResourceType]int{Iron: 10},
+			Modifiers: map[ResourceType]float64{},
+			Buildings: []*Building{s.mine},
+		},
+	}
+	s.game = NewGameService(DefaultConfig(), &mockRand{seekVal: 0.9}, s.log, planets, []*NPC{})
+	s.updates, _ = s.game.subscribeUpdates()
+}
+
+func (s *ReloadSuite) TestReloadAppliesBalanceChanges() {
+	next := DefaultConfig()
+	next.TickDuration = time.Second
+	next.SeedConfig.BuildCosts[Mine] = map[ResourceType]int{Iron: 99}
+	next.Events.BaseChance = 0.1
+	next.Taxes.ProductionTax = 2
+
+	report, err := s.game.ReloadConfig(next)
+	s.NoError(err)
+	s.Len(report.Applied, 4)
+	s.Empty(report.Rejected)
+	s.Equal(time.Second, s.game.Status().TickInterval)
+	s.Equal(0.1, s.game.config.Events.BaseChance)
+	s.Equal(2.0, s.game.config.Taxes.ProductionTax)
+	s.Equal(99, s.mine.BuildCost[Iron])
+	s.Equal(configReportToProto(report), (<-s.updates).config)
+
+	// buildings don't share their costs with each other or the config
+	s.mine.BuildCost[Iron] = 1
+	s.Equal(99, s.game.config.SeedConfig.BuildCosts[Mine][Iron])
+}
+
+func (s *ReloadSuite) TestReloadRejectsUniverseChanges() {
+	next := DefaultConfig()
+	next.SeedConfig.NumberOfPlanets = intRange{Min: 1, Max: 2}
+	next.ScenarioFile = "fixtures/scenario.yml"
+	next.SeedConfig.Production = intRange{Min: 1, Max: 2}
+
+	report, err := s.game.ReloadConfig(next)
+	s.NoError(err)
+	s.Len(report.Applied, 1)
+	s.Len(report.Rejected, 2)
+	s.Contains(report.Rejected[0], "requires a restart")
+	s.Equal(DefaultSeedConfig().NumberOfPlanets, s.game.config.SeedConfig.NumberOfPlanets)
+	s.Empty(s.game.config.ScenarioFile)
+	s.Equal(intRange{Min: 1, Max: 2}, s.game.config.SeedConfig.Production)
+}
+
+func (s *ReloadSuite) TestReloadInvalidConfig() {
+	next := DefaultConfig()
+	next.TickDuration = 0
+
+	_, err := s.game.ReloadConfig(next)
+	s.Error(err)
+	s.Equal(2*time.Second, s.game.config.TickDuration)
+	s.Empty(s.updates)
+}
+
+func (s *ReloadSuite) TestReloadWithoutChanges() {
+	report, err := s.game.ReloadConfig(DefaultConfig())
+	s.NoError(err)
+	s.False(report.HasChanges())
+	s.Empty(s.updates)
+}
+
+func (s *ReloadSuite) TestWatchConfig() {
+	next := DefaultConfig()
+	next.TickDuration = time.Second
+	next.SeedConfig.NumberOfPlanets = intRange{Min: 1, Max: 2}
+	// the first load is reloaded, the second one is unchanged and has to be skipped
+	loads := 0
+	reloaded := make(chan struct{})
+	load := func() (Config, error) {
+		loads++
+		if loads == 2 {
+			close(reloaded)
+		}
+		return next, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.game.WatchConfig(ctx, time.Millisecond, load)
+		close(done)
+	}()
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		s.Fail("config hasn't been loaded twice")
+	}
+	cancel()
+	<-done
+
+	s.GreaterOrEqual(loads, 2)
+	s.Len(s.updates, 1)
+	s.Equal(time.Second, s.game.Status().TickInterval)
+	s.Len(s.log.errors, 1)
+}
@@ -0,0 +1,269 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+var ErrNoSnapshot = errors.New("no snapshot to replay from")
+
+// Replay rebuilds a game from a snapshot and applies the entries of its journal, tick by tick.
+// Random numbers are taken from the journal, so replayed state only differs from the state of
+// the journaled game if the simulation isn't deterministic.
+type Replay struct {
+	Game     *Game
+	dir      string
+	random   *replayRandom
+	clock    *replayClock
+	segments []uint64 // start ticks of segments not read yet
+	file     *os.File
+	scanner  *bufio.Scanner
+}
+
+// ReplayStep is the outcome of replaying one tick and the actions applied before it.
+type ReplayStep struct {
+	Tick    uint64
+	Entries []JournalEntry
+	Diffs   []string // entities whose replayed state differs from the journaled one, and other divergences
+}
+
+// Diverged returns true if the replayed tick differs from the journaled one.
+func (s ReplayStep) Diverged() bool {
+	return len(s.Diffs) > 0
+}
+
+// OpenReplay restores the latest snapshot in passed journal directory which has been taken at, or before,
+// passed tick. Steps replay the journal from the tick of the snapshot on.
+func OpenReplay(dir string, from uint64, log Log) (*Replay, error) {
+	snapshots, err := journalFiles(dir, snapshotPattern)
+	if err != nil {
+		return nil, err
+	}
+	start := -1
+	for i, tick := range snapshots {
+		if tick <= from {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("%w: %s has no snapshot at or before tick %d", ErrNoSnapshot, dir, from)
+	}
+	snapshot, err := ReadSnapshotFile(snapshotFile(dir, snapshots[start]))
+	if err != nil {
+		return nil, err
+	}
+
+	random := &replayRandom{}
+	game, err := RestoreGame(snapshot, random, log)
+	if err != nil {
+		return nil, err
+	}
+	clock := &replayClock{now: snapshot.Time}
+	game.clock = clock
+
+	segments, err := journalFiles(dir, segmentPattern)
+	if err != nil {
+		return nil, err
+	}
+	replay := &Replay{Game: game, dir: dir, random: random, clock: clock}
+	for _, tick := range segments {
+		if tick >= snapshot.Tick {
+			replay.segments = append(replay.segments, tick)
+		}
+	}
+	return replay, nil
+}
+
+// Step applies all journaled actions up to the next tick and the tick itself, and compares the state
+// afterwards with the journaled one. Returns io.EOF if the journal has no further ticks, actions
+// journaled after the last tick have been applied then.
+func (r *Replay) Step() (ReplayStep, error) {
+	step := ReplayStep{}
+	for {
+		entry, err := r.next()
+		if err != nil {
+			return step, err
+		}
+		step.Entries = append(step.Entries, entry)
+		if err := r.apply(entry); err != nil {
+			step.Diffs = append(step.Diffs, fmt.Sprintf("%s: %v", entry.Type, err))
+		}
+		if err := r.random.done(); err != nil {
+			step.Diffs = append(step.Diffs, fmt.Sprintf("%s draws: %v", entry.Type, err))
+		}
+		if entry.Type == TickEntry {
+			step.Tick = entry.Tick
+			step.Diffs = append(step.Diffs, DigestSnapshot(r.Game.Snapshot()).Diff(entry.Digest)...)
+			return step, nil
+		}
+	}
+}
+
+// apply replays a journal entry with the time and random numbers it has been recorded with.
+func (r *Replay) apply(entry JournalEntry) error {
+	r.clock.now = entry.Time
+	r.random.load(entry.Draws)
+	g := r.Game
+
+	var err error
+	switch entry.Type {
+	case TickEntry:
+		g.tick(0)
+		if g.metrics.Ticks != entry.Tick {
+			err = fmt.Errorf("replayed tick %d instead of tick %d", g.metrics.Ticks, entry.Tick)
+		}
+	case JoinEntry:
+		_, err = g.joinUniverse(entry.Name, entry.Account, entry.Player)
+	case BuyEntry:
+		_, err = g.PlayerBuy(entry.Player, entry.Planet, entry.Resource, entry.Amount)
+	case SellEntry:
+		_, err = g.PlayerSell(entry.Player, entry.Planet, entry.Resource, entry.Amount)
+	case CollectEntry:
+		_, err = g.PlayerCollect(entry.Player, entry.Planet, entry.Resource, entry.Amount)
+	case ColonizeEntry:
+		_, err = g.PlayerColonize(entry.Player, entry.Planet, entry.Building)
+	case BuildEntry:
+		_, err = g.PlayerBuild(entry.Player, entry.Planet, entry.Building)
+	case ConfigEntry:
+		if entry.Config == nil {
+			return errors.New("config is missing")
+		}
+		_, err = g.ReloadConfig(*entry.Config)
+	case ScheduleEntry:
+		err = r.schedule(entry.Events)
+	default:
+		err = fmt.Errorf("unknown journal entry type %s", entry.Type)
+	}
+	return err
+}
+
+func (r *Replay) schedule(events []ScheduledEventSnapshot) error {
+	g := r.Game
+	g.mu.Lock()
+	planets := make(map[string]*Planet)
+	for _, p := range g.Planets {
+		planets[p.Name] = p
+	}
+	g.mu.Unlock()
+
+	scheduled := []*ScheduledEvent{}
+	for _, se := range events {
+		e, err := restoreEvent(se.Event, planets)
+		if err != nil {
+			return err
+		}
+		scheduled = append(scheduled, &ScheduledEvent{Tick: se.Tick, Event: e})
+	}
+	g.ScheduleEvents(scheduled)
+	return nil
+}
+
+// next returns the next entry of the journal, continuing with the next segment at the end of a segment.
+func (r *Replay) next() (JournalEntry, error) {
+	for {
+		if r.scanner == nil {
+			if len(r.segments) == 0 {
+				return JournalEntry{}, io.EOF
+			}
+			file, err := os.Open(segmentFile(r.dir, r.segments[0]))
+			if err != nil {
+				return JournalEntry{}, err
+			}
+			r.segments = r.segments[1:]
+			r.file, r.scanner = file, bufio.NewScanner(file)
+			r.scanner.Buffer(nil, 64*1024*1024)
+		}
+		if r.scanner.Scan() {
+			var entry JournalEntry
+			if err := json.Unmarshal(r.scanner.Bytes(), &entry); err != nil {
+				return entry, fmt.Errorf("invalid journal entry in %s: %w", r.file.Name(), err)
+			}
+			return entry, nil
+		}
+		err := r.scanner.Err()
+		r.file.Close()
+		r.file, r.scanner = nil, nil
+		if err != nil {
+			return JournalEntry{}, err
+		}
+	}
+}
+
+// Close closes the segment currently read.
+func (r *Replay) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file, r.scanner = nil, nil
+	return err
+}
+
+// replayRandom returns the random numbers recorded in a journal entry, in their order. A draw which
+// doesn't match the recorded one is a divergence, it's reported by done.
+type replayRandom struct {
+	draws []Draw
+	next  int
+	err   error
+}
+
+func (r *replayRandom) load(draws []Draw) {
+	r.draws, r.next, r.err = draws, 0, nil
+}
+
+func (r *replayRandom) draw(n int) float64 {
+	if r.next >= len(r.draws) {
+		if r.err == nil {
+			r.err = fmt.Errorf("more than %d numbers drawn", len(r.draws))
+		}
+		return 0
+	}
+	d := r.draws[r.next]
+	r.next++
+	if d.N != n && r.err == nil {
+		r.err = fmt.Errorf("draw %d has bound %d instead of %d", r.next, n, d.N)
+	}
+	return d.V
+}
+
+// done returns an error if the draws since the last load didn't match the recorded ones.
+func (r *replayRandom) done() error {
+	if r.err == nil && r.next < len(r.draws) {
+		return fmt.Errorf("%d of %d numbers drawn", r.next, len(r.draws))
+	}
+	return r.err
+}
+
+func (r *replayRandom) Seek() float64 {
+	return r.draw(0)
+}
+
+func (r *replayRandom) Of(n int) int {
+	v := int(r.draw(n))
+	if v < 0 || v >= n {
+		return 0
+	}
+	return v
+}
+
+func (r *replayRandom) OfRange(min, max int) int {
+	return ofRange(r, min, max)
+}
+
+func (r *replayRandom) OfIntRange(rng intRange) int {
+	return ofRange(r, rng.Min, rng.Max)
+}
+
+// replayClock returns the time a journal entry has been recorded at.
+type replayClock struct {
+	now time.Time
+}
+
+func (c *replayClock) Now() time.Time {
+	return c.now
+}
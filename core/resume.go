@@ -0,0 +1,225 @@
+package core
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/tommzn/utte-universe/core/proto"
+)
+
+// UpdateBuffer numbers universe state updates and keeps the latest of them, so streams which
+// reconnect receive the updates they missed.
+type UpdateBuffer struct {
+	mu       sync.Mutex
+	size     int
+	sequence uint64        // sequence of the latest update
+	updates  []stateUpdate // oldest first
+}
+
+// stateUpdate is a universe state update, converted when it was made. Planets, events and battles
+// keep the planet they refer to, so each stream can filter them.
+type stateUpdate struct {
+	sequence  uint64
+	tick      uint64
+	keyframe  bool
+	planets   []bufferedPlanet
+	npcs      []*pb.NPC
+	events    []bufferedEvent
+	lifecycle []*pb.LifecycleEvent
+	battles   []bufferedBattle
+	config    *pb.ConfigChange
+}
+
+type bufferedPlanet struct {
+	planetRef
+	planet *pb.Planet
+}
+
+type bufferedEvent struct {
+	target *planetRef // nil for events without target planet
+	event  *pb.Event
+}
+
+type bufferedBattle struct {
+	planetRef
+	report *pb.BattleReport
+}
+
+// NewUpdateBuffer returns a buffer keeping passed number of updates. Updates are numbered without
+// being kept if the size is zero.
+func NewUpdateBuffer(size int) *UpdateBuffer {
+	return &UpdateBuffer{size: size}
+}
+
+// Sequence returns the sequence of the latest update.
+func (b *UpdateBuffer) Sequence() uint64 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sequence
+}
+
+// add numbers an update and keeps it, dropping the oldest update if the buffer is full.
+func (b *UpdateBuffer) add(update stateUpdate) stateUpdate {
+	if b == nil {
+		return update
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sequence++
+	update.sequence = b.sequence
+	if b.size > 0 {
+		if len(b.updates) == b.size {
+			b.updates = append(b.updates[:0:0], b.updates[1:]...)
+		}
+		b.updates = append(b.updates, update)
+	}
+	return update
+}
+
+// since returns all updates after passed sequence. It returns false if some of them aren't kept anymore,
+// or the sequence is unknown, e.g. passed by a client of a previous run.
+func (b *UpdateBuffer) since(sequence uint64) ([]stateUpdate, bool) {
+	if b == nil {
+		return nil, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if sequence > b.sequence {
+		return nil, false
+	}
+	if sequence == b.sequence {
+		return nil, true
+	}
+	if len(b.updates) == 0 || b.updates[0].sequence > sequence+1 {
+		return nil, false
+	}
+	return append([]stateUpdate{}, b.updates[sequence+1-b.updates[0].sequence:]...), true
+}
+
+// updateQueueSize is the number of updates queued for a stream, later updates are dropped until
+// the stream catches up.
+const updateQueueSize = 10
+
+// subscribeUpdates returns a queue receiving all updates from now on, until the returned function is called.
+func (g *Game) subscribeUpdates() (<-chan stateUpdate, func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.subscribers == nil {
+		g.subscribers = make(map[chan stateUpdate]struct{})
+	}
+	queue := make(chan stateUpdate, updateQueueSize)
+	g.subscribers[queue] = struct{}{}
+	return queue, func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		delete(g.subscribers, queue)
+	}
+}
+
+// publish numbers an update, keeps it for resuming streams and queues it for all subscribed streams.
+// It has to be called with the game locked, so updates are numbered in the order they're made.
+func (g *Game) publish(update stateUpdate) {
+	update = g.updates.add(update)
+	kind := "state"
+	if update.config != nil {
+		kind = "config"
+	}
+	for queue := range g.subscribers {
+		select {
+		case queue <- update:
+		default:
+			g.log.Debug("Update queue of a stream is full, dropping update %d.", update.sequence)
+			g.activity.droppedUpdates[kind]++
+		}
+	}
+}
+
+// keyframe returns the current state of the universe, numbered as the latest update.
+func (g *Game) keyframe() stateUpdate {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	update := captureState(g.metrics.Ticks, g.Planets, g.NPCs, g.ActiveEvents, nil, nil)
+	update.sequence = g.updates.Sequence()
+	update.keyframe = true
+	return update
+}
+
+func captureState(tick uint64, planets []*Planet, npcs []*NPC, events []*Event, lifecycleEvents []LifecycleEvent, battleReports []BattleReport) stateUpdate {
+	update := stateUpdate{tick: tick}
+	for _, p := range planets {
+		update.planets = append(update.planets, bufferedPlanet{planetRef: refOf(p), planet: planetToProto(p)})
+	}
+	for _, n := range npcs {
+		update.npcs = append(update.npcs, npcToProto(n))
+	}
+	for _, e := range events {
+		event := bufferedEvent{event: eventToProto(e)}
+		if e.TargetPlanet != nil {
+			ref := refOf(e.TargetPlanet)
+			event.target = &ref
+		}
+		update.events = append(update.events, event)
+	}
+	for _, e := range lifecycleEvents {
+		update.lifecycle = append(update.lifecycle, lifecycleEventToProto(e))
+	}
+	for _, r := range battleReports {
+		update.battles = append(update.battles, bufferedBattle{planetRef: refOf(r.Planet), report: battleReportToProto(r)})
+	}
+	return update
+}
+
+// render returns an update as sent to a stream with passed filter, made for passed player, if any.
+// Treasuries of planets owned by other players are private.
+func (u stateUpdate) render(filter StateFilter, player *Player) *pb.UniverseState {
+	msg := &pb.UniverseState{Tick: u.tick, Sequence: u.sequence, Keyframe: u.keyframe}
+	if u.config != nil {
+		msg.ConfigChange = u.config
+		return msg
+	}
+	playerID := ""
+	if player != nil {
+		playerID = player.ID
+	}
+	owned := []string{}
+	planets := make([]*pb.Planet, 0, len(u.planets))
+	for _, p := range u.planets {
+		if p.playerID != "" && p.playerID == playerID {
+			owned = append(owned, p.name)
+		}
+		if !filter.includesPlanet(p.planetRef) {
+			continue
+		}
+		planet := p.planet
+		if p.playerID != "" && p.playerID != playerID {
+			planet = proto.Clone(planet).(*pb.Planet)
+			planet.Treasury = 0
+		}
+		planets = append(planets, planet)
+	}
+	events := make([]*pb.Event, 0, len(u.events))
+	for _, e := range u.events {
+		if filter.includesEvent(e.event.Name, e.target) {
+			events = append(events, e.event)
+		}
+	}
+	battles := make([]*pb.BattleReport, 0, len(u.battles))
+	for _, b := range u.battles {
+		if filter.includesPlanet(b.planetRef) {
+			battles = append(battles, b.report)
+		}
+	}
+	msg.Planets = &pb.PlanetList{Planets: planets}
+	msg.Npcs = &pb.NPCList{Npcs: append([]*pb.NPC{}, u.npcs...)}
+	msg.Events = events
+	msg.LifecycleEvents = append([]*pb.LifecycleEvent{}, u.lifecycle...)
+	msg.BattleReports = battles
+	if player != nil {
+		msg.Player = playerToProto(PlayerState{Player: *player, Planets: owned})
+	}
+	return msg
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	pb "github.com/tommzn/utte-universe/core/proto"
+)
+
+type UpdateBufferSuite struct {
+	suite.Suite
+}
+
+func TestUpdateBufferSuite(t *testing.T) {
+	suite.Run(t, new(UpdateBufferSuite))
+}
+
+func sequences(updates []stateUpdate) []uint64 {
+	seqs := []uint64{}
+	for _, u := range updates {
+		seqs = append(seqs, u.sequence)
+	}
+	return seqs
+}
+
+func (s *UpdateBufferSuite) TestAddNumbersUpdates() {
+	buffer := NewUpdateBuffer(3)
+	for i := 1; i <= 5; i++ {
+		s.Equal(uint64(i), buffer.add(stateUpdate{}).sequence)
+	}
+	s.Equal(uint64(5), buffer.Sequence())
+	s.Equal([]uint64{3, 4, 5}, sequences(buffer.updates))
+}
+
+func (s *UpdateBufferSuite) TestSince() {
+	buffer := NewUpdateBuffer(3)
+	for i := 0; i < 5; i++ {
+		buffer.add(stateUpdate{})
+	}
+
+	missed, ok := buffer.since(2)
+	s.True(ok)
+	s.Equal([]uint64{3, 4, 5}, sequences(missed))
+
+	missed, ok = buffer.since(4)
+	s.True(ok)
+	s.Equal([]uint64{5}, sequences(missed))
+
+	missed, ok = buffer.since(5)
+	s.True(ok)
+	s.Empty(missed)
+
+	_, ok = buffer.since(1)
+	s.False(ok, "update 2 isn't buffered anymore")
+	_, ok = buffer.since(6)
+	s.False(ok, "sequence of a previous run")
+}
+
+func (s *UpdateBufferSuite) TestWithoutBuffer() {
+	buffer := NewUpdateBuffer(0)
+	s.Equal(uint64(1), buffer.add(stateUpdate{}).sequence)
+	s.Equal(uint64(2), buffer.add(stateUpdate{}).sequence)
+	_, ok := buffer.since(1)
+	s.False(ok)
+	_, ok = buffer.since(2)
+	s.True(ok)
+
+	var disabled *UpdateBuffer
+	s.Equal(uint64(0), disabled.add(stateUpdate{}).sequence)
+	s.Equal(uint64(0), disabled.Sequence())
+}
+
+func (s *UpdateBufferSuite) TestRenderKeepsCapturedState() {
+	alice := &Player{ID: "alice", Name: "Alice"}
+	planets := []*Planet{{Name: "Vega-B", Treasury: 10, Player: alice}, {Name: "Luna-D", Treasury: 5}}
+	update := captureState(7, planets, []*NPC{{Name: "Trader"}}, []*Event{{Name: "Solar Flare", TargetPlanet: planets[1]}}, nil, nil)
+	planets[0].Treasury = 99
+
+	msg := update.render(StateFilter{}, nil)
+	s.Equal(uint64(7), msg.Tick)
+	s.Len(msg.Planets.Planets, 2)
+	s.Equal(int32(0), msg.Planets.Planets[0].Treasury, "treasury of a player's planet is private")
+	s.Equal(int32(5), msg.Planets.Planets[1].Treasury)
+	s.Nil(msg.Player)
+
+	msg = update.render(StateFilter{Owners: []string{"Alice"}}, alice)
+	s.Len(msg.Planets.Planets, 1)
+	s.Equal(int32(10), msg.Planets.Planets[0].Treasury)
+	s.Equal([]string{"Vega-B"}, msg.Player.Planets)
+	s.Empty(msg.Events)
+	s.Len(msg.Npcs.Npcs, 1)
+
+	s.Equal(int32(10), update.planets[0].planet.Treasury, "buffered planets aren't changed by rendering")
+}
+
+func (s *UpdateBufferSuite) TestRenderConfigChange() {
+	update := stateUpdate{sequence: 3, config: &pb.ConfigChange{Applied: []string{"tick_duration"}}}
+	msg := update.render(StateFilter{OnlyNPCs: true}, nil)
+	s.Equal(uint64(3), msg.Sequence)
+	s.Equal([]string{"tick_duration"}, msg.ConfigChange.Applied)
+	s.Nil(msg.Planets)
+}
@@ -0,0 +1,366 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tommzn/go-config"
+)
+
+// Scenario is a hand-authored universe, e.g. a tutorial, regression or tournament map.
+type Scenario struct {
+	Name    string
+	Planets []*Planet
+	NPCs    []*NPC
+	Events  []*ScheduledEvent
+}
+
+// ScheduledEvent is an event which is applied at a given tick instead of being triggered by chance.
+type ScheduledEvent struct {
+	Tick  uint64
+	Event *Event
+}
+
+type RawScenario struct {
+	Name       string              `mapstructure:"name"`
+	RandomFill *RawScenarioFill    `mapstructure:"random_fill"`
+	Planets    []RawScenarioPlanet `mapstructure:"planets"`
+	NPCs       []RawScenarioNPC    `mapstructure:"npcs"`
+	Events     []RawScenarioEvent  `mapstructure:"events"`
+}
+
+// RawScenarioFill adds random planets and NPCs, generated from seed config, to a scenario.
+type RawScenarioFill struct {
+	Planets RawIntRange `mapstructure:"planets"`
+	NPCs    RawIntRange `mapstructure:"npcs"`
+}
+
+type RawScenarioPlanet struct {
+	Name      string                `mapstructure:"name"`
+	Type      string                `mapstructure:"type"`
+	Resources []ResourceAmount      `mapstructure:"resources"`
+	Modifiers []ResourceMultiplier  `mapstructure:"modifiers"`
+	Buildings []RawScenarioBuilding `mapstructure:"buildings"`
+	Owner     string                `mapstructure:"owner"`
+	Treasury  int                   `mapstructure:"treasury"`
+	Garrison  int                   `mapstructure:"garrison"`
+}
+
+type RawScenarioBuilding struct {
+	Type       string           `mapstructure:"type"`
+	Level      int              `mapstructure:"level"`
+	Production []ResourceAmount `mapstructure:"production"`
+}
+
+type RawScenarioNPC struct {
+	Name                        string           `mapstructure:"name"`
+	Offers                      []ResourceAmount `mapstructure:"offers"`
+	Credits                     int              `mapstructure:"credits"`
+	Cargo                       []ResourceAmount `mapstructure:"cargo"`
+	MaxCargo                    int              `mapstructure:"max_cargo"`
+	ColonizationCooldownSeconds int              `mapstructure:"colonization_cooldown_seconds"`
+	Strategy                    string           `mapstructure:"strategy"`
+	InvestmentShare             *int             `mapstructure:"investment_share"`
+	Tariff                      *int             `mapstructure:"tariff"`
+	Faction                     string           `mapstructure:"faction"`
+	Ships                       int              `mapstructure:"ships"`
+}
+
+type RawScenarioEvent struct {
+	Name     string               `mapstructure:"name"`
+	Tick     uint64               `mapstructure:"tick"`
+	Planet   string               `mapstructure:"planet"`
+	Building string               `mapstructure:"building"`
+	Duration int                  `mapstructure:"duration"`
+	Boost    []ResourceMultiplier `mapstructure:"boost"`
+}
+
+type ResourceMultiplier struct {
+	Resource   string  `mapstructure:"resource"`
+	Multiplier float64 `mapstructure:"multiplier"`
+}
+
+// LoadScenarioFile reads a scenario from a local YAML or JSON file.
+func LoadScenarioFile(fileName string, seedConfig SeedConfig, rand Random, now time.Time) (*Scenario, error) {
+	conf, err := config.NewFileConfigSource(&fileName).Load()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read scenario %s: %w", fileName, err)
+	}
+	return LoadScenario(conf, seedConfig, rand, now)
+}
+
+// LoadScenario creates all planets, NPCs and scheduled events defined in passed config.
+// Build costs of buildings are taken from seed config, as well as ranges for random fill.
+// Colonization cooldowns of NPCs are relative to passed time.
+func LoadScenario(conf config.Config, seedConfig SeedConfig, rand Random, now time.Time) (*Scenario, error) {
+
+	raw := RawScenario{}
+	if err := conf.Unmarshal(&raw); err != nil {
+		return nil, err
+	}
+
+	scenario := &Scenario{Name: raw.Name}
+	npcsByName := make(map[string]*NPC)
+	factionsByName := make(map[string]*Faction)
+	for _, rn := range raw.NPCs {
+		npc, err := scenarioNPC(rn, seedConfig, rand, now)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := npcsByName[npc.Name]; ok {
+			return nil, fmt.Errorf("duplicate NPC %s", npc.Name)
+		}
+		// NPCs without faction are assigned to configured factions when the game starts
+		if rn.Faction != "" {
+			if _, ok := factionsByName[rn.Faction]; !ok {
+				factionsByName[rn.Faction] = &Faction{Name: rn.Faction}
+			}
+			npc.Faction = factionsByName[rn.Faction]
+		}
+		npcsByName[npc.Name] = npc
+		scenario.NPCs = append(scenario.NPCs, npc)
+	}
+
+	planetsByName := make(map[string]*Planet)
+	for _, rp := range raw.Planets {
+		planet, err := scenarioPlanet(rp, seedConfig, rand)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := planetsByName[planet.Name]; ok {
+			return nil, fmt.Errorf("duplicate planet %s", planet.Name)
+		}
+		if rp.Owner != "" {
+			owner, ok := npcsByName[rp.Owner]
+			if !ok {
+				return nil, fmt.Errorf("planet %s: unknown owner %s", rp.Name, rp.Owner)
+			}
+			planet.Owner = owner
+		}
+		planetsByName[planet.Name] = planet
+		scenario.Planets = append(scenario.Planets, planet)
+	}
+
+	for _, re := range raw.Events {
+		event, err := scenarioEvent(re, planetsByName)
+		if err != nil {
+			return nil, err
+		}
+		scenario.Events = append(scenario.Events, event)
+	}
+
+	if raw.RandomFill != nil {
+		fillConfig := seedConfig
+		fillConfig.NumberOfPlanets = intRange{Min: raw.RandomFill.Planets.Min, Max: raw.RandomFill.Planets.Max}
+		fillConfig.MPCConfig.NumberOfNPCs = intRange{Min: raw.RandomFill.NPCs.Min, Max: raw.RandomFill.NPCs.Max}
+		for _, p := range GeneratePlanets(fillConfig, rand) {
+			p.Name = uniqueName(p.Name, func(name string) bool { return planetsByName[name] != nil })
+			planetsByName[p.Name] = p
+			scenario.Planets = append(scenario.Planets, p)
+		}
+		for _, n := range GenerateNPCs(fillConfig, rand) {
+			n.Name = uniqueName(n.Name, func(name string) bool { return npcsByName[name] != nil })
+			npcsByName[n.Name] = n
+			scenario.NPCs = append(scenario.NPCs, n)
+		}
+	}
+	return scenario, nil
+}
+
+// scenarioNPC creates an NPC. Without explicit strategy, investment share or tariff, they're chosen
+// from seed config, like for random universes.
+func scenarioNPC(rn RawScenarioNPC, seedConfig SeedConfig, rand Random, now time.Time) (*NPC, error) {
+	if rn.Name == "" {
+		return nil, fmt.Errorf("NPC without name")
+	}
+	strategy := ChooseNPCStrategy(seedConfig.MPCConfig.Strategies, rand)
+	if rn.Strategy != "" {
+		if strategy = NPCStrategyFromString(rn.Strategy); strategy == nil {
+			return nil, fmt.Errorf("NPC %s: unknown strategy %s", rn.Name, rn.Strategy)
+		}
+	}
+	investmentShare := rand.OfIntRange(seedConfig.MPCConfig.InvestmentShare)
+	if rn.InvestmentShare != nil {
+		if *rn.InvestmentShare < 0 || *rn.InvestmentShare > 100 {
+			return nil, fmt.Errorf("NPC %s: investment share has to be between 0 and 100, got %d", rn.Name, *rn.InvestmentShare)
+		}
+		investmentShare = *rn.InvestmentShare
+	}
+	tariff := rand.OfIntRange(seedConfig.MPCConfig.Tariff)
+	if rn.Tariff != nil {
+		if *rn.Tariff < 0 || *rn.Tariff > 100 {
+			return nil, fmt.Errorf("NPC %s: tariff has to be between 0 and 100, got %d", rn.Name, *rn.Tariff)
+		}
+		tariff = *rn.Tariff
+	}
+	if rn.Ships < 0 {
+		return nil, fmt.Errorf("NPC %s: ships can't be negative, got %d", rn.Name, rn.Ships)
+	}
+	offer, err := resourceAmounts(rn.Offers)
+	if err != nil {
+		return nil, fmt.Errorf("NPC %s: %w", rn.Name, err)
+	}
+	cargo, err := resourceAmounts(rn.Cargo)
+	if err != nil {
+		return nil, fmt.Errorf("NPC %s: %w", rn.Name, err)
+	}
+	for _, res := range resourceTypes {
+		if _, ok := cargo[res]; !ok {
+			cargo[res] = 0
+		}
+	}
+	return &NPC{
+		Name:                 rn.Name,
+		Offer:                offer,
+		Credits:              rn.Credits,
+		Cargo:                cargo,
+		MaxCargo:             rn.MaxCargo,
+		ColonizationCooldown: now.Add(time.Duration(rn.ColonizationCooldownSeconds) * time.Second),
+		Strategy:             strategy,
+		InvestmentShare:      investmentShare,
+		Tariff:               tariff,
+		Ships:                rn.Ships,
+	}, nil
+}
+
+func scenarioPlanet(rp RawScenarioPlanet, seedConfig SeedConfig, rand Random) (*Planet, error) {
+	if rp.Name == "" {
+		return nil, fmt.Errorf("planet without name")
+	}
+	planetType := PlanetTypeFromString(rp.Type)
+	if planetType < 0 {
+		return nil, fmt.Errorf("planet %s: unknown planet type %s", rp.Name, rp.Type)
+	}
+	if rp.Treasury < 0 {
+		return nil, fmt.Errorf("planet %s: treasury can't be negative, got %d", rp.Name, rp.Treasury)
+	}
+	if rp.Garrison < 0 {
+		return nil, fmt.Errorf("planet %s: garrison can't be negative, got %d", rp.Name, rp.Garrison)
+	}
+	resources, err := resourceAmounts(rp.Resources)
+	if err != nil {
+		return nil, fmt.Errorf("planet %s: %w", rp.Name, err)
+	}
+	modifiers := copyModifiers(baseModifiers)
+	for _, rm := range rp.Modifiers {
+		res := ResourceTypeFromString(rm.Resource)
+		if res < 0 {
+			return nil, fmt.Errorf("planet %s: unknown resource %s", rp.Name, rm.Resource)
+		}
+		modifiers[res] = rm.Multiplier
+	}
+
+	planet := &Planet{
+		Name:      rp.Name,
+		Type:      planetType,
+		Resources: resources,
+		Modifiers: modifiers,
+		Buildings: []*Building{},
+		Treasury:  rp.Treasury,
+		Garrison:  rp.Garrison,
+	}
+	for _, rb := range rp.Buildings {
+		building, err := scenarioBuilding(rb, seedConfig, rand)
+		if err != nil {
+			return nil, fmt.Errorf("planet %s: %w", rp.Name, err)
+		}
+		planet.Buildings = append(planet.Buildings, building)
+	}
+	return planet, nil
+}
+
+// scenarioBuilding creates a building. Without explicit production, production of the building's main
+// resource is chosen from seed config, like for random universes.
+func scenarioBuilding(rb RawScenarioBuilding, seedConfig SeedConfig, rand Random) (*Building, error) {
+	buildingType := BuildingTypeFromString(rb.Type)
+	if buildingType < 0 {
+		return nil, fmt.Errorf("unknown building type %s", rb.Type)
+	}
+	production, err := resourceAmounts(rb.Production)
+	if err != nil {
+		return nil, fmt.Errorf("building %s: %w", rb.Type, err)
+	}
+	if len(production) == 0 {
+		if res, ok := buildingResourceRelation[buildingType]; ok {
+			production[res] = rand.OfIntRange(seedConfig.Production)
+		}
+	}
+	modifiers := make(map[ResourceType]float64)
+	for res := range production {
+		modifiers[res] = 1.0
+	}
+	level := rb.Level
+	if level <= 0 {
+		level = 1
+	}
+	return &Building{
+		Type:       buildingType,
+		Level:      level,
+		Production: production,
+		Modifiers:  modifiers,
+		BuildCost:  seedConfig.BuildCosts[buildingType],
+	}, nil
+}
+
+func scenarioEvent(re RawScenarioEvent, planetsByName map[string]*Planet) (*ScheduledEvent, error) {
+	planet, ok := planetsByName[re.Planet]
+	if !ok {
+		return nil, fmt.Errorf("event %s: unknown planet %s", re.Name, re.Planet)
+	}
+	boost := make(map[ResourceType]float64)
+	for _, rm := range re.Boost {
+		res := ResourceTypeFromString(rm.Resource)
+		if res < 0 {
+			return nil, fmt.Errorf("event %s: unknown resource %s", re.Name, rm.Resource)
+		}
+		boost[res] = rm.Multiplier
+	}
+	duration := re.Duration
+	if duration <= 0 {
+		duration = 5
+	}
+
+	event := &Event{
+		Name:           re.Name,
+		Target:         PlanetTarget,
+		TargetPlanet:   planet,
+		ResourceBoost:  boost,
+		Duration:       duration,
+		RemainingTicks: duration,
+	}
+	if re.Building != "" {
+		buildingType := BuildingTypeFromString(re.Building)
+		for _, b := range planet.Buildings {
+			if b.Type == buildingType {
+				event.Target = BuildingTarget
+				event.TargetBuilding = b
+				break
+			}
+		}
+		if event.TargetBuilding == nil {
+			return nil, fmt.Errorf("event %s: no %s on planet %s", re.Name, re.Building, re.Planet)
+		}
+	}
+	return &ScheduledEvent{Tick: max(1, re.Tick), Event: event}, nil
+}
+
+func resourceAmounts(amounts []ResourceAmount) (map[ResourceType]int, error) {
+	result := make(map[ResourceType]int)
+	for _, ra := range amounts {
+		res := ResourceTypeFromString(ra.Resource)
+		if res < 0 {
+			return nil, fmt.Errorf("unknown resource %s", ra.Resource)
+		}
+		result[res] = ra.Amount
+	}
+	return result, nil
+}
+
+// uniqueName appends a counter to passed name until it's no longer taken.
+func uniqueName(name string, taken func(string) bool) string {
+	candidate := name
+	for i := 2; taken(candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
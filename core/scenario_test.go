@@ -0,0 +1,222 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tommzn/go-config"
+)
+
+type ScenarioSuite struct {
+	suite.Suite
+	seedConfig SeedConfig
+	now        time.Time
+}
+
+func TestScenarioSuite(t *testing.T) {
+	suite.Run(t, new(ScenarioSuite))
+}
+
+func (s *ScenarioSuite) SetupTest() {
+	s.seedConfig = DefaultSeedConfig()
+	s.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *ScenarioSuite) loadScenario(yaml string) (*Scenario, error) {
+	conf, err := config.NewStaticConfigSource(yaml).Load()
+	s.Require().NoError(err)
+	return LoadScenario(conf, s.seedConfig, &mockRand{seekVal: 0.5, ofVal: 1}, s.now)
+}
+
+func (s *ScenarioSuite) TestLoadScenarioFile() {
+	scenario, err := LoadScenarioFile("fixtures/scenario.yml", s.seedConfig, NewSeededRand(1), s.now)
+	s.NoError(err)
+	s.Equal("Tutorial", scenario.Name)
+	s.Len(scenario.Planets, 4)
+	s.Len(scenario.NPCs, 2)
+	s.Len(scenario.Events, 2)
+
+	joe := scenario.NPCs[0]
+	s.Equal("Trader Joe", joe.Name)
+	s.Equal(1000, joe.Credits)
+	s.Equal(100, joe.MaxCargo)
+	s.Equal(map[ResourceType]int{Iron: 5, Food: 3, Fuel: 8}, joe.Offer)
+	s.Equal(map[ResourceType]int{Iron: 0, Food: 0, Fuel: 10}, joe.Cargo)
+	s.Equal(s.now.Add(time.Minute), joe.ColonizationCooldown)
+	s.Equal(TraderStrategy{}, joe.Strategy)
+	s.Equal(20, joe.InvestmentShare)
+	s.Equal(10, joe.Tariff)
+	s.Equal("Traders Guild", joe.Faction.Name)
+	s.Equal(4, joe.Ships)
+	s.NotNil(scenario.NPCs[1].Strategy)
+	s.Nil(scenario.NPCs[1].Faction)
+
+	vega := scenario.Planets[0]
+	s.Equal("Vega-B", vega.Name)
+	s.Equal(Desert, vega.Type)
+	s.Equal(joe, vega.Owner)
+	s.Equal(3, vega.Garrison)
+	s.Equal(500, vega.Resources[Iron])
+	s.Equal(1.5, vega.Modifiers[Iron])
+	s.Equal(1.0, vega.Modifiers[Food])
+	s.Len(vega.Buildings, 2)
+	s.Equal(Mine, vega.Buildings[0].Type)
+	s.Equal(2, vega.Buildings[0].Level)
+	s.Equal(map[ResourceType]int{Iron: 7}, vega.Buildings[0].Production)
+	s.Equal(s.seedConfig.BuildCosts[Mine], vega.Buildings[0].BuildCost)
+	s.Equal(1, vega.Buildings[1].Level)
+	s.Contains(vega.Buildings[1].Production, Fuel)
+
+	s.Nil(scenario.Planets[1].Owner)
+
+	boom := scenario.Events[0]
+	s.Equal(uint64(3), boom.Tick)
+	s.Equal(BuildingTarget, boom.Event.Target)
+	s.Equal(vega.Buildings[0], boom.Event.TargetBuilding)
+	s.Equal(4, boom.Event.RemainingTicks)
+	s.Equal(PlanetTarget, scenario.Events[1].Event.Target)
+	s.Equal(5, scenario.Events[1].Event.Duration)
+}
+
+func (s *ScenarioSuite) TestRandomFillUsesUniqueNames() {
+	scenario, err := s.loadScenario(`
+random_fill:
+  planets:
+    min: 1
+    max: 1
+planets:
+  - name: Aurora-A
+    type: Icy
+`)
+	s.NoError(err)
+	s.Len(scenario.Planets, 2)
+	s.Equal("Aurora-A", scenario.Planets[0].Name)
+	s.Equal("Aurora-A-2", scenario.Planets[1].Name)
+	s.Empty(scenario.NPCs)
+}
+
+func (s *ScenarioSuite) TestInvalidScenarios() {
+	invalid := map[string]string{
+		"unknown planet type": `
+planets:
+  - name: X
+    type: Lava
+`,
+		"unknown owner": `
+planets:
+  - name: X
+    type: Icy
+    owner: Nobody
+`,
+		"unknown resource": `
+planets:
+  - name: X
+    type: Icy
+    resources:
+      - resource: Gold
+        amount: 1
+`,
+		"unknown building": `
+planets:
+  - name: X
+    type: Icy
+    buildings:
+      - type: Castle
+`,
+		"duplicate planet": `
+planets:
+  - name: X
+    type: Icy
+  - name: X
+    type: Desert
+`,
+		"event on unknown planet": `
+events:
+  - name: Storm
+    planet: X
+`,
+		"event on missing building": `
+planets:
+  - name: X
+    type: Icy
+events:
+  - name: Storm
+    planet: X
+    building: Mine
+`,
+		"npc without name": `
+npcs:
+  - credits: 5
+`,
+		"unknown strategy": `
+npcs:
+  - name: X
+    strategy: pirate
+`,
+		"invalid tariff": `
+npcs:
+  - name: X
+    tariff: -1
+`,
+		"negative ships": `
+npcs:
+  - name: X
+    ships: -1
+`,
+		"negative garrison": `
+planets:
+  - name: X
+    type: Icy
+    garrison: -2
+`,
+		"negative treasury": `
+planets:
+  - name: X
+    type: Icy
+    treasury: -5
+`,
+		"invalid investment share": `
+npcs:
+  - name: X
+    investment_share: 150
+`,
+	}
+	for name, yaml := range invalid {
+		_, err := s.loadScenario(yaml)
+		s.Error(err, name)
+	}
+}
+
+func (s *ScenarioSuite) TestScheduledEventsAreApplied() {
+	config := DefaultConfig()
+	simulation, err := NewScenarioSimulation(config, "fixtures/scenario.yml", 1, &mockLog{})
+	s.NoError(err)
+	vega := simulation.Game.Planets[0]
+
+	simulation.Run(1, nil)
+	s.Equal(0.5, vega.Modifiers[Food])
+	s.Equal(1.0, vega.Buildings[0].Modifiers[Iron])
+
+	simulation.Run(2, nil)
+	s.Equal(2.0, vega.Buildings[0].Modifiers[Iron])
+	s.Empty(simulation.Game.scheduledEvents)
+
+	simulation.Run(4, nil)
+	s.Equal(1.0, vega.Buildings[0].Modifiers[Iron])
+}
+
+func (s *ScenarioSuite) TestScenarioSimulationIsReproducible() {
+	config := DefaultConfig()
+	config.ScenarioFile = "fixtures/scenario.yml"
+	run := func() []TickStats {
+		stats := []TickStats{}
+		simulation, err := NewSimulationFromConfig(config, 5, &mockLog{})
+		s.Require().NoError(err)
+		simulation.Run(50, func(ts TickStats) {
+			stats = append(stats, ts)
+		})
+		return stats
+	}
+	s.Equal(run(), run())
+}
@@ -37,13 +37,23 @@ func GeneratePlanets(seedConfig SeedConfig, rand Random) []*Planet {
 			Name:      GeneratePlanetName(i),
 			Type:      planetType,
 			Resources: GenerateResources(seedConfig, rand),
-			Modifiers: baseModifiers,
+			Modifiers: copyModifiers(baseModifiers),
 			Buildings: GenerateBuildings(planetType, seedConfig, rand),
 		})
 	}
 	return planets
 }
 
+// copyModifiers returns a copy of passed modifiers, events change modifiers per planet and
+// must not affect other planets sharing the same map.
+func copyModifiers(modifiers map[ResourceType]float64) map[ResourceType]float64 {
+	result := make(map[ResourceType]float64, len(modifiers))
+	for res, modifier := range modifiers {
+		result[res] = modifier
+	}
+	return result
+}
+
 func GenerateBuildings(planetType PlanetType, seedConfig SeedConfig, rand Random) []*Building {
 
 	buildings := make([]*Building, 0)
@@ -54,21 +64,29 @@ func GenerateBuildings(planetType PlanetType, seedConfig SeedConfig, rand Random
 		}
 
 		if rand.Seek() < seedConfig.BuildingChance[buildingType] {
-			resourceType := buildingResourceRelation[buildingType]
-			buildings = append(buildings, &Building{
-				Type:  buildingType,
-				Level: 1,
-				Production: map[ResourceType]int{
-					resourceType: rand.OfIntRange(seedConfig.Production),
-				},
-				Modifiers: map[ResourceType]float64{resourceType: 1.0},
-				BuildCost: seedConfig.BuildCosts[buildingType],
-			})
+			buildings = append(buildings, NewBuilding(buildingType, seedConfig, rand))
 		}
 	}
 	return buildings
 }
 
+// NewBuilding creates a building on level 1, with production of its main resource chosen from seed config.
+func NewBuilding(buildingType BuildingType, seedConfig SeedConfig, rand Random) *Building {
+	production := make(map[ResourceType]int)
+	modifiers := make(map[ResourceType]float64)
+	if resourceType, ok := buildingResourceRelation[buildingType]; ok {
+		production[resourceType] = rand.OfIntRange(seedConfig.Production)
+		modifiers[resourceType] = 1.0
+	}
+	return &Building{
+		Type:       buildingType,
+		Level:      1,
+		Production: production,
+		Modifiers:  modifiers,
+		BuildCost:  seedConfig.BuildCosts[buildingType],
+	}
+}
+
 func GenerateResources(seedConfig SeedConfig, rand Random) map[ResourceType]int {
 
 	resources := make(map[ResourceType]int)
@@ -83,27 +101,35 @@ func GenerateNPCs(seedConfig SeedConfig, rand Random) []*NPC {
 	numNPCs := rand.OfIntRange(seedConfig.MPCConfig.NumberOfNPCs)
 	npcs := make([]*NPC, 0)
 	for i := 0; i < numNPCs; i++ {
+		npcs = append(npcs, NewNPC(GenerateNPCName(i), seedConfig, time.Now(), rand))
+	}
+	return npcs
+}
 
-		offer := make(map[ResourceType]int)
-		for _, resourceType := range resourceTypes {
-			offer[resourceType] = rand.OfRange(
-				seedConfig.MPCConfig.Offers[resourceType].Min,
-				seedConfig.MPCConfig.Offers[resourceType].Max)
-		}
+// NewNPC creates an NPC with given name, all other values are chosen from seed config.
+func NewNPC(name string, seedConfig SeedConfig, now time.Time, rand Random) *NPC {
 
-		cargo := map[ResourceType]int{
-			Iron: 0,
-			Food: 0,
-			Fuel: 0,
-		}
-		npcs = append(npcs, &NPC{
-			Name:                 GenerateNPCName(i),
-			Offer:                offer,
-			Credits:              rand.OfIntRange(seedConfig.MPCConfig.Credits),
-			Cargo:                cargo,
-			MaxCargo:             rand.OfIntRange(seedConfig.MPCConfig.MaxCargo),
-			ColonizationCooldown: time.Now().Add(time.Duration(rand.Of(seedConfig.MPCConfig.ColonizationCooldownSeconds)) * time.Second),
-		})
+	offer := make(map[ResourceType]int)
+	for _, resourceType := range resourceTypes {
+		offer[resourceType] = rand.OfRange(
+			seedConfig.MPCConfig.Offers[resourceType].Min,
+			seedConfig.MPCConfig.Offers[resourceType].Max)
+	}
+
+	cargo := map[ResourceType]int{
+		Iron: 0,
+		Food: 0,
+		Fuel: 0,
+	}
+	return &NPC{
+		Name:                 name,
+		Offer:                offer,
+		Credits:              rand.OfIntRange(seedConfig.MPCConfig.Credits),
+		Cargo:                cargo,
+		MaxCargo:             rand.OfIntRange(seedConfig.MPCConfig.MaxCargo),
+		ColonizationCooldown: now.Add(time.Duration(rand.Of(seedConfig.MPCConfig.ColonizationCooldownSeconds)) * time.Second),
+		Strategy:             ChooseNPCStrategy(seedConfig.MPCConfig.Strategies, rand),
+		InvestmentShare:      rand.OfIntRange(seedConfig.MPCConfig.InvestmentShare),
+		Tariff:               rand.OfIntRange(seedConfig.MPCConfig.Tariff),
 	}
-	return npcs
 }
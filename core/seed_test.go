@@ -339,3 +339,14 @@ func (s *SeedUniverseSuite) TestNegativePlanetsAndNPCs() {
 	s.Equal(0, len(planets))
 	s.Equal(0, len(npcs))
 }
+
+func (s *SeedUniverseSuite) TestPlanetsDoNotShareModifiers() {
+	seedConfig := DefaultSeedConfig()
+	seedConfig.NumberOfPlanets = intRange{Min: 2, Max: 3}
+	planets := GeneratePlanets(seedConfig, &mockRand{seekVal: 0.5, ofVal: 0})
+	s.Len(planets, 2)
+
+	planets[0].Modifiers[Iron] = 2.0
+	s.Equal(1.0, planets[1].Modifiers[Iron])
+	s.Equal(1.0, baseModifiers[Iron])
+}
@@ -0,0 +1,64 @@
+package core
+
+import "time"
+
+// Simulation runs a game headless, without timers or gRPC, as fast as possible.
+// Game time is driven by a SimulationClock which advances one tick duration per tick.
+type Simulation struct {
+	Game  *Game
+	clock *SimulationClock
+}
+
+// NewSimulation seeds a random universe with given seed and returns a simulation for it.
+func NewSimulation(config Config, seed int64, log Log) *Simulation {
+	random := NewSeededRand(seed)
+	start := time.Now()
+	planets, npcs := SeedUniverse(config.SeedConfig, random)
+	simulation := newSimulation(config, random, log, planets, npcs, start)
+	simulation.Game.seed = seed
+	return simulation
+}
+
+// NewSimulationFromConfig returns a simulation for the scenario defined in config or,
+// if there's none, for a random universe.
+func NewSimulationFromConfig(config Config, seed int64, log Log) (*Simulation, error) {
+	if config.ScenarioFile != "" {
+		return NewScenarioSimulation(config, config.ScenarioFile, seed, log)
+	}
+	return NewSimulation(config, seed, log), nil
+}
+
+// NewScenarioSimulation loads a scenario file and returns a simulation for it. Passed seed is used for
+// random fill of the scenario and all random decisions during the simulation.
+func NewScenarioSimulation(config Config, scenarioFile string, seed int64, log Log) (*Simulation, error) {
+	random := NewSeededRand(seed)
+	start := time.Now()
+	scenario, err := LoadScenarioFile(scenarioFile, config.SeedConfig, random, start)
+	if err != nil {
+		return nil, err
+	}
+	simulation := newSimulation(config, random, log, scenario.Planets, scenario.NPCs, start)
+	simulation.Game.seed = seed
+	simulation.Game.ScheduleEvents(scenario.Events)
+	return simulation, nil
+}
+
+func newSimulation(config Config, random Random, log Log, planets []*Planet, npcs []*NPC, start time.Time) *Simulation {
+	game := NewGameService(config, random, log, planets, npcs)
+	clock := NewSimulationClock(start, config.TickDuration)
+	game.clock = clock
+	game.updates = nil // simulations aren't streamed
+	return &Simulation{Game: game, clock: clock}
+}
+
+// Run executes given number of ticks. If observe is set, it's called with statistics
+// collected after each tick.
+func (s *Simulation) Run(ticks int, observe func(TickStats)) {
+	for i := 0; i < ticks; i++ {
+		s.clock.Advance()
+		s.Game.tick(0)
+		if observe != nil {
+			observe(CollectStats(s.Game))
+		}
+	}
+}
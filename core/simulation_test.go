@@ -0,0 +1,73 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SimulationSuite struct {
+	suite.Suite
+	config Config
+	log    *mockLog
+}
+
+func TestSimulationSuite(t *testing.T) {
+	suite.Run(t, new(SimulationSuite))
+}
+
+func (s *SimulationSuite) SetupTest() {
+	s.config = DefaultConfig()
+	s.log = &mockLog{}
+}
+
+func (s *SimulationSuite) TestRun() {
+	simulation := NewSimulation(s.config, 42, s.log)
+	start := simulation.clock.Now()
+	stats := []TickStats{}
+	simulation.Run(10, func(ts TickStats) {
+		stats = append(stats, ts)
+	})
+	s.Len(stats, 10)
+	s.Equal(uint64(1), stats[0].Tick)
+	s.Equal(uint64(10), stats[9].Tick)
+	s.Equal(start.Add(10*s.config.TickDuration), simulation.clock.Now())
+	s.Equal(len(simulation.Game.Planets), stats[9].OwnedPlanets+stats[9].UnownedPlanets)
+}
+
+func (s *SimulationSuite) TestRunWithoutObserver() {
+	simulation := NewSimulation(s.config, 42, s.log)
+	simulation.Run(3, nil)
+	s.Equal(uint64(3), simulation.Game.Status().Metrics.Ticks)
+}
+
+func (s *SimulationSuite) TestSameSeedSameResult() {
+	run := func() []TickStats {
+		stats := []TickStats{}
+		NewSimulation(s.config, 7, &mockLog{}).Run(200, func(ts TickStats) {
+			stats = append(stats, ts)
+		})
+		return stats
+	}
+	s.Equal(run(), run())
+}
+
+func (s *SimulationSuite) TestNPCCooldownFollowsSimulationTime() {
+	npc := &NPC{
+		Name:                 "Colonizer",
+		Offer:                map[ResourceType]int{Iron: 1, Food: 1, Fuel: 1},
+		Cargo:                map[ResourceType]int{},
+		ColonizationCooldown: time.Now().Add(time.Hour),
+	}
+	planet := &Planet{
+		Name:      "Target",
+		Resources: map[ResourceType]int{},
+		Modifiers: map[ResourceType]float64{},
+	}
+	simulation := newSimulation(Config{TickDuration: time.Hour}, &mockRand{seekVal: 0.01}, s.log, []*Planet{planet}, []*NPC{npc}, time.Now())
+
+	// First tick advances the clock by an hour, so cooldown is over and NPC colonizes.
+	simulation.Run(1, nil)
+	s.Equal(npc, planet.Owner)
+}
@@ -0,0 +1,286 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// Snapshot is the complete state of a universe between two ticks, including the config it runs with.
+// Entities refer to each other by name, so a snapshot can be written as JSON and restored later.
+type Snapshot struct {
+	Tick            uint64 // ticks completed when the snapshot was taken
+	Time            time.Time
+	Config          Config
+	Planets         []PlanetSnapshot
+	NPCs            []NPCSnapshot
+	Factions        []FactionSnapshot
+	Players         []PlayerSnapshot
+	ActiveEvents    []EventSnapshot
+	ScheduledEvents []ScheduledEventSnapshot
+}
+
+type PlanetSnapshot struct {
+	Name      string
+	Type      PlanetType
+	Resources map[ResourceType]int
+	Modifiers map[ResourceType]float64
+	Buildings []Building
+	Owner     string // name of the owning NPC
+	Player    string // ID of the owning player
+	Treasury  int
+	Garrison  int
+}
+
+type NPCSnapshot struct {
+	NPC
+	Strategy string
+	Faction  string
+	Location string
+}
+
+type FactionSnapshot struct {
+	Name      string
+	Relations map[string]int
+}
+
+// PlayerSnapshot keeps ID and account of a player, which aren't part of a player's JSON.
+type PlayerSnapshot struct {
+	Player
+	ID      string
+	Account string
+}
+
+type EventSnapshot struct {
+	Name           string
+	Target         EventTarget
+	Planet         string
+	Building       int // index of the targeted building on the planet, -1 if the event targets the planet
+	ResourceBoost  map[ResourceType]float64
+	Duration       int
+	RemainingTicks int
+}
+
+type ScheduledEventSnapshot struct {
+	Tick  uint64
+	Event EventSnapshot
+}
+
+// Snapshot returns the current state of the game.
+func (g *Game) Snapshot() Snapshot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.snapshot()
+}
+
+// snapshot returns the current state of the game, the caller has to hold the game's lock.
+func (g *Game) snapshot() Snapshot {
+	snapshot := Snapshot{
+		Tick:   g.metrics.Ticks,
+		Time:   g.clock.Now(),
+		Config: g.config,
+	}
+	for _, p := range g.Planets {
+		planet := PlanetSnapshot{
+			Name:      p.Name,
+			Type:      p.Type,
+			Resources: p.Resources,
+			Modifiers: p.Modifiers,
+			Treasury:  p.Treasury,
+			Garrison:  p.Garrison,
+		}
+		for _, b := range p.Buildings {
+			planet.Buildings = append(planet.Buildings, *b)
+		}
+		if p.Owner != nil {
+			planet.Owner = p.Owner.Name
+		}
+		if p.Player != nil {
+			planet.Player = p.Player.ID
+		}
+		snapshot.Planets = append(snapshot.Planets, planet)
+	}
+	for _, n := range g.NPCs {
+		npc := NPCSnapshot{NPC: *n}
+		if n.Strategy != nil {
+			npc.Strategy = n.Strategy.Name()
+		}
+		if n.Faction != nil {
+			npc.Faction = n.Faction.Name
+		}
+		if n.Location != nil {
+			npc.Location = n.Location.Name
+		}
+		snapshot.NPCs = append(snapshot.NPCs, npc)
+	}
+	for _, f := range g.Factions {
+		faction := FactionSnapshot{Name: f.Name, Relations: make(map[string]int)}
+		for other, relation := range f.Relations {
+			faction.Relations[other.Name] = relation
+		}
+		snapshot.Factions = append(snapshot.Factions, faction)
+	}
+	for _, pl := range g.Players {
+		snapshot.Players = append(snapshot.Players, PlayerSnapshot{Player: *pl, ID: pl.ID, Account: pl.Account})
+	}
+	for _, e := range g.ActiveEvents {
+		snapshot.ActiveEvents = append(snapshot.ActiveEvents, eventSnapshot(e))
+	}
+	for _, se := range g.scheduledEvents {
+		snapshot.ScheduledEvents = append(snapshot.ScheduledEvents, ScheduledEventSnapshot{Tick: se.Tick, Event: eventSnapshot(se.Event)})
+	}
+	return snapshot
+}
+
+func eventSnapshot(e *Event) EventSnapshot {
+	event := EventSnapshot{
+		Name:           e.Name,
+		Target:         e.Target,
+		Building:       -1,
+		ResourceBoost:  e.ResourceBoost,
+		Duration:       e.Duration,
+		RemainingTicks: e.RemainingTicks,
+	}
+	if e.TargetPlanet != nil {
+		event.Planet = e.TargetPlanet.Name
+		if e.TargetBuilding != nil {
+			event.Building = slices.Index(e.TargetPlanet.Buildings, e.TargetBuilding)
+		}
+	}
+	return event
+}
+
+// RestoreGame returns a game in the state of passed snapshot. Snapshots are restored from JSON, so the
+// game doesn't share any state with the game the snapshot has been taken of.
+func RestoreGame(snapshot Snapshot, random Random, log Log) (*Game, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	factions := make(map[string]*Faction)
+	for _, fs := range s.Factions {
+		factions[fs.Name] = &Faction{Name: fs.Name, Relations: make(map[*Faction]int)}
+	}
+	players := make(map[string]*Player)
+	for _, ps := range s.Players {
+		player := ps.Player
+		player.ID, player.Account = ps.ID, ps.Account
+		players[ps.ID] = &player
+	}
+	npcs := make(map[string]*NPC)
+	planets := make(map[string]*Planet)
+	for _, ps := range s.Planets {
+		p := &Planet{
+			Name:      ps.Name,
+			Type:      ps.Type,
+			Resources: ps.Resources,
+			Modifiers: ps.Modifiers,
+			Buildings: []*Building{},
+			Treasury:  ps.Treasury,
+			Garrison:  ps.Garrison,
+			Player:    players[ps.Player],
+		}
+		for _, b := range ps.Buildings {
+			p.Buildings = append(p.Buildings, &b)
+		}
+		planets[ps.Name] = p
+	}
+
+	game := NewGameService(s.Config, random, log, []*Planet{}, []*NPC{})
+	game.metrics.Ticks = s.Tick
+	for _, ns := range s.NPCs {
+		npc := ns.NPC
+		npc.Strategy = NPCStrategyFromString(ns.Strategy)
+		npc.Faction = factions[ns.Faction]
+		npc.Location = planets[ns.Location]
+		npcs[npc.Name] = &npc
+		game.NPCs = append(game.NPCs, &npc)
+	}
+	for _, ps := range s.Planets {
+		p := planets[ps.Name]
+		if ps.Owner != "" {
+			if p.Owner = npcs[ps.Owner]; p.Owner == nil {
+				return nil, fmt.Errorf("planet %s is owned by unknown NPC %s", ps.Name, ps.Owner)
+			}
+		}
+		game.Planets = append(game.Planets, p)
+	}
+	game.Factions = []*Faction{}
+	for _, fs := range s.Factions {
+		f := factions[fs.Name]
+		for name, relation := range fs.Relations {
+			f.Relations[factions[name]] = relation
+		}
+		game.Factions = append(game.Factions, f)
+	}
+	for _, ps := range s.Players {
+		game.Players = append(game.Players, players[ps.ID])
+	}
+	for _, es := range s.ActiveEvents {
+		e, err := restoreEvent(es, planets)
+		if err != nil {
+			return nil, err
+		}
+		game.ActiveEvents = append(game.ActiveEvents, e)
+	}
+	for _, ses := range s.ScheduledEvents {
+		e, err := restoreEvent(ses.Event, planets)
+		if err != nil {
+			return nil, err
+		}
+		game.scheduledEvents = append(game.scheduledEvents, &ScheduledEvent{Tick: ses.Tick, Event: e})
+	}
+	return game, nil
+}
+
+func restoreEvent(es EventSnapshot, planets map[string]*Planet) (*Event, error) {
+	e := &Event{
+		Name:           es.Name,
+		Target:         es.Target,
+		ResourceBoost:  es.ResourceBoost,
+		Duration:       es.Duration,
+		RemainingTicks: es.RemainingTicks,
+	}
+	if es.Planet == "" {
+		return e, nil
+	}
+	if e.TargetPlanet = planets[es.Planet]; e.TargetPlanet == nil {
+		return nil, fmt.Errorf("event %s targets unknown planet %s", es.Name, es.Planet)
+	}
+	if es.Building >= 0 {
+		if es.Building >= len(e.TargetPlanet.Buildings) {
+			return nil, fmt.Errorf("event %s targets unknown building %d on planet %s", es.Name, es.Building, es.Planet)
+		}
+		e.TargetBuilding = e.TargetPlanet.Buildings[es.Building]
+	}
+	return e, nil
+}
+
+// WriteSnapshotFile writes a snapshot as JSON to passed file.
+func WriteSnapshotFile(fileName string, snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, data, 0o600)
+}
+
+// ReadSnapshotFile reads a snapshot written by WriteSnapshotFile.
+func ReadSnapshotFile(fileName string) (Snapshot, error) {
+	var snapshot Snapshot
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return snapshot, err
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("invalid snapshot %s: %w", fileName, err)
+	}
+	return snapshot, nil
+}
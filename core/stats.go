@@ -0,0 +1,115 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TickStats is a summary of the universe economy after a tick, used for balancing analysis.
+type TickStats struct {
+	Tick            uint64         `json:"tick"`
+	Resources       map[string]int `json:"resources"`
+	NPCCredits      int            `json:"npcCredits"`
+	NPCCreditsByNPC map[string]int `json:"npcCreditsByNpc"`
+	NPCCargo        map[string]int `json:"npcCargo"`
+	OwnedPlanets    int            `json:"ownedPlanets"`
+	UnownedPlanets  int            `json:"unownedPlanets"`
+	PlanetsByOwner  map[string]int `json:"planetsByOwner"`
+	ActiveEvents    int            `json:"activeEvents"`
+	EventsTriggered map[string]int `json:"eventsTriggered"` // by planet type
+}
+
+// CollectStats summarizes current state of passed game.
+func CollectStats(g *Game) TickStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	stats := TickStats{
+		Tick:            g.metrics.Ticks,
+		Resources:       make(map[string]int),
+		NPCCreditsByNPC: make(map[string]int),
+		NPCCargo:        make(map[string]int),
+		PlanetsByOwner:  make(map[string]int),
+		ActiveEvents:    len(g.ActiveEvents),
+		EventsTriggered: make(map[string]int),
+	}
+	for _, res := range resourceTypes {
+		stats.Resources[res.String()] = 0
+		stats.NPCCargo[res.String()] = 0
+	}
+	for _, p := range g.Planets {
+		for res, amount := range p.Resources {
+			stats.Resources[res.String()] += amount
+		}
+		if p.Owner != nil {
+			stats.OwnedPlanets++
+			stats.PlanetsByOwner[p.Owner.Name]++
+		} else if p.Player != nil {
+			stats.OwnedPlanets++
+			stats.PlanetsByOwner[p.Player.Name]++
+		} else {
+			stats.UnownedPlanets++
+		}
+	}
+	for _, e := range g.triggeredEvents {
+		if e.TargetPlanet != nil {
+			stats.EventsTriggered[e.TargetPlanet.Type.String()]++
+		}
+	}
+	for _, npc := range g.NPCs {
+		stats.NPCCredits += npc.Credits
+		stats.NPCCreditsByNPC[npc.Name] = npc.Credits
+		for res, amount := range npc.Cargo {
+			stats.NPCCargo[res.String()] += amount
+		}
+	}
+	return stats
+}
+
+// WriteStatsCSV writes one row per tick. Per-NPC and per-owner values are only part of the JSON output.
+func WriteStatsCSV(w io.Writer, stats []TickStats) error {
+	writer := csv.NewWriter(w)
+	header := []string{"tick"}
+	for _, res := range resourceTypes {
+		header = append(header, "resource_"+strings.ToLower(res.String()))
+	}
+	header = append(header, "npc_credits")
+	for _, res := range resourceTypes {
+		header = append(header, "npc_cargo_"+strings.ToLower(res.String()))
+	}
+	header = append(header, "owned_planets", "unowned_planets", "active_events", "events_triggered")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, s := range stats {
+		row := []string{strconv.FormatUint(s.Tick, 10)}
+		for _, res := range resourceTypes {
+			row = append(row, strconv.Itoa(s.Resources[res.String()]))
+		}
+		row = append(row, strconv.Itoa(s.NPCCredits))
+		for _, res := range resourceTypes {
+			row = append(row, strconv.Itoa(s.NPCCargo[res.String()]))
+		}
+		triggered := 0
+		for _, count := range s.EventsTriggered {
+			triggered += count
+		}
+		row = append(row, strconv.Itoa(s.OwnedPlanets), strconv.Itoa(s.UnownedPlanets), strconv.Itoa(s.ActiveEvents), strconv.Itoa(triggered))
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteStatsJSON writes all tick statistics as a JSON array.
+func WriteStatsJSON(w io.Writer, stats []TickStats) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatsSuite struct {
+	suite.Suite
+	game *Game
+}
+
+func TestStatsSuite(t *testing.T) {
+	suite.Run(t, new(StatsSuite))
+}
+
+func (s *StatsSuite) SetupTest() {
+	npc := &NPC{
+		Name:    "Trader",
+		Credits: 150,
+		Cargo:   map[ResourceType]int{Iron: 5, Fuel: 1},
+	}
+	planets := []*Planet{
+		{Name: "A", Resources: map[ResourceType]int{Iron: 10, Food: 20, Fuel: 30}, Owner: npc},
+		{Name: "B", Type: Desert, Resources: map[ResourceType]int{Iron: 1, Food: 2, Fuel: 3}},
+	}
+	s.game = NewGameService(DefaultConfig(), &mockRand{}, &mockLog{}, planets, []*NPC{npc})
+	s.game.ActiveEvents = []*Event{{Name: "Storm", TargetPlanet: planets[1]}}
+	s.game.triggeredEvents = s.game.ActiveEvents
+	s.game.metrics.Ticks = 4
+}
+
+func (s *StatsSuite) TestCollectStats() {
+	stats := CollectStats(s.game)
+	s.Equal(uint64(4), stats.Tick)
+	s.Equal(map[string]int{"Iron": 11, "Food": 22, "Fuel": 33}, stats.Resources)
+	s.Equal(150, stats.NPCCredits)
+	s.Equal(150, stats.NPCCreditsByNPC["Trader"])
+	s.Equal(map[string]int{"Iron": 5, "Food": 0, "Fuel": 1}, stats.NPCCargo)
+	s.Equal(1, stats.OwnedPlanets)
+	s.Equal(1, stats.UnownedPlanets)
+	s.Equal(1, stats.PlanetsByOwner["Trader"])
+	s.Equal(1, stats.ActiveEvents)
+	s.Equal(map[string]int{"Desert": 1}, stats.EventsTriggered)
+}
+
+func (s *StatsSuite) TestWriteStatsCSV() {
+	buf := &bytes.Buffer{}
+	s.NoError(WriteStatsCSV(buf, []TickStats{CollectStats(s.game)}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	s.Len(lines, 2)
+	s.Equal("tick,resource_iron,resource_food,resource_fuel,npc_credits,npc_cargo_iron,npc_cargo_food,npc_cargo_fuel,owned_planets,unowned_planets,active_events,events_triggered", lines[0])
+	s.Equal("4,11,22,33,150,5,0,1,1,1,1,1", lines[1])
+}
+
+func (s *StatsSuite) TestWriteStatsJSON() {
+	buf := &bytes.Buffer{}
+	s.NoError(WriteStatsJSON(buf, []TickStats{CollectStats(s.game)}))
+	decoded := []TickStats{}
+	s.NoError(json.Unmarshal(buf.Bytes(), &decoded))
+	s.Len(decoded, 1)
+	s.Equal(22, decoded[0].Resources["Food"])
+	s.Equal(1, decoded[0].PlanetsByOwner["Trader"])
+}
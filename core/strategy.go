@@ -0,0 +1,484 @@
+package core
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NPCStrategy decides what an NPC does in a tick. Strategies only decide, actions are executed
+// by the game, so a strategy can be tested with an observation alone.
+type NPCStrategy interface {
+	Name() string
+	Decide(obs Observation, rand Random) []NPCAction
+}
+
+// Names of available NPC strategies, used in config and scenarios.
+const (
+	RandomStrategyName        = "random"
+	TraderStrategyName        = "trader"
+	ColonizerStrategyName     = "colonizer"
+	IndustrialistStrategyName = "industrialist"
+	HoarderStrategyName       = "hoarder"
+)
+
+// NPCStrategyFromString returns the strategy with given name, nil if there's none.
+func NPCStrategyFromString(name string) NPCStrategy {
+	switch strings.ToLower(name) {
+	case RandomStrategyName:
+		return RandomStrategy{}
+	case TraderStrategyName:
+		return TraderStrategy{}
+	case ColonizerStrategyName:
+		return ColonizerStrategy{}
+	case IndustrialistStrategyName:
+		return IndustrialistStrategy{}
+	case HoarderStrategyName:
+		return HoarderStrategy{}
+	default:
+		return nil
+	}
+}
+
+// ChooseNPCStrategy picks a strategy at random, weighted by passed weights.
+// Returns nil if there are no weights, NPCs without strategy use RandomStrategy.
+func ChooseNPCStrategy(weights map[string]float64, rand Random) NPCStrategy {
+
+	// sort names, so choices are reproducible with a seeded random generator
+	names := make([]string, 0, len(weights))
+	total := 0.0
+	for name, weight := range weights {
+		if weight > 0 && NPCStrategyFromString(name) != nil {
+			names = append(names, name)
+			total += weight
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	pick := rand.Seek() * total
+	for _, name := range names {
+		pick -= weights[name]
+		if pick < 0 {
+			return NPCStrategyFromString(name)
+		}
+	}
+	return NPCStrategyFromString(names[len(names)-1])
+}
+
+// ActionType defines what an NPC does.
+type ActionType int
+
+const (
+	BuyAction      ActionType = iota // buy a resource from a planet at market price
+	SellAction                       // sell a resource from cargo to a planet at market price
+	CollectAction                    // move a resource from an owned planet into cargo
+	ExchangeAction                   // trade with a planet via ExecuteTrade
+	ColonizeAction                   // take over an unowned planet and place a building on it
+	BuildAction                      // construct a building on an owned planet
+	UpgradeAction                    // upgrade a building on an owned planet
+	ArmAction                        // buy ships for the NPC's fleet
+	AttackAction                     // dispatch ships to attack a planet of a faction which isn't allied
+)
+
+func (t ActionType) String() string {
+	switch t {
+	case BuyAction:
+		return "Buy"
+	case SellAction:
+		return "Sell"
+	case CollectAction:
+		return "Collect"
+	case ExchangeAction:
+		return "Exchange"
+	case ColonizeAction:
+		return "Colonize"
+	case BuildAction:
+		return "Build"
+	case UpgradeAction:
+		return "Upgrade"
+	case ArmAction:
+		return "Arm"
+	case AttackAction:
+		return "Attack"
+	default:
+		return "Unknown"
+	}
+}
+
+// travels returns true if an NPC has to travel to the action's planet with its cargo.
+func travels(action NPCAction) bool {
+	switch action.Type {
+	case BuyAction, SellAction, CollectAction, ExchangeAction:
+		return action.Planet != nil
+	default:
+		return false
+	}
+}
+
+// NPCAction is a single decision of an NPC strategy.
+type NPCAction struct {
+	Type     ActionType
+	Planet   *Planet
+	Resource ResourceType // resource to buy, sell or collect
+	Amount   int          // amount to buy, sell or collect, ships to buy or to attack with
+	Building BuildingType // building to place on colonization or to build
+	Target   *Building    // building to upgrade
+}
+
+// Observation is what an NPC knows about the universe when its strategy decides.
+type Observation struct {
+	NPC        *NPC
+	Planets    []*Planet
+	Now        time.Time
+	SeedConfig SeedConfig     // build costs and production of new buildings
+	Conflict   ConflictConfig // ship costs and strength of fleets and defences
+}
+
+// CanColonize returns true if the NPC's colonization cooldown is over.
+func (o Observation) CanColonize() bool {
+	return !o.Now.Before(o.NPC.ColonizationCooldown)
+}
+
+// OwnedPlanets returns all planets owned by the observing NPC.
+func (o Observation) OwnedPlanets() []*Planet {
+	owned := []*Planet{}
+	for _, p := range o.Planets {
+		if p.Owner == o.NPC {
+			owned = append(owned, p)
+		}
+	}
+	return owned
+}
+
+// UnownedPlanets returns all planets without owner.
+func (o Observation) UnownedPlanets() []*Planet {
+	unowned := []*Planet{}
+	for _, p := range o.Planets {
+		if !IsPlanetColonized(p) {
+			unowned = append(unowned, p)
+		}
+	}
+	return unowned
+}
+
+// FreeCargo returns the cargo space left.
+func (o Observation) FreeCargo() int {
+	return max(0, o.NPC.MaxCargo-cargoLoad(o.NPC))
+}
+
+// BuyPrice returns the price per unit the observing NPC pays for a resource on a planet, including tariff.
+func (o Observation) BuyPrice(p *Planet, res ResourceType) int {
+	return BuyPrice(o.NPC, p, res)
+}
+
+// SellPrice returns the price per unit the observing NPC gets for a resource on a planet, after tariff.
+func (o Observation) SellPrice(p *Planet, res ResourceType) int {
+	return SellPrice(o.NPC, p, res)
+}
+
+// cheapestSeller returns the planet, not owned by the NPC or a rival faction, which sells given resource at the lowest price.
+func (o Observation) cheapestSeller(res ResourceType) (*Planet, int) {
+	var best *Planet
+	bestPrice := math.MaxInt
+	for _, p := range o.Planets {
+		if p.Owner == o.NPC || buyableStock(p, res) == 0 || Embargoed(o.NPC, p) {
+			continue
+		}
+		if price := o.BuyPrice(p, res); price < bestPrice {
+			best, bestPrice = p, price
+		}
+	}
+	return best, bestPrice
+}
+
+// bestBuyer returns the planet, not owned by a rival faction, which pays the highest price for given resource.
+func (o Observation) bestBuyer(res ResourceType) (*Planet, int) {
+	var best *Planet
+	bestPrice := 0
+	for _, p := range o.Planets {
+		if Embargoed(o.NPC, p) {
+			continue
+		}
+		if price := o.SellPrice(p, res); price > bestPrice {
+			best, bestPrice = p, price
+		}
+	}
+	return best, bestPrice
+}
+
+// buyAmount returns how many units the NPC can buy at passed price.
+func (o Observation) buyAmount(p *Planet, res ResourceType, price int) int {
+	return min(min(o.FreeCargo(), buyableStock(p, res)), o.NPC.Credits/max(1, price))
+}
+
+// sellExpensive returns actions to sell cargo on planets paying more than the NPC's offer.
+func (o Observation) sellExpensive() []NPCAction {
+	actions := []NPCAction{}
+	for _, res := range resourceTypes {
+		if o.NPC.Cargo[res] <= 0 {
+			continue
+		}
+		if p, price := o.bestBuyer(res); p != nil && price > o.NPC.Offer[res] {
+			actions = append(actions, NPCAction{Type: SellAction, Planet: p, Resource: res, Amount: o.NPC.Cargo[res]})
+		}
+	}
+	return actions
+}
+
+// collectFromColonies returns an action to fill free cargo with the most plentiful resource on owned planets.
+func (o Observation) collectFromColonies() []NPCAction {
+	if o.FreeCargo() == 0 {
+		return nil
+	}
+	var best *Planet
+	var bestRes ResourceType
+	for _, p := range o.OwnedPlanets() {
+		for _, res := range resourceTypes {
+			if best == nil || p.Resources[res] > best.Resources[bestRes] {
+				best, bestRes = p, res
+			}
+		}
+	}
+	if best == nil || best.Resources[bestRes] <= 0 {
+		return nil
+	}
+	return []NPCAction{{Type: CollectAction, Planet: best, Resource: bestRes, Amount: o.FreeCargo()}}
+}
+
+// colonizeRichest returns an action to colonize the unowned planet with most resources.
+// Planets rich in Iron get a Mine, all others a City.
+func (o Observation) colonizeRichest() []NPCAction {
+	if !o.CanColonize() {
+		return nil
+	}
+	var best *Planet
+	for _, p := range o.UnownedPlanets() {
+		if best == nil || totalResources(p) > totalResources(best) {
+			best = p
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	building := City
+	if BuildingAllowed(best.Type, Mine) && best.Resources[Iron] >= best.Resources[Food] {
+		building = Mine
+	}
+	return []NPCAction{{Type: ColonizeAction, Planet: best, Building: building}}
+}
+
+// conquerWeakest returns an action to attack the hostile planet which needs the fewest ships to
+// conquer, the richest one on ties. If the NPC's fleet is too small, it returns an action to buy
+// missing ships within its investment budget instead.
+func (o Observation) conquerWeakest() []NPCAction {
+	if !o.CanColonize() || o.Conflict.ShipCost <= 0 {
+		return nil
+	}
+	var target *Planet
+	needed := 0
+	for _, p := range o.Planets {
+		if !Hostile(o.NPC, p) {
+			continue
+		}
+		ships := ShipsToConquer(p, o.Conflict)
+		if target == nil || ships < needed || (ships == needed && totalResources(p) > totalResources(target)) {
+			target, needed = p, ships
+		}
+	}
+	if target == nil {
+		return nil
+	}
+	if o.NPC.Ships >= needed {
+		return []NPCAction{{Type: AttackAction, Planet: target, Amount: needed}}
+	}
+	if ships := min(needed-o.NPC.Ships, o.NPC.InvestmentBudget()/o.Conflict.ShipCost); ships > 0 {
+		return []NPCAction{{Type: ArmAction, Planet: target, Amount: ships}}
+	}
+	return nil
+}
+
+// developmentTypes are all buildings NPCs construct on their planets.
+var developmentTypes = []BuildingType{Mine, Farm, Refinery, Fortress}
+
+// developColonies returns an action to build a missing building or to upgrade one on an owned planet,
+// whichever requires the fewest credits to invest, within the NPC's investment budget.
+// Ties are broken by the amount of resources used. Buildings without costs are ignored.
+func (o Observation) developColonies() []NPCAction {
+
+	var best *NPCAction
+	bestCredits, bestAmount := math.MaxInt, math.MaxInt
+	consider := func(action NPCAction, cost map[ResourceType]int) {
+		amount := sumAmounts(cost)
+		if amount <= 0 {
+			return
+		}
+		credits := PlanInvestment(o.NPC, action.Planet, cost).Credits
+		if credits < bestCredits || (credits == bestCredits && amount < bestAmount) {
+			best, bestCredits, bestAmount = &action, credits, amount
+		}
+	}
+	for _, p := range o.OwnedPlanets() {
+		for _, buildingType := range developmentTypes {
+			if !hasBuilding(p, buildingType) && BuildingAllowed(p.Type, buildingType) {
+				consider(NPCAction{Type: BuildAction, Planet: p, Building: buildingType}, o.SeedConfig.BuildCosts[buildingType])
+			}
+		}
+		for _, b := range p.Buildings {
+			consider(NPCAction{Type: UpgradeAction, Planet: p, Target: b}, b.UpgradeCost())
+		}
+	}
+	if best == nil || bestCredits > o.NPC.InvestmentBudget() {
+		return nil
+	}
+	return []NPCAction{*best}
+}
+
+// RandomStrategy is the original NPC behaviour. Once its colonization cooldown is over, an NPC
+// colonizes a random planet with a chance of 5% per tick, otherwise it trades with a random planet
+// with a chance of 30%.
+type RandomStrategy struct{}
+
+func (RandomStrategy) Name() string { return RandomStrategyName }
+
+func (RandomStrategy) Decide(obs Observation, rand Random) []NPCAction {
+	if !obs.CanColonize() {
+		return nil
+	}
+
+	candidates := obs.UnownedPlanets()
+	if len(candidates) > 0 && rand.Seek() < 0.05 {
+		planet := candidates[rand.Of(len(candidates))]
+		building := Mine
+		if rand.Seek() < 0.7 {
+			building = City
+		}
+		return []NPCAction{{Type: ColonizeAction, Planet: planet, Building: building}}
+	}
+
+	if len(obs.Planets) > 0 && rand.Seek() < 0.3 {
+		return []NPCAction{{Type: ExchangeAction, Planet: obs.Planets[rand.Of(len(obs.Planets))]}}
+	}
+	return nil
+}
+
+// TraderStrategy seeks arbitrage. It sells cargo where it's paid more than its offer and buys the
+// resource with the largest price difference between planets where it's cheapest. Owned planets
+// are developed with what's left of its investment budget.
+type TraderStrategy struct{}
+
+func (TraderStrategy) Name() string { return TraderStrategyName }
+
+func (TraderStrategy) Decide(obs Observation, rand Random) []NPCAction {
+
+	actions := obs.sellExpensive()
+
+	var buyFrom *Planet
+	var buyRes ResourceType
+	bestSpread, buyPrice := 0, 0
+	for _, res := range resourceTypes {
+		seller, price := obs.cheapestSeller(res)
+		buyer, sellPrice := obs.bestBuyer(res)
+		if seller == nil || buyer == nil || buyer == seller {
+			continue
+		}
+		if spread := sellPrice - price; spread > bestSpread {
+			buyFrom, buyRes, bestSpread, buyPrice = seller, res, spread, price
+		}
+	}
+	if buyFrom != nil {
+		if amount := obs.buyAmount(buyFrom, buyRes, buyPrice); amount > 0 {
+			actions = append(actions, NPCAction{Type: BuyAction, Planet: buyFrom, Resource: buyRes, Amount: amount})
+		}
+	}
+	return append(actions, obs.developColonies()...)
+}
+
+// ColonizerStrategy expands as fast as its cooldown allows, always to the richest unowned planet.
+// Once all planets are owned, it arms a fleet and conquers planets of factions which aren't allied.
+// In between it develops its colonies, collects resources from them and sells them where they're paid well.
+type ColonizerStrategy struct{}
+
+func (ColonizerStrategy) Name() string { return ColonizerStrategyName }
+
+func (ColonizerStrategy) Decide(obs Observation, rand Random) []NPCAction {
+	if actions := obs.colonizeRichest(); len(actions) > 0 {
+		return actions
+	}
+	if len(obs.UnownedPlanets()) == 0 {
+		if actions := obs.conquerWeakest(); len(actions) > 0 {
+			return actions
+		}
+	}
+	actions := append(obs.sellExpensive(), obs.developColonies()...)
+	return append(actions, obs.collectFromColonies()...)
+}
+
+// IndustrialistStrategy develops its planets, it doesn't trade. Without any planet, it colonizes one.
+type IndustrialistStrategy struct{}
+
+func (IndustrialistStrategy) Name() string { return IndustrialistStrategyName }
+
+func (IndustrialistStrategy) Decide(obs Observation, rand Random) []NPCAction {
+	if len(obs.OwnedPlanets()) == 0 {
+		return obs.colonizeRichest()
+	}
+	return obs.developColonies()
+}
+
+// HoarderStrategy never sells. It develops its colonies, collects everything they produce and buys
+// resources wherever they're cheaper than its offer.
+type HoarderStrategy struct{}
+
+func (HoarderStrategy) Name() string { return HoarderStrategyName }
+
+func (HoarderStrategy) Decide(obs Observation, rand Random) []NPCAction {
+
+	actions := obs.developColonies()
+	if collect := obs.collectFromColonies(); len(collect) > 0 {
+		return append(actions, collect...)
+	}
+
+	var buyFrom *Planet
+	var buyRes ResourceType
+	bestRatio, buyPrice := 1.0, 0
+	for _, res := range resourceTypes {
+		seller, price := obs.cheapestSeller(res)
+		if seller == nil || obs.NPC.Offer[res] <= 0 {
+			continue
+		}
+		if ratio := float64(price) / float64(obs.NPC.Offer[res]); ratio < bestRatio {
+			buyFrom, buyRes, bestRatio, buyPrice = seller, res, ratio, price
+		}
+	}
+	if buyFrom != nil {
+		if amount := obs.buyAmount(buyFrom, buyRes, buyPrice); amount > 0 {
+			actions = append(actions, NPCAction{Type: BuyAction, Planet: buyFrom, Resource: buyRes, Amount: amount})
+		}
+	}
+	return actions
+}
+
+func hasBuilding(p *Planet, buildingType BuildingType) bool {
+	for _, b := range p.Buildings {
+		if b.Type == buildingType {
+			return true
+		}
+	}
+	return false
+}
+
+func sumAmounts(amounts map[ResourceType]int) int {
+	total := 0
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
+
+func totalResources(p *Planet) int {
+	return sumAmounts(p.Resources)
+}
@@ -0,0 +1,217 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StrategySuite struct {
+	suite.Suite
+	npc      *NPC
+	cheap    *Planet
+	scarce   *Planet
+	obs      Observation
+	log      *mockLog
+	now      time.Time
+	config   SeedConfig
+	conflict ConflictConfig
+	planets  []*Planet
+}
+
+func TestStrategySuite(t *testing.T) {
+	suite.Run(t, new(StrategySuite))
+}
+
+func (s *StrategySuite) SetupTest() {
+	s.log = &mockLog{}
+	s.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.config = DefaultSeedConfig()
+	s.conflict = DefaultConflictConfig()
+	s.npc = &NPC{
+		Name:                 "Tester",
+		Offer:                map[ResourceType]int{Iron: 10, Food: 10, Fuel: 10},
+		Credits:              1000,
+		Cargo:                map[ResourceType]int{Iron: 0, Food: 0, Fuel: 0},
+		MaxCargo:             100,
+		ColonizationCooldown: s.now.Add(time.Hour),
+	}
+	// Iron is plentiful on the first planet and scarce on the second one
+	s.cheap = &Planet{
+		Name:      "Cheap",
+		Type:      TerraLike,
+		Resources: map[ResourceType]int{Iron: 5000, Food: 1000, Fuel: 1000},
+		Modifiers: map[ResourceType]float64{},
+	}
+	s.scarce = &Planet{
+		Name:      "Scarce",
+		Type:      Desert,
+		Resources: map[ResourceType]int{Iron: 100, Food: 1000, Fuel: 1000},
+		Modifiers: map[ResourceType]float64{},
+	}
+	s.planets = []*Planet{s.cheap, s.scarce}
+	s.obs = Observation{NPC: s.npc, Planets: s.planets, Now: s.now, SeedConfig: s.config, Conflict: s.conflict}
+}
+
+func (s *StrategySuite) TestNPCStrategyFromString() {
+	for _, name := range []string{RandomStrategyName, TraderStrategyName, ColonizerStrategyName, IndustrialistStrategyName, HoarderStrategyName} {
+		strategy := NPCStrategyFromString(name)
+		s.NotNil(strategy)
+		s.Equal(name, strategy.Name())
+	}
+	s.Equal(TraderStrategy{}, NPCStrategyFromString("Trader"))
+	s.Nil(NPCStrategyFromString("pirate"))
+}
+
+func (s *StrategySuite) TestChooseNPCStrategy() {
+	weights := map[string]float64{TraderStrategyName: 1, HoarderStrategyName: 3}
+	// names are sorted, so hoarder covers [0, 3) and trader [3, 4)
+	s.Equal(HoarderStrategy{}, ChooseNPCStrategy(weights, &mockRand{seekVal: 0.5}))
+	s.Equal(TraderStrategy{}, ChooseNPCStrategy(weights, &mockRand{seekVal: 0.9}))
+	s.Nil(ChooseNPCStrategy(map[string]float64{}, &mockRand{}))
+	s.Nil(ChooseNPCStrategy(map[string]float64{"pirate": 1}, &mockRand{}))
+}
+
+func (s *StrategySuite) TestTraderBuysCheapForArbitrage() {
+	actions := TraderStrategy{}.Decide(s.obs, &mockRand{})
+	s.Len(actions, 1)
+	s.Equal(BuyAction, actions[0].Type)
+	s.Equal(s.cheap, actions[0].Planet)
+	s.Equal(Iron, actions[0].Resource)
+	s.Equal(100, actions[0].Amount)
+}
+
+func (s *StrategySuite) TestTraderSellsWherePaidWell() {
+	s.npc.Cargo[Iron] = 40
+	actions := TraderStrategy{}.Decide(s.obs, &mockRand{})
+	s.Equal(SellAction, actions[0].Type)
+	s.Equal(s.scarce, actions[0].Planet)
+	s.Equal(40, actions[0].Amount)
+}
+
+func (s *StrategySuite) TestColonizerPicksRichestPlanet() {
+	s.Empty(ColonizerStrategy{}.Decide(s.obs, &mockRand{}))
+
+	s.obs.Now = s.now.Add(2 * time.Hour)
+	actions := ColonizerStrategy{}.Decide(s.obs, &mockRand{})
+	s.Len(actions, 1)
+	s.Equal(ColonizeAction, actions[0].Type)
+	s.Equal(s.cheap, actions[0].Planet)
+	s.Equal(Mine, actions[0].Building)
+}
+
+func (s *StrategySuite) TestColonizerDevelopsAndCollects() {
+	s.cheap.Owner = s.npc
+	actions := ColonizerStrategy{}.Decide(s.obs, &mockRand{})
+	s.Len(actions, 2)
+	s.Equal(NPCAction{Type: BuildAction, Planet: s.cheap, Building: Farm}, actions[0])
+	s.Equal(CollectAction, actions[1].Type)
+	s.Equal(Iron, actions[1].Resource)
+	s.Equal(100, actions[1].Amount)
+}
+
+func (s *StrategySuite) TestColonizerConquersRivalPlanets() {
+	rival := &NPC{Name: "Rival"}
+	SetupFactions([]*NPC{s.npc, rival}, []string{"Red", "Blue"})
+	ChangeRelation(s.npc.Faction, rival.Faction, RivalryThreshold, s.log)
+	s.cheap.Owner, s.scarce.Owner = rival, rival
+	s.scarce.Garrison = 1
+	s.obs.Now = s.now.Add(2 * time.Hour)
+
+	// the weakest planet needs 3 ships, the budget of 400 credits buys one
+	s.npc.InvestmentShare = 40
+	s.Equal([]NPCAction{{Type: ArmAction, Planet: s.cheap, Amount: 1}}, ColonizerStrategy{}.Decide(s.obs, &mockRand{}))
+
+	s.npc.Ships = 3
+	s.Equal([]NPCAction{{Type: AttackAction, Planet: s.cheap, Amount: 3}}, ColonizerStrategy{}.Decide(s.obs, &mockRand{}))
+}
+
+func (s *StrategySuite) TestIndustrialistBuildsAndUpgrades() {
+	s.cheap.Owner = s.npc
+	actions := IndustrialistStrategy{}.Decide(s.obs, &mockRand{})
+	s.Equal([]NPCAction{{Type: BuildAction, Planet: s.cheap, Building: Farm}}, actions)
+
+	for _, buildingType := range buildingTypes {
+		s.cheap.Buildings = append(s.cheap.Buildings, NewBuilding(buildingType, s.config, &mockRand{}))
+	}
+	actions = IndustrialistStrategy{}.Decide(s.obs, &mockRand{})
+	s.Len(actions, 1)
+	s.Equal(UpgradeAction, actions[0].Type)
+	s.Equal(Farm, actions[0].Target.Type)
+}
+
+func (s *StrategySuite) TestIndustrialistInvestsWithinBudget() {
+	s.cheap.Owner = s.npc
+	s.cheap.Resources = map[ResourceType]int{Iron: 0, Food: 1000, Fuel: 1000}
+	s.npc.Cargo[Iron] = 20
+
+	// Iron is scarce, the 10 units a Farm needs beyond cargo cost 200 credits at market price
+	s.npc.InvestmentShare = 10
+	s.Empty(IndustrialistStrategy{}.Decide(s.obs, &mockRand{}))
+
+	s.npc.InvestmentShare = 20
+	actions := IndustrialistStrategy{}.Decide(s.obs, &mockRand{})
+	s.Equal([]NPCAction{{Type: BuildAction, Planet: s.cheap, Building: Farm}}, actions)
+}
+
+func (s *StrategySuite) TestHoarderNeverSells() {
+	s.npc.Cargo[Iron] = 40
+	actions := HoarderStrategy{}.Decide(s.obs, &mockRand{})
+	s.Len(actions, 1)
+	s.Equal(BuyAction, actions[0].Type)
+	s.Equal(s.cheap, actions[0].Planet)
+}
+
+func (s *StrategySuite) TestRandomStrategy() {
+	s.Empty(RandomStrategy{}.Decide(s.obs, &mockRand{seekVal: 0.01}))
+
+	s.obs.Now = s.now.Add(2 * time.Hour)
+	actions := RandomStrategy{}.Decide(s.obs, &mockRand{seekVal: 0.01, ofVal: 1})
+	s.Equal([]NPCAction{{Type: ColonizeAction, Planet: s.scarce, Building: City}}, actions)
+
+	s.cheap.Owner, s.scarce.Owner = s.npc, s.npc
+	actions = RandomStrategy{}.Decide(s.obs, &mockRand{seekVal: 0.2, ofVal: 0})
+	s.Equal([]NPCAction{{Type: ExchangeAction, Planet: s.cheap}}, actions)
+}
+
+func (s *StrategySuite) TestExecuteNPCActions() {
+	rand := &mockRand{ofVal: 0}
+	s.True(ExecuteNPCAction(s.npc, NPCAction{Type: BuyAction, Planet: s.cheap, Resource: Iron, Amount: 10}, s.config, s.conflict, s.now, rand, s.log))
+	s.Equal(10, s.npc.Cargo[Iron])
+	s.Equal(1000-10*5, s.npc.Credits)
+
+	s.True(ExecuteNPCAction(s.npc, NPCAction{Type: SellAction, Planet: s.scarce, Resource: Iron, Amount: 10}, s.config, s.conflict, s.now, rand, s.log))
+	s.Equal(0, s.npc.Cargo[Iron])
+	s.Equal(1000-50+200, s.npc.Credits)
+
+	s.False(ExecuteNPCAction(s.npc, NPCAction{Type: BuildAction, Planet: s.cheap, Building: Mine}, s.config, s.conflict, s.now, rand, s.log))
+
+	s.True(ExecuteNPCAction(s.npc, NPCAction{Type: ColonizeAction, Planet: s.cheap, Building: Mine}, s.config, s.conflict, s.now, rand, s.log))
+	s.Equal(s.npc, s.cheap.Owner)
+	s.Equal(s.now.Add(600*time.Second), s.npc.ColonizationCooldown)
+	s.False(ExecuteNPCAction(s.npc, NPCAction{Type: ColonizeAction, Planet: s.cheap, Building: City}, s.config, s.conflict, s.now, rand, s.log))
+
+	s.True(ExecuteNPCAction(s.npc, NPCAction{Type: BuildAction, Planet: s.cheap, Building: Farm}, s.config, s.conflict, s.now, rand, s.log))
+	s.Len(s.cheap.Buildings, 2)
+	s.True(ExecuteNPCAction(s.npc, NPCAction{Type: UpgradeAction, Planet: s.cheap, Target: s.cheap.Buildings[1]}, s.config, s.conflict, s.now, rand, s.log))
+	s.Equal(2, s.cheap.Buildings[1].Level)
+
+	s.True(ExecuteNPCAction(s.npc, NPCAction{Type: CollectAction, Planet: s.cheap, Resource: Food, Amount: 30}, s.config, s.conflict, s.now, rand, s.log))
+	s.Equal(30, s.npc.Cargo[Food])
+}
+
+func (s *StrategySuite) TestRunNPCLogicUsesStrategy() {
+	s.npc.Strategy = TraderStrategy{}
+	ledger := NewTradeLedger(10)
+	RunNPCLogic(s.npc, s.planets, DefaultConfig(), ledger, s.now, &mockRand{}, s.log)
+	s.Equal(100, s.npc.Cargo[Iron])
+	s.Equal(4900, s.cheap.Resources[Iron])
+	s.Equal(s.cheap, s.npc.Location)
+	s.Equal([]LedgerEntry{{
+		TradeAction: TradeAction{NPCName: "Tester", PlanetName: "Cheap", Resource: Iron, Amount: 100},
+		Type:        PurchaseEntry,
+		Credits:     500,
+		Time:        s.now,
+	}}, ledger.Entries())
+}
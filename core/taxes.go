@@ -0,0 +1,42 @@
+package core
+
+import "math"
+
+// CollectTaxes pays the production tax for all units produced on owned planets, as returned by
+// ProduceResources, into their treasuries. Unowned planets don't pay taxes.
+func CollectTaxes(produced map[*Planet]int, config TaxConfig, log Log) {
+	for p, units := range produced {
+		if !IsPlanetColonized(p) || units <= 0 {
+			continue
+		}
+		tax := int(float64(units) * config.ProductionTax)
+		if tax <= 0 {
+			continue
+		}
+		p.Treasury += tax
+		log.Debug("Planet %s paid %d credits production tax for %d units into its treasury.", p.Name, tax, units)
+	}
+}
+
+// PayOutTreasuries pays the configured share of each owned planet's treasury to its owner, an NPC
+// or a player, at least one credit. Treasuries of unowned planets are kept for the next owner.
+func PayOutTreasuries(planets []*Planet, config TaxConfig, log Log) {
+	for _, p := range planets {
+		if !IsPlanetColonized(p) || p.Treasury <= 0 {
+			continue
+		}
+		payout := min(p.Treasury, int(math.Ceil(float64(p.Treasury)*config.PayoutRate)))
+		if payout <= 0 {
+			continue
+		}
+		p.Treasury -= payout
+		if p.Player != nil {
+			p.Player.Credits += payout
+			log.Info("Player %s received %d credits from the treasury of planet %s.", p.Player.Name, payout, p.Name)
+			continue
+		}
+		p.Owner.Credits += payout
+		p.Owner.Revenue += payout
+		log.Info("NPC %s received %d credits from the treasury of planet %s.", p.Owner.Name, payout, p.Name)
+	}
+}
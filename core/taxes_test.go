@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TaxesSuite struct {
+	suite.Suite
+	owner   *NPC
+	owned   *Planet
+	unowned *Planet
+	config  TaxConfig
+	log     *mockLog
+}
+
+func TestTaxesSuite(t *testing.T) {
+	suite.Run(t, new(TaxesSuite))
+}
+
+func (s *TaxesSuite) SetupTest() {
+	s.log = &mockLog{}
+	s.owner = &NPC{Name: "Owner", Credits: 100}
+	s.owned = &Planet{Name: "Owned", Owner: s.owner}
+	s.unowned = &Planet{Name: "Unowned", Treasury: 50}
+	s.config = TaxConfig{ProductionTax: 0.5, PayoutRate: 0.25}
+}
+
+func (s *TaxesSuite) TestCollectTaxes() {
+	CollectTaxes(map[*Planet]int{s.owned: 41, s.unowned: 100}, s.config, s.log)
+	s.Equal(20, s.owned.Treasury)
+	s.Equal(50, s.unowned.Treasury)
+}
+
+func (s *TaxesSuite) TestPayOutTreasuries() {
+	s.owned.Treasury = 10
+	PayOutTreasuries([]*Planet{s.owned, s.unowned}, s.config, s.log)
+	s.Equal(7, s.owned.Treasury)
+	s.Equal(103, s.owner.Credits)
+	s.Equal(3, s.owner.Revenue)
+	s.Equal(50, s.unowned.Treasury)
+
+	// small treasuries pay at least one credit, so they're emptied eventually
+	s.owned.Treasury = 1
+	PayOutTreasuries([]*Planet{s.owned}, s.config, s.log)
+	s.Equal(0, s.owned.Treasury)
+	s.Equal(104, s.owner.Credits)
+}
+
+func (s *TaxesSuite) TestProductionIsTaxed() {
+	s.owned.Resources = map[ResourceType]int{Iron: 0}
+	s.owned.Buildings = []*Building{{Type: Mine, Level: 2, Production: map[ResourceType]int{Iron: 10}}}
+	produced := ProduceResources([]*Planet{s.owned}, s.log)
+	s.Equal(map[*Planet]int{s.owned: 20}, produced)
+
+	CollectTaxes(produced, s.config, s.log)
+	s.Equal(10, s.owned.Treasury)
+}
@@ -56,6 +56,14 @@ func (l *mockLog) Flush() {
 	// No-op for mock, but present to satisfy Log interface.
 }
 
+// nopLog discards all messages and can be shared between goroutines.
+type nopLog struct{}
+
+func (l *nopLog) Info(format string, args ...interface{})  {}
+func (l *nopLog) Error(format string, args ...interface{}) {}
+func (l *nopLog) Debug(format string, args ...interface{}) {}
+func (l *nopLog) Flush()                                   {}
+
 func loadConfigForTest(fileName *string) config.Config {
 
 	configFile := "fixtures/testconfig.yml"
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tommzn/go-config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TLSConfig defines the certificates of a gRPC server or client, e.g. mounted as secrets.
+// TLS is disabled without certificate and CA.
+type TLSConfig struct {
+	CertFile   string // certificate presented to the peer, required on servers, enables mTLS on clients
+	KeyFile    string // private key of the certificate
+	CAFile     string // CA verifying the peer: client certificates are required on servers, on clients it replaces the system CAs
+	ServerName string // name expected in the server certificate, clients use the host dialed if empty
+}
+
+// Enabled returns true if TLS is configured.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.CAFile != ""
+}
+
+// LoadTLSConfig reads TLS config below passed key, e.g. tls.cert_file.
+func LoadTLSConfig(conf config.Config, key string) TLSConfig {
+	return TLSConfig{
+		CertFile:   *conf.Get(key+".cert_file", config.AsStringPtr("")),
+		KeyFile:    *conf.Get(key+".key_file", config.AsStringPtr("")),
+		CAFile:     *conf.Get(key+".ca_file", config.AsStringPtr("")),
+		ServerName: *conf.Get(key+".server_name", config.AsStringPtr("")),
+	}
+}
+
+// TLSServerOptions returns gRPC server options serving TLS with certificates of passed config,
+// none if TLS is disabled.
+func TLSServerOptions(config TLSConfig, log Log) ([]grpc.ServerOption, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+	reloader, err := NewCertReloader(config, log)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(reloader.ServerTLSConfig()))}, nil
+}
+
+// ClientCredentials returns gRPC client credentials using TLS with certificates of passed config,
+// plaintext if TLS is disabled.
+func ClientCredentials(config TLSConfig, log Log) (credentials.TransportCredentials, error) {
+	if !config.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	reloader, err := NewCertReloader(config, log)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(reloader.ClientTLSConfig()), nil
+}
+
+// CertReloader provides certificate and CA of a TLS config and reloads them on the next handshake
+// once their files have changed, so renewed certificates are used without a restart. If reloading
+// fails, e.g. while files are replaced, the last certificates are kept.
+type CertReloader struct {
+	config TLSConfig
+	log    Log
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+// NewCertReloader loads certificate and CA of passed config, or returns an error if they can't be loaded.
+func NewCertReloader(config TLSConfig, log Log) (*CertReloader, error) {
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, errors.New("tls: cert_file and key_file have to be set together")
+	}
+	r := &CertReloader{config: config, log: log}
+	if err := r.load(r.currentModTimes()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ServerTLSConfig returns a config for servers. With a CA, clients have to present a certificate signed by it.
+func (r *CertReloader) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := r.current()
+			if cert == nil {
+				return nil, errors.New("tls: no server certificate")
+			}
+			config := &tls.Config{MinVersion: tls.VersionTLS12, Certificates: []tls.Certificate{*cert}}
+			if pool != nil {
+				config.ClientCAs = pool
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
+
+// ClientTLSConfig returns a config for clients, presenting the certificate if there's one. The server
+// certificate is verified against the CA, or the system CAs without one.
+func (r *CertReloader) ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: r.config.ServerName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if cert, _ := r.current(); cert != nil {
+				return cert, nil
+			}
+			return &tls.Certificate{}, nil
+		},
+		// The default verification uses the CA at the time the config is created,
+		// it's replaced by a verification against the current CA.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			_, pool := r.current()
+			return verifyServerCertificate(state, pool)
+		},
+	}
+}
+
+// current returns certificate and CA, after reloading them if their files have changed.
+func (r *CertReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	modTimes := r.currentModTimes()
+	if changed(r.modTimes, modTimes) {
+		if err := r.load(modTimes); err != nil {
+			r.log.Error("Unable to reload certificates, keeping the current ones: %v", err)
+		} else {
+			r.log.Info("Reloaded certificates.")
+		}
+	}
+	return r.cert, r.pool
+}
+
+// load reads certificate and CA from their files and remembers passed modification times.
+func (r *CertReloader) load(modTimes map[string]time.Time) error {
+	var cert *tls.Certificate
+	if r.config.CertFile != "" {
+		loaded, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+		if err != nil {
+			return fmt.Errorf("tls: unable to load certificate: %w", err)
+		}
+		cert = &loaded
+	}
+	var pool *x509.CertPool
+	if r.config.CAFile != "" {
+		pem, err := os.ReadFile(r.config.CAFile)
+		if err != nil {
+			return fmt.Errorf("tls: unable to read CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("tls: no certificates in CA file %s", r.config.CAFile)
+		}
+	}
+	r.cert, r.pool, r.modTimes = cert, pool, modTimes
+	return nil
+}
+
+func (r *CertReloader) currentModTimes() map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	for _, file := range []string{r.config.CertFile, r.config.KeyFile, r.config.CAFile} {
+		if file == "" {
+			continue
+		}
+		if info, err := os.Stat(file); err == nil {
+			modTimes[file] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+func changed(last, current map[string]time.Time) bool {
+	if len(last) != len(current) {
+		return true
+	}
+	for file, modTime := range current {
+		if !last[file].Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyServerCertificate verifies the certificate chain and name of a server, against passed CA
+// or the system CAs if it's nil.
+func verifyServerCertificate(state tls.ConnectionState, pool *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("tls: server presented no certificate")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         pool,
+		DNSName:       state.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range state.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(opts)
+	return err
+}
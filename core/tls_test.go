@@ -0,0 +1,200 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	pb "github.com/tommzn/utte-universe/core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type TLSSuite struct {
+	suite.Suite
+	dir  string
+	log  *mockLog
+	game *Game
+}
+
+func TestTLSSuite(t *testing.T) {
+	suite.Run(t, new(TLSSuite))
+}
+
+func (s *TLSSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.log = &mockLog{}
+	s.game = NewGameService(Config{TickDuration: time.Second}, &mockRand{}, s.log, []*Planet{}, []*NPC{})
+}
+
+// testCA is a self-signed CA issuing certificates for tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func (s *TLSSuite) newCA(name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a certificate for localhost signed by the CA, and its key, to files with passed name.
+func (s *TLSSuite) issue(ca *testCA, name string, usage x509.ExtKeyUsage) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	s.Require().NoError(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+	certFile, keyFile := filepath.Join(s.dir, name+".crt"), filepath.Join(s.dir, name+".key")
+	s.writeFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	s.writeFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certFile, keyFile
+}
+
+func (s *TLSSuite) writeCA(ca *testCA, name string) string {
+	file := filepath.Join(s.dir, name+".pem")
+	s.writeFile(file, ca.pem)
+	return file
+}
+
+// writeFile writes a file and moves its modification time forward, so a reload is detected
+// even if a file is replaced within the resolution of file times.
+func (s *TLSSuite) writeFile(file string, data []byte) {
+	modTime := time.Now()
+	if info, err := os.Stat(file); err == nil {
+		modTime = info.ModTime().Add(time.Second)
+	}
+	s.Require().NoError(os.WriteFile(file, data, 0600))
+	s.Require().NoError(os.Chtimes(file, modTime, modTime))
+}
+
+// serve starts a gRPC server with passed TLS config and returns its address.
+func (s *TLSSuite) serve(config TLSConfig) string {
+	opts, err := TLSServerOptions(config, s.log)
+	s.Require().NoError(err)
+	server, lis, err := NewGRPCServer(s.game, "127.0.0.1:0", nil, s.log, opts...)
+	s.Require().NoError(err)
+	go server.Serve(lis)
+	s.T().Cleanup(server.Stop)
+	_, port, _ := net.SplitHostPort(lis.Addr().String())
+	return "localhost:" + port
+}
+
+// call requests the game status with passed client credentials.
+func (s *TLSSuite) call(addr string, creds credentials.TransportCredentials) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	s.Require().NoError(err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = pb.NewUniverseServiceClient(conn).GetGameStatus(ctx, &pb.Empty{})
+	return err
+}
+
+func (s *TLSSuite) clientCreds(config TLSConfig) credentials.TransportCredentials {
+	creds, err := ClientCredentials(config, s.log)
+	s.Require().NoError(err)
+	return creds
+}
+
+func (s *TLSSuite) TestServerTLS() {
+	ca := s.newCA("ca")
+	certFile, keyFile := s.issue(ca, "server", x509.ExtKeyUsageServerAuth)
+	addr := s.serve(TLSConfig{CertFile: certFile, KeyFile: keyFile})
+
+	s.NoError(s.call(addr, s.clientCreds(TLSConfig{CAFile: s.writeCA(ca, "ca")})))
+	s.Error(s.call(addr, insecure.NewCredentials()))
+	s.Error(s.call(addr, s.clientCreds(TLSConfig{CAFile: s.writeCA(s.newCA("other"), "other")})))
+}
+
+func (s *TLSSuite) TestMutualTLS() {
+	ca := s.newCA("ca")
+	caFile := s.writeCA(ca, "ca")
+	serverCert, serverKey := s.issue(ca, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := s.issue(ca, "client", x509.ExtKeyUsageClientAuth)
+	addr := s.serve(TLSConfig{CertFile: serverCert, KeyFile: serverKey, CAFile: caFile})
+
+	s.Error(s.call(addr, s.clientCreds(TLSConfig{CAFile: caFile})))
+	s.NoError(s.call(addr, s.clientCreds(TLSConfig{CertFile: clientCert, KeyFile: clientKey, CAFile: caFile})))
+
+	foreignCert, foreignKey := s.issue(s.newCA("other"), "foreign", x509.ExtKeyUsageClientAuth)
+	s.Error(s.call(addr, s.clientCreds(TLSConfig{CertFile: foreignCert, KeyFile: foreignKey, CAFile: caFile})))
+}
+
+func (s *TLSSuite) TestCertificatesAreReloaded() {
+	oldCA, newCA := s.newCA("old"), s.newCA("new")
+	caFile := s.writeCA(oldCA, "ca")
+	serverCert, serverKey := s.issue(oldCA, "server", x509.ExtKeyUsageServerAuth)
+	addr := s.serve(TLSConfig{CertFile: serverCert, KeyFile: serverKey})
+	creds := s.clientCreds(TLSConfig{CAFile: caFile})
+	s.NoError(s.call(addr, creds))
+
+	// the server certificate is renewed by another CA, the client doesn't trust yet
+	s.issue(newCA, "server", x509.ExtKeyUsageServerAuth)
+	s.Error(s.call(addr, creds))
+
+	s.writeCA(newCA, "ca")
+	s.NoError(s.call(addr, creds))
+	s.Contains(s.log.infos, "Reloaded certificates.")
+}
+
+func (s *TLSSuite) TestBrokenFilesKeepCertificates() {
+	ca := s.newCA("ca")
+	certFile, keyFile := s.issue(ca, "server", x509.ExtKeyUsageServerAuth)
+	addr := s.serve(TLSConfig{CertFile: certFile, KeyFile: keyFile})
+	creds := s.clientCreds(TLSConfig{CAFile: s.writeCA(ca, "ca")})
+
+	s.writeFile(certFile, []byte("broken"))
+	s.NoError(s.call(addr, creds))
+	s.NotEmpty(s.log.errors)
+}
+
+func (s *TLSSuite) TestInvalidConfig() {
+	_, err := NewCertReloader(TLSConfig{CertFile: "server.crt"}, s.log)
+	s.ErrorContains(err, "key_file")
+	_, err = NewCertReloader(TLSConfig{CAFile: filepath.Join(s.dir, "missing.pem")}, s.log)
+	s.Error(err)
+	_, err = NewCertReloader(TLSConfig{CAFile: s.writeCA(&testCA{pem: []byte("no pem")}, "empty")}, s.log)
+	s.ErrorContains(err, "no certificates")
+
+	s.False(TLSConfig{}.Enabled())
+	s.True(TLSConfig{CAFile: "ca.pem"}.Enabled())
+}
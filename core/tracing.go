@@ -0,0 +1,215 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/tommzn/go-config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/tommzn/utte-universe/core/proto"
+)
+
+// tracer creates spans of the game. It uses the global tracer provider, spans aren't recorded until
+// tracing has been set up.
+var tracer = otel.Tracer("github.com/tommzn/utte-universe/core")
+
+// Tracing exporters, see TracingConfig.
+const (
+	OTLPExporter = "otlp"
+	FileExporter = "file"
+)
+
+// TracingConfig defines where spans are exported to. Tracing is disabled without exporter.
+type TracingConfig struct {
+	Exporter    string  // otlp exports to an OTLP collector via gRPC, file writes spans as JSON lines
+	Endpoint    string  // address of the OTLP collector, e.g. otel-collector:4317, OTEL_EXPORTER_OTLP_ENDPOINT if empty
+	Insecure    bool    // connects to the OTLP collector without TLS
+	File        string  // file the file exporter writes to
+	SampleRatio float64 // share of traces recorded, traces continued from another service follow its decision
+}
+
+// LoadTracingConfig reads tracing config below passed key, e.g. tracing.exporter.
+func LoadTracingConfig(conf config.Config, key string) (TracingConfig, error) {
+	tracing := TracingConfig{
+		Exporter: *conf.Get(key+".exporter", config.AsStringPtr("")),
+		Endpoint: *conf.Get(key+".endpoint", config.AsStringPtr("")),
+		Insecure: *conf.GetAsBool(key+".insecure", config.AsBoolPtr(false)),
+		File:     *conf.Get(key+".file", config.AsStringPtr("")),
+	}
+	ratio, err := strconv.ParseFloat(*conf.Get(key+".sample_ratio", config.AsStringPtr("1")), 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return TracingConfig{}, fmt.Errorf("tracing: sample_ratio has to be between 0 and 1")
+	}
+	tracing.SampleRatio = ratio
+	return tracing, nil
+}
+
+// SetupTracing installs a global tracer provider exporting spans of passed service as configured, and
+// propagation of trace context between services. The returned function flushes pending spans and stops
+// exporting, it has to be called on shutdown. Without exporter, spans aren't recorded.
+func SetupTracing(ctx context.Context, config TracingConfig, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var file *os.File
+	switch config.Exporter {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case OTLPExporter:
+		opts := []otlptracegrpc.Option{}
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		otlpExporter, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("tracing: unable to create OTLP exporter: %w", err)
+		}
+		exporter = otlpExporter
+	case FileExporter:
+		if config.File == "" {
+			return nil, errors.New("tracing: file exporter requires a file")
+		}
+		var err error
+		if file, err = os.Create(config.File); err != nil {
+			return nil, fmt.Errorf("tracing: unable to create %s: %w", config.File, err)
+		}
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(file)); err != nil {
+			file.Close()
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("tracing: unknown exporter %q", config.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// metadataCarrier lets propagators read and write trace context as gRPC metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// InjectTraceContext adds the trace context of passed context to the metadata of outgoing gRPC requests,
+// so spans of the receiving service continue the trace.
+func InjectTraceContext(ctx context.Context) context.Context {
+	md := metadata.MD{}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	for key, values := range md {
+		for _, value := range values {
+			ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+		}
+	}
+	return ctx
+}
+
+// ExtractTraceContext returns a context continuing the trace passed in the metadata of an incoming gRPC request.
+func ExtractTraceContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+}
+
+// TracingServerOptions returns interceptors recording a span per gRPC request, or stream, which
+// continues the trace passed by the client.
+func TracingServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, span := tracer.Start(ExtractTraceContext(ctx), info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+			resp, err := handler(ctx, req)
+			recordError(span, err)
+			return resp, err
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, span := tracer.Start(ExtractTraceContext(ss.Context()), info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+			err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+			recordError(span, err)
+			return err
+		}),
+	}
+}
+
+// tracedStream is a server stream whose context carries the span of the stream.
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+// recordError marks passed span as failed, if there's an error.
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// startCommandSpan starts a span of a client command received on a stream, as child of the stream's span.
+func startCommandSpan(ctx context.Context, cmd *pb.ClientCommand) trace.Span {
+	_, span := tracer.Start(ctx, "client_command", trace.WithAttributes(attribute.String("command", cmd.Type.String())))
+	return span
+}
+
+// startPhase starts a span of a phase of a game tick and returns the function ending it.
+func startPhase(ctx context.Context, name string) func() {
+	_, span := tracer.Start(ctx, name)
+	return func() { span.End() }
+}
+
+// startTickSpan starts the span of a game tick, phases of the tick are recorded as its children.
+func startTickSpan(tick uint64) (context.Context, trace.Span) {
+	return tracer.Start(context.Background(), "game.tick", trace.WithAttributes(attribute.Int64("game.tick", int64(tick))))
+}
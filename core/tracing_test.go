@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+type TracingSuite struct {
+	suite.Suite
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
+
+func (s *TracingSuite) TestLoadTracingConfigDefaults() {
+	tracing, err := LoadTracingConfig(loadConfigForTest(nil), "tracing")
+	s.Require().NoError(err)
+	s.Equal("", tracing.Exporter)
+	s.Equal(1.0, tracing.SampleRatio)
+}
+
+func (s *TracingSuite) TestSetupTracingWithoutExporter() {
+	shutdown, err := SetupTracing(context.Background(), TracingConfig{}, "test")
+	s.Require().NoError(err)
+	s.NoError(shutdown(context.Background()))
+}
+
+func (s *TracingSuite) TestSetupTracingUnknownExporter() {
+	_, err := SetupTracing(context.Background(), TracingConfig{Exporter: "jaeger"}, "test")
+	s.Error(err)
+
+	_, err = SetupTracing(context.Background(), TracingConfig{Exporter: FileExporter}, "test")
+	s.Error(err)
+}
+
+func (s *TracingSuite) TestTickPhasesExportedToFile() {
+	file := filepath.Join(s.T().TempDir(), "spans.json")
+	shutdown, err := SetupTracing(context.Background(), TracingConfig{Exporter: FileExporter, File: file, SampleRatio: 1}, "test")
+	s.Require().NoError(err)
+
+	planets := []*Planet{{Name: "Mars", Type: Desert, Resources: map[ResourceType]int{Iron: 10}, Modifiers: map[ResourceType]float64{}}}
+	game := NewGameService(Config{TickDuration: time.Second}, &mockRand{}, &nopLog{}, planets, []*NPC{})
+	game.tick(time.Second)
+	s.Require().NoError(shutdown(context.Background()))
+
+	content, err := os.ReadFile(file)
+	s.Require().NoError(err)
+	for _, name := range []string{"game.tick", "production", "events", "npc_logic", "broadcast"} {
+		s.Contains(string(content), `"Name":"`+name+`"`)
+	}
+}
+
+func (s *TracingSuite) TestTraceContextPropagation() {
+	_, err := SetupTracing(context.Background(), TracingConfig{}, "test")
+	s.Require().NoError(err)
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := InjectTraceContext(trace.ContextWithSpanContext(context.Background(), spanContext))
+	md, ok := metadata.FromOutgoingContext(ctx)
+	s.Require().True(ok)
+
+	extracted := trace.SpanContextFromContext(ExtractTraceContext(metadata.NewIncomingContext(context.Background(), md)))
+	s.Equal(spanContext.TraceID(), extracted.TraceID())
+	s.Equal(spanContext.SpanID(), extracted.SpanID())
+	s.True(extracted.IsRemote())
+}
@@ -5,7 +5,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/tommzn/go-log"
+	"github.com/tommzn/utte-universe/core"
 )
 
 type HealthServer struct {
@@ -14,11 +18,14 @@ type HealthServer struct {
 	done   chan struct{}
 }
 
-func NewHealthServer(addr string, logger log.Logger) *HealthServer {
+// NewHealthServer serves the result of passed liveness check at /livez, and at /healthz for existing probes,
+// of passed readiness check at /readyz and metrics of passed gatherer at /metrics.
+func NewHealthServer(addr string, liveness, readiness core.HealthCheck, gatherer prometheus.Gatherer, logger log.Logger) *HealthServer {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNoContent)
-	})
+	mux.HandleFunc("/livez", healthHandler(liveness, logger))
+	mux.HandleFunc("/healthz", healthHandler(liveness, logger))
+	mux.HandleFunc("/readyz", healthHandler(readiness, logger))
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
 
 	return &HealthServer{
 		server: &http.Server{
@@ -30,6 +37,18 @@ func NewHealthServer(addr string, logger log.Logger) *HealthServer {
 	}
 }
 
+// healthHandler responds with 204 if passed check succeeds, with 503 and the failure otherwise.
+func healthHandler(check core.HealthCheck, logger log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := check(); err != nil {
+			logger.Errorf("Health check %s failed: %v", r.URL.Path, err)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 func (hs *HealthServer) Start() {
 	hs.logger.Infof("Health server listening on %s", hs.server.Addr)
 	if err := hs.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -46,3 +65,10 @@ func (hs *HealthServer) Shutdown(timeout time.Duration) {
 	}
 	<-hs.done
 }
+
+// newMetricsRegistry returns a registry with metrics of the Go runtime and the process.
+func newMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	return registry
+}
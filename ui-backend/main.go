@@ -2,16 +2,22 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+
+	"github.com/tommzn/go-secrets"
+	"github.com/tommzn/utte-universe/core"
 )
 
 func main() {
 
-	logger := bootstrap()
+	conf, logger, secretsManager := bootstrap()
 	defer logger.Flush()
 
 	backendAddr := os.Getenv("GAME_BACKEND_ADDR")
@@ -19,7 +25,38 @@ func main() {
 		backendAddr = "localhost:8081"
 	}
 
-	ui, err := NewUIBackend(backendAddr, logger)
+	verifier, err := newTokenVerifier(secretsManager)
+	if err != nil {
+		logger.Errorf("Failed to set up authentication: %v", err)
+		os.Exit(1)
+	}
+	if verifier == nil {
+		logger.Error("AUTH_DISABLED is set, websocket clients aren't authenticated")
+	}
+	var allowedOrigins []string
+	if origins := os.Getenv("ALLOWED_ORIGINS"); origins != "" {
+		allowedOrigins = strings.Split(origins, ",")
+	}
+
+	creds, err := core.ClientCredentials(core.LoadTLSConfig(conf, "backend_tls"), core.NewCustomLogger(logger))
+	if err != nil {
+		logger.Errorf("Failed to load TLS certificates: %v", err)
+		os.Exit(1)
+	}
+
+	tracingConfig, err := core.LoadTracingConfig(conf, "tracing")
+	if err != nil {
+		logger.Errorf("Failed to load tracing configuration: %v", err)
+		os.Exit(1)
+	}
+	shutdownTracing, err := core.SetupTracing(context.Background(), tracingConfig, "utte-ui-backend")
+	if err != nil {
+		logger.Errorf("Failed to set up tracing: %v", err)
+		os.Exit(1)
+	}
+
+	registry := newMetricsRegistry()
+	ui, err := NewUIBackend(backendAddr, creds, verifier, allowedOrigins, registry, logger)
 	if err != nil {
 		logger.Errorf("Failed to connect to game backend: %v", err)
 		os.Exit(1)
@@ -30,7 +67,8 @@ func main() {
 	mux.HandleFunc("/ws", ui.handleWebsocket)
 
 	healthPort := os.Getenv("HEALTH_CHECK_PORT")
-	healthServer := NewHealthServer(":"+healthPort, logger)
+	alive := func() error { return nil }
+	healthServer := NewHealthServer(":"+healthPort, alive, ui.checkBackend, registry, logger)
 	go healthServer.Start()
 
 	httpPort := os.Getenv("HTTP_PORT")
@@ -57,5 +95,35 @@ func main() {
 
 	healthServer.Shutdown(5 * time.Second)
 
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Errorf("Failed to flush spans: %v", err)
+	}
+
 	logger.Info("UI backend exited cleanly")
 }
+
+// newTokenVerifier returns a verifier for tokens signed with the key AUTH_TOKEN_KEY and issued by
+// AUTH_ISSUER, if set. Authentication is only disabled, and nil returned, if AUTH_DISABLED is true and
+// no key is configured. A missing, unreadable or empty key is an error, the server mustn't run without
+// authentication by accident.
+func newTokenVerifier(secretsManager secrets.SecretsManager) (*core.TokenVerifier, error) {
+	disabled, err := core.AuthDisabled()
+	if err != nil {
+		return nil, err
+	}
+	key, err := secretsManager.Obtain(core.AuthTokenKey)
+	var notFound *secrets.SecretNotFoundError
+	switch {
+	case errors.As(err, &notFound) && disabled:
+		return nil, nil
+	case errors.As(err, &notFound):
+		return nil, fmt.Errorf("%s isn't configured, set %s=true to run without authentication", core.AuthTokenKey, core.AuthDisabledKey)
+	case err != nil:
+		return nil, fmt.Errorf("unable to obtain %s: %w", core.AuthTokenKey, err)
+	case disabled:
+		return nil, fmt.Errorf("%s is configured, but %s is true", core.AuthTokenKey, core.AuthDisabledKey)
+	case *key == "":
+		return nil, fmt.Errorf("%s is empty", core.AuthTokenKey)
+	}
+	return core.NewTokenVerifier([]byte(*key), os.Getenv("AUTH_ISSUER")), nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// uiMetrics exposes websocket connections of the ui-backend to Prometheus.
+type uiMetrics struct {
+	connections   prometheus.Gauge
+	connectionsBy *prometheus.CounterVec
+	updates       prometheus.Counter
+	streamErrors  prometheus.Counter
+	reconnects    prometheus.Counter
+}
+
+// newUIMetrics creates metrics of the ui-backend and registers them at passed registerer.
+func newUIMetrics(reg prometheus.Registerer) *uiMetrics {
+	m := &uiMetrics{
+		connections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "utte_ui_websocket_connections",
+			Help: "Open websocket connections.",
+		}),
+		connectionsBy: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "utte_ui_websocket_connections_total",
+			Help: "Websocket connection attempts, by result: accepted, unauthorized or failed.",
+		}, []string{"result"}),
+		updates: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "utte_ui_updates_total",
+			Help: "Updates of the game backend forwarded to websocket clients.",
+		}),
+		streamErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "utte_ui_stream_errors_total",
+			Help: "Failed streams to the game backend of websocket connections.",
+		}),
+		reconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "utte_ui_stream_reconnects_total",
+			Help: "Streams to the game backend reopened after they failed, without closing the websocket.",
+		}),
+	}
+	reg.MustRegister(m.connections, m.connectionsBy, m.updates, m.streamErrors, m.reconnects)
+	return m
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tommzn/go-log"
+	"github.com/tommzn/utte-universe/core"
+	pb "github.com/tommzn/utte-universe/core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Delays between attempts to reconnect to the game backend, doubled after each failed attempt and
+// after each new stream which fails before it receives an update.
+const (
+	reconnectDelay    = 500 * time.Millisecond
+	maxReconnectDelay = 30 * time.Second
+)
+
+// relay is the stream of a websocket client to the game backend. It remembers the subscription of
+// the client and the last update it received, so a new stream resumes where the last one stopped.
+type relay struct {
+	mu         sync.Mutex
+	client     pb.UniverseServiceClient
+	stream     pb.UniverseService_StreamUniverseStateClient
+	subscribed bool
+	paused     bool
+	payload    string        // filter of the client
+	sequence   uint64        // sequence of the last update received
+	delay      time.Duration // delay of the last reconnect, reset once an update is received
+}
+
+// connect opens a stream to the game backend and restores the subscription of the client.
+func (r *relay) connect(ctx context.Context) error {
+	stream, err := r.client.StreamUniverseState(core.InjectTraceContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to open gRPC stream: %w", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stream = stream
+	commands := []*pb.ClientCommand{}
+	switch {
+	case r.subscribed:
+		commands = append(commands, &pb.ClientCommand{Type: pb.ClientCommand_SUBSCRIBE, Payload: r.payload, ResumeFrom: r.sequence})
+	case r.payload != "":
+		commands = append(commands, &pb.ClientCommand{Type: pb.ClientCommand_FILTER, Payload: r.payload})
+	}
+	if r.paused {
+		commands = append(commands, &pb.ClientCommand{Type: pb.ClientCommand_PAUSE})
+	}
+	for _, cmd := range commands {
+		if err := stream.Send(cmd); err != nil {
+			return fmt.Errorf("failed to restore subscription: %w", err)
+		}
+	}
+	return nil
+}
+
+// reconnect opens a new stream, retrying with increasing delays until it succeeds, the context is done
+// or the game backend rejects the stream for good.
+func (r *relay) reconnect(ctx context.Context, logger log.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.nextDelay()):
+		}
+		err := r.connect(ctx)
+		if err == nil || permanent(err) {
+			return err
+		}
+		logger.Errorf("Reconnect to game backend failed: %v", err)
+	}
+}
+
+// nextDelay returns the delay before the next attempt to reconnect, doubling the delay of the last one.
+func (r *relay) nextDelay() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delay = min(max(2*r.delay, reconnectDelay), maxReconnectDelay)
+	return r.delay
+}
+
+// send sends a command of the client to the game backend and remembers it for the next stream.
+func (r *relay) send(cmd *pb.ClientCommand) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch cmd.Type {
+	case pb.ClientCommand_SUBSCRIBE:
+		r.subscribed, r.paused = true, false
+		if cmd.Payload != "" {
+			r.payload = cmd.Payload
+		}
+	case pb.ClientCommand_PAUSE:
+		r.paused = true
+	case pb.ClientCommand_RESUME:
+		r.paused = false
+	case pb.ClientCommand_UNSUBSCRIBE:
+		r.subscribed = false
+	case pb.ClientCommand_FILTER:
+		r.payload = cmd.Payload
+	}
+	return r.stream.Send(cmd)
+}
+
+// recv receives the next update of the current stream.
+func (r *relay) recv() (*pb.UniverseState, error) {
+	r.mu.Lock()
+	stream := r.stream
+	r.mu.Unlock()
+	update, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	r.delay = 0
+	if update.Sequence > 0 {
+		r.sequence = update.Sequence
+	}
+	r.mu.Unlock()
+	return update, nil
+}
+
+// permanent returns true for errors of the game backend which reconnecting doesn't fix, e.g. a
+// rejected token or filter.
+func permanent(err error) bool {
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied, codes.InvalidArgument:
+		return true
+	default:
+		return false
+	}
+}
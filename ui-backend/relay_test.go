@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+	"github.com/tommzn/go-log"
+	pb "github.com/tommzn/utte-universe/core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RelaySuite struct {
+	suite.Suite
+}
+
+func TestRelaySuite(t *testing.T) {
+	suite.Run(t, new(RelaySuite))
+}
+
+func (s *RelaySuite) TestRejectedStreamClosesWebsocket() {
+	client := &gameClient{err: status.Error(codes.Unauthenticated, "token expired")}
+	backend := &UIBBackend{gameClient: client, logger: log.NewLogger(log.LogLevelByName("none"), nil, nil), metrics: newUIMetrics(prometheus.NewRegistry())}
+	server := httptest.NewServer(http.HandlerFunc(backend.handleWebsocket))
+	defer server.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	s.Require().NoError(err)
+	defer ws.Close()
+	ws.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err = ws.ReadMessage()
+
+	closeErr, ok := err.(*websocket.CloseError)
+	s.Require().True(ok, "expected a close frame, got %v", err)
+	s.Equal(websocket.ClosePolicyViolation, closeErr.Code)
+	s.Equal("token expired", closeErr.Text)
+	s.Equal(int32(1), client.streams.Load())
+}
+
+func (s *RelaySuite) TestBackoffIsKeptUntilAnUpdateIsReceived() {
+	r := &relay{stream: &stateStream{updates: []*pb.UniverseState{{Sequence: 7}}}}
+	s.Equal(reconnectDelay, r.nextDelay())
+	s.Equal(2*reconnectDelay, r.nextDelay())
+	for range 10 {
+		r.nextDelay()
+	}
+	s.Equal(maxReconnectDelay, r.nextDelay())
+
+	update, err := r.recv()
+	s.NoError(err)
+	s.Equal(uint64(7), update.Sequence)
+	s.Equal(uint64(7), r.sequence)
+	s.Equal(reconnectDelay, r.nextDelay())
+}
+
+// gameClient opens streams which fail with err once all their updates have been received.
+type gameClient struct {
+	pb.UniverseServiceClient
+	err     error
+	streams atomic.Int32
+}
+
+func (c *gameClient) StreamUniverseState(ctx context.Context, opts ...grpc.CallOption) (pb.UniverseService_StreamUniverseStateClient, error) {
+	c.streams.Add(1)
+	return &stateStream{err: c.err}, nil
+}
+
+type stateStream struct {
+	pb.UniverseService_StreamUniverseStateClient
+	updates []*pb.UniverseState
+	err     error
+}
+
+func (s *stateStream) Send(*pb.ClientCommand) error {
+	return nil
+}
+
+func (s *stateStream) Recv() (*pb.UniverseState, error) {
+	if len(s.updates) == 0 {
+		return nil, s.err
+	}
+	update := s.updates[0]
+	s.updates = s.updates[1:]
+	return update, nil
+}
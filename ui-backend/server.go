@@ -4,31 +4,102 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tommzn/go-log"
+	"github.com/tommzn/utte-universe/core"
 	pb "github.com/tommzn/utte-universe/core/proto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
-var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool { return true },
-}
+// tracer creates spans of websocket connections and their messages.
+var tracer = otel.Tracer("github.com/tommzn/utte-universe/ui-backend")
 
 type UIBBackend struct {
-	gameClient pb.UniverseServiceClient
-	logger     log.Logger
+	conn           *grpc.ClientConn
+	gameClient     pb.UniverseServiceClient
+	logger         log.Logger
+	verifier       *core.TokenVerifier // verifies tokens of websocket clients, authentication is disabled if nil
+	allowedOrigins []string            // origins allowed to open a websocket, besides the ui-backend's own
+	upgrader       websocket.Upgrader
+	metrics        *uiMetrics
 }
 
-func NewUIBackend(addr string, logger log.Logger) (*UIBBackend, error) {
-	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewUIBackend connects to the game backend at passed address, using passed credentials, e.g. TLS.
+// Its metrics are registered at passed registerer.
+func NewUIBackend(addr string, creds credentials.TransportCredentials, verifier *core.TokenVerifier, allowedOrigins []string, reg prometheus.Registerer, logger log.Logger) (*UIBBackend, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial game backend: %w", err)
 	}
 	client := pb.NewUniverseServiceClient(conn)
-	return &UIBBackend{gameClient: client, logger: logger}, nil
+	u := &UIBBackend{conn: conn, gameClient: client, logger: logger, verifier: verifier, allowedOrigins: allowedOrigins, metrics: newUIMetrics(reg)}
+	u.upgrader = websocket.Upgrader{CheckOrigin: u.checkOrigin}
+	return u, nil
+}
+
+// checkBackend fails unless the connection to the game backend is ready. An idle connection, e.g.
+// before the first websocket, is asked to connect and fails until it's ready.
+func (u *UIBBackend) checkBackend() error {
+	state := u.conn.GetState()
+	if state == connectivity.Idle {
+		u.conn.Connect()
+	}
+	if state != connectivity.Ready {
+		return fmt.Errorf("connection to game backend is %v", state)
+	}
+	return nil
+}
+
+// checkOrigin accepts websockets opened by pages of the ui-backend itself or of an allowed origin,
+// "*" allows all origins. Requests without origin aren't made by browsers and are accepted.
+func (u *UIBBackend) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || slices.Contains(u.allowedOrigins, "*") || slices.Contains(u.allowedOrigins, origin) {
+		return true
+	}
+	originURL, err := url.Parse(origin)
+	if err == nil && strings.EqualFold(originURL.Host, r.Host) {
+		return true
+	}
+	u.logger.Errorf("Rejected websocket from origin %s", origin)
+	return false
+}
+
+// authenticate verifies the token of a websocket client, passed as bearer token or, since browsers
+// can't set headers on websockets, as token query parameter. Returns a context propagating the token
+// to the game backend, which verifies it again and identifies the caller by it.
+func (u *UIBBackend) authenticate(ctx context.Context, r *http.Request) (context.Context, error) {
+	if u.verifier == nil {
+		// Players pass their ID to receive their own data, all others watch as spectators.
+		if playerID := r.URL.Query().Get("player"); playerID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, core.PlayerIDHeader, playerID)
+		}
+		return ctx, nil
+	}
+	token := core.BearerToken(r.Header.Get("Authorization"))
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+	identity, err := u.verifier.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	u.logger.Infof("Authenticated websocket client %s with role %v", identity.Subject, identity.Role)
+	return metadata.AppendToOutgoingContext(ctx, core.AuthorizationHeader, "Bearer "+token), nil
 }
 
 func (u *UIBBackend) flushLogsPeriodically(ctx context.Context) {
@@ -46,23 +117,40 @@ func (u *UIBBackend) flushLogsPeriodically(ctx context.Context) {
 
 func (u *UIBBackend) handleWebsocket(w http.ResponseWriter, r *http.Request) {
 
-	ws, err := upgrader.Upgrade(w, r, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// All messages of a connection belong to one trace, which continues in the backend's stream.
+	ctx, span := tracer.Start(ctx, "websocket.connection", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	ctx, err := u.authenticate(ctx, r)
+	if err != nil {
+		u.logger.Errorf("WebSocket authentication failed: %v", err)
+		u.metrics.connectionsBy.WithLabelValues("unauthorized").Inc()
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ws, err := u.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		u.logger.Errorf("WebSocket upgrade failed: %v", err)
+		u.metrics.connectionsBy.WithLabelValues("failed").Inc()
 		http.Error(w, "failed to upgrade", http.StatusBadRequest)
 		return
 	}
 	defer ws.Close()
 	u.logger.Info("WebSocket connection established")
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	u.metrics.connectionsBy.WithLabelValues("accepted").Inc()
+	u.metrics.connections.Inc()
+	defer u.metrics.connections.Dec()
 
 	go u.flushLogsPeriodically(ctx)
 
-	stream, err := u.gameClient.StreamUniverseState(ctx)
-	if err != nil {
+	relay := &relay{client: u.gameClient}
+	if err := relay.connect(ctx); err != nil {
 		u.logger.Errorf("Failed to open gRPC stream: %v", err)
+		u.metrics.streamErrors.Inc()
 		return
 	}
 	u.logger.Info("gRPC stream to backend opened")
@@ -78,41 +166,91 @@ func (u *UIBBackend) handleWebsocket(w http.ResponseWriter, r *http.Request) {
 			}
 			if command, ok := msg["command"]; ok {
 				u.logger.Debugf("Received command from frontend: %s", command)
-				if err := stream.Send(&pb.ClientCommand{Type: commandTypeFromString(command)}); err != nil {
+				_, span := tracer.Start(ctx, "websocket.command", trace.WithAttributes(attribute.String("command", command)))
+				commandType, known := commandTypeFromString(command)
+				if !known {
+					u.logger.Errorf("Ignored unknown command from frontend: %s", command)
+					span.End()
+					continue
+				}
+				// Commands which fail to send are restored on the next stream.
+				if err := relay.send(&pb.ClientCommand{Type: commandType, Payload: msg["payload"]}); err != nil {
 					u.logger.Errorf("Failed to send command to backend: %v", err)
-					cancel()
-					return
+					span.RecordError(err)
 				}
+				span.End()
 			}
 		}
 	}()
 
-	// Forward backend → frontend
+	// Forward backend → frontend, the websocket stays open while the stream to the backend is reconnected.
 	for {
-		update, err := stream.Recv()
+		update, err := relay.recv()
 		if err != nil {
-			u.logger.Errorf("gRPC stream recv error: %v", err)
-			return
+			if ctx.Err() != nil {
+				return
+			}
+			u.metrics.streamErrors.Inc()
+			if permanent(err) {
+				u.logger.Errorf("gRPC stream rejected by backend: %v, closing websocket", err)
+				closeWebsocket(ws, err)
+				return
+			}
+			u.logger.Errorf("gRPC stream recv error: %v, reconnecting", err)
+			if err := relay.reconnect(ctx, u.logger); err != nil {
+				if permanent(err) {
+					u.logger.Errorf("gRPC stream rejected by backend: %v, closing websocket", err)
+					closeWebsocket(ws, err)
+				}
+				return
+			}
+			u.logger.Info("gRPC stream to backend reopened")
+			u.metrics.reconnects.Inc()
+			continue
 		}
 		u.logger.Debug("Sending update to frontend")
+		_, span := tracer.Start(ctx, "websocket.update", trace.WithAttributes(attribute.Int64("sequence", int64(update.Sequence))))
 		if err := ws.WriteJSON(update); err != nil {
 			u.logger.Errorf("WebSocket write error: %v, content: %+v", err, update)
+			span.RecordError(err)
+			span.End()
 			return
 		}
+		span.End()
+		u.metrics.updates.Inc()
 	}
 }
 
-func commandTypeFromString(cmd string) pb.ClientCommand_CommandType {
+// closeWebsocket tells a client why its websocket is closed, after the game backend rejected its stream.
+func closeWebsocket(ws *websocket.Conn, err error) {
+	code := websocket.CloseUnsupportedData
+	if c := status.Code(err); c == codes.Unauthenticated || c == codes.PermissionDenied {
+		code = websocket.ClosePolicyViolation
+	}
+	reason := status.Convert(err).Message()
+	if len(reason) > maxCloseReason {
+		reason = reason[:maxCloseReason]
+	}
+	ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+}
+
+// maxCloseReason is the maximum length of the reason in a close frame.
+const maxCloseReason = 123
+
+// commandTypeFromString converts a command of the frontend, false if it's unknown.
+func commandTypeFromString(cmd string) (pb.ClientCommand_CommandType, bool) {
 	switch cmd {
 	case "SUBSCRIBE":
-		return pb.ClientCommand_SUBSCRIBE
+		return pb.ClientCommand_SUBSCRIBE, true
 	case "PAUSE":
-		return pb.ClientCommand_PAUSE
+		return pb.ClientCommand_PAUSE, true
 	case "RESUME":
-		return pb.ClientCommand_RESUME
+		return pb.ClientCommand_RESUME, true
 	case "UNSUBSCRIBE":
-		return pb.ClientCommand_UNSUBSCRIBE
+		return pb.ClientCommand_UNSUBSCRIBE, true
+	case "FILTER":
+		return pb.ClientCommand_FILTER, true
 	default:
-		return pb.ClientCommand_SUBSCRIBE // or a default/fallback value
+		return pb.ClientCommand_SUBSCRIBE, false
 	}
 }